@@ -0,0 +1,104 @@
+package main
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"typing-game-backend/apierror"
+	"typing-game-backend/privacy"
+)
+
+// requireAdmin は X-Admin-Key ヘッダーを検証し、一致しない場合は 403 を返して false を返す。
+// ADMIN_API_KEY が未設定の場合、管理用エンドポイントはすべて無効
+func (s *server) requireAdmin(c *gin.Context) bool {
+	adminKey := s.cfg.AdminAPIKey
+	if adminKey == "" || subtle.ConstantTimeCompare([]byte(c.GetHeader("X-Admin-Key")), []byte(adminKey)) != 1 {
+		respondError(c, apierror.New(apierror.CodeForbidden))
+		return false
+	}
+	return true
+}
+
+// playerDataRequest はプレイヤーデータの管理用リクエスト。
+// プレイヤー名がURLやアクセスログに残らないよう、ボディで受け取る
+type playerDataRequest struct {
+	PlayerName string `json:"player_name" binding:"required"`
+	Mode       string `json:"mode"` // delete（デフォルト）または anonymize
+}
+
+// exportPlayerData はプレイヤーについて保存されているすべてのデータをJSONで返す
+func (s *server) exportPlayerData(c *gin.Context) {
+	if !s.requireAdmin(c) {
+		return
+	}
+
+	var req playerDataRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, bindError(err))
+		return
+	}
+
+	export, err := s.privacy.Export(c.Request.Context(), req.PlayerName)
+	if err != nil {
+		loggerFrom(c.Request.Context()).Error("Failed to export player data", "player_name", req.PlayerName, "error", err)
+		respondError(c, storageError(err))
+		return
+	}
+
+	loggerFrom(c.Request.Context()).Info("Player data exported", "player_name", req.PlayerName, "scores", len(export.Scores))
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, export)
+}
+
+// erasePlayerData はプレイヤーのデータを削除または匿名化し、検証結果を含むレポートを返す
+func (s *server) erasePlayerData(c *gin.Context) {
+	if !s.requireAdmin(c) {
+		return
+	}
+
+	var req playerDataRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, bindError(err))
+		return
+	}
+	if req.Mode == "" {
+		req.Mode = string(privacy.Delete)
+	}
+	mode, err := privacy.ParseMode(req.Mode)
+	if err != nil {
+		respondError(c, apierror.Validation(apierror.Field("mode", apierror.FieldInvalid)))
+		return
+	}
+
+	report, err := s.privacy.Erase(c.Request.Context(), req.PlayerName, mode, s.logRetentionNote())
+	if err != nil {
+		loggerFrom(c.Request.Context()).Error("Failed to erase player data", "player_name", req.PlayerName, "mode", mode, "error", err)
+		respondError(c, storageError(err))
+		return
+	}
+
+	loggerFrom(c.Request.Context()).Info("Player data erased", "player_name", req.PlayerName, "mode", mode, "verified", report.Verified, "digest", report.Digest)
+
+	status := http.StatusOK
+	if !report.Verified {
+		status = http.StatusInternalServerError
+	}
+	c.Header("Cache-Control", "no-store")
+	c.JSON(status, report)
+}
+
+// logRetentionNote はレポートに含めるログの扱いの説明
+func (s *server) logRetentionNote() string {
+	switch {
+	case s.cfg.Logging.RedactPlayerData && s.cfg.Logging.RedactionKey != "":
+		// 鍵付きハッシュは消去したことにならない（鍵を持つ人は名前から照合できる）ため、そのように伝える
+		return "logs: player names are written to logs only as a keyed hash (HMAC); entries are not erased, " +
+			"and anyone holding LOG_REDACTION_KEY can match this player's name to them until the logs expire"
+	case s.cfg.Logging.RedactPlayerData:
+		return "logs: player names are omitted from logs"
+	}
+	return fmt.Sprintf("logs: player name redaction is disabled in %s; player names may remain in logs until they expire", s.cfg.Environment)
+}
@@ -0,0 +1,214 @@
+// Package apierror はAPIのエラーレスポンスを定義する。
+// エラーは安定したエラーコード・HTTPステータス・項目ごとの詳細を持ち、メッセージはリクエストの言語（jp / en）で返す。
+// 内部エラーの内容（ストレージのエラーなど）はログにだけ出力し、レスポンスには含めない
+package apierror
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Code はエラーの種類を表す安定したコード。クライアントはメッセージではなくこの値で処理を分岐する
+type Code string
+
+const (
+	CodeInvalidRequest      Code = "invalid_request"       // ボディをJSONとして読めない
+	CodeValidationFailed    Code = "validation_failed"     // 項目の検証エラー（Details に項目ごとの理由）
+	CodeForbidden           Code = "forbidden"             // 管理用キーがない・一致しない
+	CodeNotFound            Code = "not_found"             // ルートが存在しない
+	CodeTranslationNotFound Code = "translation_not_found" // 翻訳が登録されていない
+	CodePassageNotFound     Code = "passage_not_found"     // 文章が登録されていない
+	CodeWordListNotFound    Code = "word_list_not_found"   // 共有コードの単語リストが作成されていない
+	CodeRequestTooLarge     Code = "request_too_large"     // リクエストボディが上限を超える
+	CodeStorageUnavailable  Code = "storage_unavailable"   // ストレージの障害・タイムアウト（再試行で解消する可能性がある）
+	CodeInternal            Code = "internal_error"        // その他のサーバー側のエラー
+
+	// プレイヤー名のポリシー違反（names.Code と同じ値）
+	CodeNameEmpty             Code = "name_empty"
+	CodeNameTooLong           Code = "name_too_long"
+	CodeNameInvalidCharacters Code = "name_invalid_characters"
+	CodeNameBlocked           Code = "name_blocked"
+	CodeNameReserved          Code = "name_reserved"
+	CodeNameTaken             Code = "name_taken"
+)
+
+// statuses はエラーコードごとのHTTPステータス
+var statuses = map[Code]int{
+	CodeInvalidRequest:        http.StatusBadRequest,
+	CodeValidationFailed:      http.StatusBadRequest,
+	CodeForbidden:             http.StatusForbidden,
+	CodeNotFound:              http.StatusNotFound,
+	CodeTranslationNotFound:   http.StatusNotFound,
+	CodePassageNotFound:       http.StatusNotFound,
+	CodeWordListNotFound:      http.StatusNotFound,
+	CodeRequestTooLarge:       http.StatusRequestEntityTooLarge,
+	CodeStorageUnavailable:    http.StatusServiceUnavailable,
+	CodeInternal:              http.StatusInternalServerError,
+	CodeNameEmpty:             http.StatusBadRequest,
+	CodeNameTooLong:           http.StatusBadRequest,
+	CodeNameInvalidCharacters: http.StatusBadRequest,
+	CodeNameBlocked:           http.StatusBadRequest,
+	CodeNameReserved:          http.StatusBadRequest,
+	CodeNameTaken:             http.StatusConflict,
+}
+
+// FieldCode は項目ごとの検証エラーの理由
+type FieldCode string
+
+const (
+	FieldRequired   FieldCode = "required"     // 必須の項目がない
+	FieldOutOfRange FieldCode = "out_of_range" // 範囲外（Args: 最小値, 最大値）
+	FieldInvalid    FieldCode = "invalid"      // 許可されていない値・型
+	FieldConflict   FieldCode = "conflict"     // 同時に指定できない項目がある（Args: もう一方の項目名）
+)
+
+// FieldError は1項目の検証エラー
+type FieldError struct {
+	Field string
+	Code  FieldCode
+	Args  []interface{} // メッセージに埋め込む値
+}
+
+// Field は項目の検証エラーを作成する
+func Field(field string, code FieldCode, args ...interface{}) FieldError {
+	return FieldError{Field: field, Code: code, Args: args}
+}
+
+// Error はAPIのエラー。cause は原因となった内部エラーで、ログにだけ出力する
+type Error struct {
+	Code   Code
+	Fields []FieldError
+	Args   []interface{} // メッセージに埋め込む値
+	cause  error
+}
+
+// New はエラーを作成する
+func New(code Code, args ...interface{}) *Error {
+	return &Error{Code: code, Args: args}
+}
+
+// Wrap は内部エラーを原因として持つエラーを作成する
+func Wrap(code Code, cause error) *Error {
+	return &Error{Code: code, cause: cause}
+}
+
+// Validation は項目の検証エラーをまとめた validation_failed エラーを作成する
+func Validation(fields ...FieldError) *Error {
+	return &Error{Code: CodeValidationFailed, Fields: fields}
+}
+
+// As は err が *Error ならそれを、そうでなければ err を原因とする internal_error を返す
+func As(err error) *Error {
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		return apiErr
+	}
+	return Wrap(CodeInternal, err)
+}
+
+func (e *Error) Error() string {
+	var b strings.Builder
+	b.WriteString(string(e.Code))
+	for _, f := range e.Fields {
+		fmt.Fprintf(&b, " %s:%s", f.Field, f.Code)
+	}
+	if e.cause != nil {
+		b.WriteString(": " + e.cause.Error())
+	}
+	return b.String()
+}
+
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// Status はHTTPステータスを返す
+func (e *Error) Status() int {
+	if status, ok := statuses[e.Code]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
+
+// Detail はレスポンスに含める項目ごとの検証エラー
+type Detail struct {
+	Field   string    `json:"field"`
+	Code    FieldCode `json:"code"`
+	Message string    `json:"message"`
+}
+
+// Response はエラーレスポンスのボディ。error には従来どおりメッセージ（文字列）が入る
+type Response struct {
+	Error     string   `json:"error"`
+	Code      Code     `json:"code"`
+	Details   []Detail `json:"details,omitempty"`
+	RequestID string   `json:"request_id,omitempty"`
+}
+
+// Response は language（"jp" / "en"）のメッセージでレスポンスのボディを作成する
+func (e *Error) Response(language string) Response {
+	resp := Response{
+		Error: localize(messages[e.Code], language, e.Args...),
+		Code:  e.Code,
+	}
+	if resp.Error == "" {
+		resp.Error = localize(messages[CodeInternal], language)
+	}
+	for _, f := range e.Fields {
+		args := append([]interface{}{f.Field}, f.Args...)
+		resp.Details = append(resp.Details, Detail{
+			Field:   f.Field,
+			Code:    f.Code,
+			Message: localize(fieldMessages[f.Code], language, args...),
+		})
+	}
+	return resp
+}
+
+// Language はメッセージの言語を language パラメータ（jp / en）、なければ Accept-Language から決める。
+// どちらもない場合は英語
+func Language(r *http.Request) string {
+	switch language := r.URL.Query().Get("language"); language {
+	case "jp", "en":
+		return language
+	}
+
+	best, bestQ := "en", 0.0
+	for _, part := range strings.Split(r.Header.Get("Accept-Language"), ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+				q = parsed
+			}
+		}
+
+		var language string
+		switch primary, _, _ := strings.Cut(strings.ToLower(tag), "-"); primary {
+		case "ja":
+			language = "jp"
+		case "en":
+			language = "en"
+		default:
+			continue
+		}
+		if q > bestQ {
+			best, bestQ = language, q
+		}
+	}
+	return best
+}
+
+func localize(catalog map[string]string, language string, args ...interface{}) string {
+	msg, ok := catalog[language]
+	if !ok {
+		msg = catalog["en"]
+	}
+	if len(args) > 0 && strings.Contains(msg, "%") {
+		return fmt.Sprintf(msg, args...)
+	}
+	return msg
+}
@@ -0,0 +1,89 @@
+package apierror
+
+// messages はエラーコードごとの日本語・英語のメッセージ（Args を書式に埋め込む）
+var messages = map[Code]map[string]string{
+	CodeInvalidRequest: {
+		"jp": "リクエストの形式が正しくありません",
+		"en": "Malformed request",
+	},
+	CodeValidationFailed: {
+		"jp": "入力内容に誤りがあります",
+		"en": "Invalid input",
+	},
+	CodeForbidden: {
+		"jp": "この操作は許可されていません",
+		"en": "Forbidden",
+	},
+	CodeNotFound: {
+		"jp": "見つかりません",
+		"en": "Not found",
+	},
+	CodeTranslationNotFound: {
+		"jp": "翻訳が見つかりません",
+		"en": "Translation not found",
+	},
+	CodePassageNotFound: {
+		"jp": "文章が見つかりません",
+		"en": "Passage not found",
+	},
+	CodeWordListNotFound: {
+		"jp": "単語リストが見つかりません",
+		"en": "Word list not found",
+	},
+	CodeRequestTooLarge: {
+		"jp": "リクエストが大きすぎます",
+		"en": "Request body too large",
+	},
+	CodeStorageUnavailable: {
+		"jp": "一時的に利用できません。しばらくしてからもう一度お試しください",
+		"en": "Service temporarily unavailable, please try again later",
+	},
+	CodeInternal: {
+		"jp": "サーバーでエラーが発生しました",
+		"en": "Internal server error",
+	},
+	CodeNameEmpty: {
+		"jp": "プレイヤー名を入力してください",
+		"en": "Player name is required",
+	},
+	CodeNameTooLong: {
+		"jp": "プレイヤー名は%d文字以内で入力してください",
+		"en": "Player name must be 1-%d characters",
+	},
+	CodeNameInvalidCharacters: {
+		"jp": "プレイヤー名に使用できない文字（制御文字・ゼロ幅文字など）が含まれています",
+		"en": "Player name contains characters that are not allowed (control or zero-width characters)",
+	},
+	CodeNameBlocked: {
+		"jp": "プレイヤー名に不適切な言葉が含まれています",
+		"en": "Player name contains inappropriate words",
+	},
+	CodeNameReserved: {
+		"jp": "このプレイヤー名は予約されているため使用できません",
+		"en": "This player name is reserved",
+	},
+	CodeNameTaken: {
+		"jp": "このプレイヤー名は他のプレイヤーの名前と紛らわしいため使用できません",
+		"en": "This player name is too similar to another player's name",
+	},
+}
+
+// fieldMessages は項目ごとの検証エラーのメッセージ（最初の %s は項目名）
+var fieldMessages = map[FieldCode]map[string]string{
+	FieldRequired: {
+		"jp": "%s は必須です",
+		"en": "%s is required",
+	},
+	FieldOutOfRange: {
+		"jp": "%s は %v〜%v の範囲で指定してください",
+		"en": "%s must be between %v and %v",
+	},
+	FieldInvalid: {
+		"jp": "%s の値が正しくありません",
+		"en": "%s is invalid",
+	},
+	FieldConflict: {
+		"jp": "%s は %s と同時に指定できません",
+		"en": "%s cannot be combined with %s",
+	},
+}
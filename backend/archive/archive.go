@@ -0,0 +1,192 @@
+// Package archive はDynamoDBの項目を gzip 圧縮したJSONLファイルに書き出す・読み込むための共通処理
+// （アーカイブ・エクスポート用コマンドで使用）
+package archive
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// JSONLWriter は1行1項目のJSONを gzip で圧縮して書き出す。
+// 書き込み中は一時ファイルに出力し、Close で最終的なパスへ移動するため、
+// 途中で中断しても不完全なファイルが残らない
+type JSONLWriter struct {
+	path  string
+	tmp   *os.File
+	buf   *bufio.Writer
+	gz    *gzip.Writer
+	enc   *json.Encoder
+	count int
+}
+
+// Create は path（通常は .jsonl.gz）に書き出す JSONLWriter を作成する
+func Create(path string) (*JSONLWriter, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create directory for %s: %w", path, err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", path, err)
+	}
+
+	buf := bufio.NewWriter(tmp)
+	gz := gzip.NewWriter(buf)
+	return &JSONLWriter{path: path, tmp: tmp, buf: buf, gz: gz, enc: json.NewEncoder(gz)}, nil
+}
+
+// WriteItem はDynamoDBの項目を1行として書き出す
+func (w *JSONLWriter) WriteItem(item map[string]types.AttributeValue) error {
+	var record map[string]interface{}
+	if err := attributevalue.UnmarshalMap(item, &record); err != nil {
+		return fmt.Errorf("failed to unmarshal item: %w", err)
+	}
+	return w.Write(record)
+}
+
+// Write は任意の値を1行として書き出す
+func (w *JSONLWriter) Write(record interface{}) error {
+	if err := w.enc.Encode(record); err != nil {
+		return fmt.Errorf("failed to write %s: %w", w.path, err)
+	}
+	w.count++
+	return nil
+}
+
+// Count は書き出した行数を返す
+func (w *JSONLWriter) Count() int {
+	return w.count
+}
+
+// Close はファイルを書き切って最終的なパスに移動する
+func (w *JSONLWriter) Close() error {
+	err := errors.Join(w.gz.Close(), w.buf.Flush(), w.tmp.Sync(), w.tmp.Close())
+	if err != nil {
+		os.Remove(w.tmp.Name())
+		return fmt.Errorf("failed to write %s: %w", w.path, err)
+	}
+	if err := os.Rename(w.tmp.Name(), w.path); err != nil {
+		os.Remove(w.tmp.Name())
+		return fmt.Errorf("failed to rename %s: %w", w.path, err)
+	}
+	return nil
+}
+
+// Abort は書き込みを中止して一時ファイルを削除する
+func (w *JSONLWriter) Abort() {
+	w.tmp.Close()
+	os.Remove(w.tmp.Name())
+}
+
+// ReadJSONL は Create で書き出したファイルを1行ずつ読み込み、fn に渡す
+func ReadJSONL(path string, fn func(line []byte) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	defer gz.Close()
+
+	scanner := bufio.NewScanner(gz)
+	scanner.Buffer(make([]byte, 0, 64<<10), 4<<20)
+	for scanner.Scan() {
+		if err := fn(scanner.Bytes()); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return nil
+}
+
+// KeyValue はページングのキー（LastEvaluatedKey）の属性値。キーに使われる文字列・数値のみを扱う
+type KeyValue struct {
+	S *string `json:"S,omitempty"`
+	N *string `json:"N,omitempty"`
+}
+
+// EncodeKey は LastEvaluatedKey をJSONで保存できる形に変換する
+func EncodeKey(key map[string]types.AttributeValue) (map[string]KeyValue, error) {
+	if len(key) == 0 {
+		return nil, nil
+	}
+
+	encoded := make(map[string]KeyValue, len(key))
+	for name, value := range key {
+		switch v := value.(type) {
+		case *types.AttributeValueMemberS:
+			encoded[name] = KeyValue{S: &v.Value}
+		case *types.AttributeValueMemberN:
+			encoded[name] = KeyValue{N: &v.Value}
+		default:
+			return nil, fmt.Errorf("unsupported key attribute type %T for %s", value, name)
+		}
+	}
+	return encoded, nil
+}
+
+// DecodeKey は EncodeKey で保存したキーを ExclusiveStartKey に戻す
+func DecodeKey(encoded map[string]KeyValue) map[string]types.AttributeValue {
+	if len(encoded) == 0 {
+		return nil
+	}
+
+	key := make(map[string]types.AttributeValue, len(encoded))
+	for name, value := range encoded {
+		if value.S != nil {
+			key[name] = &types.AttributeValueMemberS{Value: *value.S}
+		} else if value.N != nil {
+			key[name] = &types.AttributeValueMemberN{Value: *value.N}
+		}
+	}
+	return key
+}
+
+// LoadState はJSONの状態ファイルを読み込む。ファイルがない場合は state をそのままにして false を返す
+func LoadState(path string, state interface{}) (bool, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return false, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return true, nil
+}
+
+// SaveState は状態ファイルを一時ファイル経由で書き換える
+func SaveState(path string, state interface{}) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", path, err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", path, err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to rename %s: %w", path, err)
+	}
+	return nil
+}
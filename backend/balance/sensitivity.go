@@ -0,0 +1,93 @@
+package balance
+
+import (
+	"fmt"
+	"math"
+)
+
+// Parameter はシミュレーションの1つの値を factor 倍にする変更
+type Parameter struct {
+	Name  string
+	apply func(s *Simulation, factor float64)
+}
+
+// Parameters は感度を求めるパラメータ（ルールセットの値と合成プレイヤーの特性）
+var Parameters = []Parameter{
+	{"special_words.chance", func(s *Simulation, f float64) { s.Rules.Special.Chance = math.Min(1, s.Rules.Special.Chance*f) }},
+	{"special_words.bonus_share", func(s *Simulation, f float64) {
+		s.Rules.Special.BonusShare = math.Min(1, s.Rules.Special.BonusShare*f)
+	}},
+	{"combo.score_base", func(s *Simulation, f float64) { s.Rules.Combo.ScoreBase *= f }},
+	{"combo.score_exponent", func(s *Simulation, f float64) { s.Rules.Combo.ScoreExponent *= f }},
+	{"combo.damage_step", func(s *Simulation, f float64) { s.Rules.Combo.DamageStep *= f }},
+	{"combo.max_multiplier", func(s *Simulation, f float64) {
+		s.Rules.Combo.MaxMultiplier = math.Max(1, s.Rules.Combo.MaxMultiplier*f)
+	}},
+	{"mistake_damage", func(s *Simulation, f float64) { s.Rules.MistakeDamage = scale(s.Rules.MistakeDamage, f) }},
+	{"round_clear_heal", func(s *Simulation, f float64) { s.Rules.RoundClearHeal = scale(s.Rules.RoundClearHeal, f) }},
+	{"enemies.max_hp", func(s *Simulation, f float64) {
+		for i := range s.Rules.Enemies {
+			s.Rules.Enemies[i].MaxHP = max(1, scale(s.Rules.Enemies[i].MaxHP, f))
+		}
+	}},
+	{"enemies.time_limit_seconds", func(s *Simulation, f float64) {
+		for i := range s.Rules.Enemies {
+			s.Rules.Enemies[i].TimeLimitSeconds = max(1, scale(s.Rules.Enemies[i].TimeLimitSeconds, f))
+		}
+	}},
+	{"player.wpm", func(s *Simulation, f float64) { s.Player.WPM = math.Max(minWPM, s.Player.WPM*f) }},
+	// 正確さは誤りの率（1 - accuracy）を変える
+	{"player.error_rate", func(s *Simulation, f float64) { s.Player.Accuracy = math.Max(0.01, 1-(1-s.Player.Accuracy)*f) }},
+}
+
+func scale(v int, f float64) int {
+	return int(math.Round(float64(v) * f))
+}
+
+// Sensitivity はパラメータを変えたときの結果の変化
+type Sensitivity struct {
+	Parameter string  `json:"parameter"`
+	Change    float64 `json:"change"` // 変化率（0.1 は +10%）
+	ClearRate float64 `json:"clear_rate"`
+	// ClearRateDelta・MeanScoreDelta は変更前の結果との差
+	ClearRateDelta float64 `json:"clear_rate_delta"`
+	MeanScore      float64 `json:"mean_score"`
+	MeanScoreDelta float64 `json:"mean_score_delta"`
+	// WinRates はラウンドごとの勝率
+	WinRates []float64 `json:"win_rates"`
+}
+
+// Sensitivities は各パラメータを ±change 変えて同じ Seed で実行し、base（変更前の結果）との差を返す
+func (s Simulation) Sensitivities(base Summary, change float64) ([]Sensitivity, error) {
+	var results []Sensitivity
+	for _, p := range Parameters {
+		for _, c := range []float64{-change, change} {
+			variant := s
+			variant.Rules = s.Rules.Clone()
+			p.apply(&variant, 1+c)
+
+			summary, err := variant.Run()
+			if err != nil {
+				return nil, fmt.Errorf("%s %+.0f%%: %w", p.Name, c*100, err)
+			}
+			results = append(results, Sensitivity{
+				Parameter:      p.Name,
+				Change:         c,
+				ClearRate:      summary.ClearRate,
+				ClearRateDelta: summary.ClearRate - base.ClearRate,
+				MeanScore:      summary.Score.Mean,
+				MeanScoreDelta: summary.Score.Mean - base.Score.Mean,
+				WinRates:       winRates(summary.Rounds),
+			})
+		}
+	}
+	return results, nil
+}
+
+func winRates(rounds []RoundStats) []float64 {
+	rates := make([]float64, len(rounds))
+	for i, r := range rounds {
+		rates[i] = r.WinRate
+	}
+	return rates
+}
@@ -0,0 +1,261 @@
+// Package balance は合成プレイヤーにゲームのルール（game パッケージ）をプレイさせるモンテカルロシミュレーションで、
+// ラウンドごとの勝率・スコアの分布・各パラメータへの感度を求める（cmd/simulate-balance で使用）
+package balance
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+
+	"typing-game-backend/game"
+)
+
+// minWPM は WPM を正規分布で引いたときの下限（0以下にならないようにする）
+const minWPM = 5
+
+// Player は合成プレイヤーの入力の特性
+type Player struct {
+	// WPM は1分あたりの語数（5打鍵を1語とする）の平均
+	WPM float64 `json:"wpm"`
+	// WPMStdDev はゲームごとの WPM のばらつき（標準偏差）
+	WPMStdDev float64 `json:"wpm_stddev"`
+	// WordJitter は単語ごとの入力時間のばらつき（変動係数）
+	WordJitter float64 `json:"word_jitter"`
+	// Accuracy は1打鍵ごとに正しく打つ確率
+	Accuracy float64 `json:"accuracy"`
+	// Correction は打ち間違いに送信前に気づいて直す確率。直さなかった単語は不正解（ミス）になり、もう一度入力する
+	Correction float64 `json:"correction"`
+	// ReactionSeconds は単語が表示されてから打ち始めるまでの時間
+	ReactionSeconds float64 `json:"reaction_seconds"`
+}
+
+// Validate はプレイヤーの値を検証する
+func (p Player) Validate() error {
+	var errs []error
+	if p.WPM < minWPM {
+		errs = append(errs, fmt.Errorf("wpm must be at least %d", minWPM))
+	}
+	if p.WPMStdDev < 0 || p.WordJitter < 0 || p.ReactionSeconds < 0 {
+		errs = append(errs, errors.New("wpm_stddev, word_jitter and reaction_seconds must not be negative"))
+	}
+	if p.Accuracy <= 0 || p.Accuracy > 1 || p.Correction < 0 || p.Correction > 1 {
+		errs = append(errs, errors.New("accuracy must be in (0, 1] and correction in [0, 1]"))
+	}
+	return errors.Join(errs...)
+}
+
+// Simulation はシミュレーションの条件
+type Simulation struct {
+	Rules  *game.Ruleset
+	Player Player
+	Words  Words
+	Games  int
+	// Seed が同じなら同じ結果になる。感度の計算では同じ Seed で値だけを変えて比較する
+	Seed int64
+}
+
+// RoundStats はラウンドごとの結果
+type RoundStats struct {
+	Round   int `json:"round"`
+	Reached int `json:"reached"` // このラウンドを始めたゲーム数
+	Won     int `json:"won"`
+	// WinRate はこのラウンドを始めたゲームのうち敵を倒した割合
+	WinRate  float64 `json:"win_rate"`
+	Timeouts int     `json:"timeouts"`
+	Defeats  int     `json:"defeats"` // HPが0になった
+}
+
+// Distribution は値の分布
+type Distribution struct {
+	Mean   float64 `json:"mean"`
+	StdDev float64 `json:"stddev"`
+	Min    float64 `json:"min"`
+	P10    float64 `json:"p10"`
+	P25    float64 `json:"p25"`
+	P50    float64 `json:"p50"`
+	P75    float64 `json:"p75"`
+	P90    float64 `json:"p90"`
+	Max    float64 `json:"max"`
+}
+
+// Summary はシミュレーションの結果
+type Summary struct {
+	Games int `json:"games"`
+	// ClearRate は最終ラウンドの敵まで倒したゲームの割合
+	ClearRate float64      `json:"clear_rate"`
+	Rounds    []RoundStats `json:"rounds"`
+	Score     Distribution `json:"score"`
+	// Breakdown はスコアの内訳の平均
+	Breakdown   map[string]float64 `json:"breakdown"`
+	WordsPerSec float64            `json:"words_per_second"` // 入力できた単語数 / 経過時間
+	MaxCombo    Distribution       `json:"max_combo"`
+}
+
+// Run はシミュレーションを実行する
+func (s Simulation) Run() (Summary, error) {
+	if s.Games < 1 {
+		return Summary{}, errors.New("games must be at least 1")
+	}
+	if err := s.Player.Validate(); err != nil {
+		return Summary{}, err
+	}
+	if err := s.Rules.Validate(); err != nil {
+		return Summary{}, err
+	}
+	if err := s.Words.check(s.Rules); err != nil {
+		return Summary{}, err
+	}
+
+	rng := rand.New(rand.NewSource(s.Seed))
+	rounds := make([]RoundStats, s.Rules.Rounds())
+	for i := range rounds {
+		rounds[i].Round = i + 1
+	}
+	scores := make([]float64, 0, s.Games)
+	combos := make([]float64, 0, s.Games)
+	var breakdown game.ScoreBreakdown
+	var words, seconds int
+	cleared := 0
+
+	for i := 0; i < s.Games; i++ {
+		state, outcome := s.play(rng)
+		for r := 1; r <= state.Round; r++ {
+			rounds[r-1].Reached++
+			if r < state.Round || state.Phase == game.PhaseWon {
+				rounds[r-1].Won++
+			}
+		}
+		switch outcome {
+		case game.OutcomeTimeout:
+			rounds[state.Round-1].Timeouts++
+		case game.OutcomePlayerDefeated:
+			rounds[state.Round-1].Defeats++
+		}
+		if state.Phase == game.PhaseWon {
+			cleared++
+		}
+		scores = append(scores, float64(state.Score.Total))
+		combos = append(combos, float64(state.MaxCombo))
+		breakdown = breakdown.Add(state.Score)
+		words += state.TotalWords
+		seconds += state.Elapsed
+	}
+
+	for i := range rounds {
+		if rounds[i].Reached > 0 {
+			rounds[i].WinRate = float64(rounds[i].Won) / float64(rounds[i].Reached)
+		}
+	}
+	games := float64(s.Games)
+	summary := Summary{
+		Games:     s.Games,
+		ClearRate: float64(cleared) / games,
+		Rounds:    rounds,
+		Score:     distribution(scores),
+		Breakdown: map[string]float64{
+			"base":    float64(breakdown.Base) / games,
+			"combo":   float64(breakdown.Combo) / games,
+			"special": float64(breakdown.Special) / games,
+			"time":    float64(breakdown.Time) / games,
+		},
+		MaxCombo: distribution(combos),
+	}
+	if seconds > 0 {
+		summary.WordsPerSec = float64(words) / float64(seconds)
+	}
+	return summary, nil
+}
+
+// play は1ゲームをプレイし、最後の状態と決着の種類を返す
+func (s Simulation) play(rng *rand.Rand) (game.State, game.Outcome) {
+	p := s.Player
+	wpm := math.Max(minWPM, p.WPM+rng.NormFloat64()*p.WPMStdDev)
+	secondsPerKey := 60 / (wpm * 5)
+
+	b := game.NewBattle(s.Rules)
+	for {
+		b.Apply(game.RoundStart())
+		words := newPicker(s.Words[b.State().Round], s.Rules.Special)
+		// clock はラウンド開始からの経過時間、ticked は Tick で経過させた秒数
+		clock, ticked := 0.0, 0
+
+		for b.State().Phase == game.PhasePlaying {
+			word, t := words.pick(rng)
+			keys := Keystrokes(word) + 1 // 確定の Enter を含む
+
+			for {
+				d := p.ReactionSeconds + float64(keys)*secondsPerKey*math.Max(0.2, 1+rng.NormFloat64()*p.WordJitter)
+				wrong := false
+				for k := 0; k < keys; k++ {
+					if rng.Float64() < p.Accuracy {
+						continue
+					}
+					if rng.Float64() < p.Correction {
+						d += 2 * secondsPerKey // BackSpace と打ち直し
+					} else {
+						wrong = true
+					}
+				}
+				clock += d
+
+				// 入力中に過ぎた時間を経過させる（時間切れならラウンドは終わる）
+				if elapsed := int(clock) - ticked; elapsed > 0 {
+					ticked += elapsed
+					step, _ := b.Apply(game.Tick(elapsed))
+					if step.Outcome == game.OutcomeTimeout {
+						return b.State(), step.Outcome
+					}
+				}
+
+				if !wrong {
+					b.Apply(game.WordCompleted(t))
+					break
+				}
+				step, _ := b.Apply(game.Mistake())
+				if step.Outcome == game.OutcomePlayerDefeated {
+					return b.State(), step.Outcome
+				}
+			}
+		}
+		if b.State().Phase == game.PhaseWon {
+			return b.State(), game.OutcomeNone
+		}
+	}
+}
+
+// distribution は値の平均・標準偏差・パーセンタイルを求める
+func distribution(values []float64) Distribution {
+	if len(values) == 0 {
+		return Distribution{}
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	var sum, sq float64
+	for _, v := range sorted {
+		sum += v
+	}
+	mean := sum / float64(len(sorted))
+	for _, v := range sorted {
+		sq += (v - mean) * (v - mean)
+	}
+
+	// 最も近い順位（nearest-rank）のパーセンタイル
+	percentile := func(p float64) float64 {
+		i := int(math.Ceil(p*float64(len(sorted)))) - 1
+		return sorted[max(0, min(i, len(sorted)-1))]
+	}
+	return Distribution{
+		Mean:   mean,
+		StdDev: math.Sqrt(sq / float64(len(sorted))),
+		Min:    sorted[0],
+		P10:    percentile(0.10),
+		P25:    percentile(0.25),
+		P50:    percentile(0.50),
+		P75:    percentile(0.75),
+		P90:    percentile(0.90),
+		Max:    sorted[len(sorted)-1],
+	}
+}
@@ -0,0 +1,125 @@
+package balance
+
+import (
+	"reflect"
+	"testing"
+
+	"typing-game-backend/game"
+)
+
+func testSimulation(t *testing.T, player Player) Simulation {
+	t.Helper()
+	rules, err := game.Builtin("v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	words := Words{}
+	for round := 1; round <= rules.Rounds(); round++ {
+		for _, w := range []string{"ねこ", "いぬ", "さかな", "とり", "うさぎ", "りんご"} {
+			words.Add(round, game.Normal, w)
+		}
+		words.Add(round, game.Bonus, "ぼーなす")
+		words.Add(round, game.Debuff, "でばふ")
+	}
+	return Simulation{Rules: rules, Player: player, Words: words, Games: 300, Seed: 7}
+}
+
+var average = Player{WPM: 40, WPMStdDev: 8, WordJitter: 0.25, Accuracy: 0.95, Correction: 0.8, ReactionSeconds: 0.5}
+
+func TestRunIsDeterministic(t *testing.T) {
+	sim := testSimulation(t, average)
+	a, err := sim.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := sim.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(a, b) {
+		t.Errorf("same seed gave different results:\n%+v\n%+v", a, b)
+	}
+	if a.Rounds[0].Reached != sim.Games {
+		t.Errorf("round 1 reached = %d, want %d", a.Rounds[0].Reached, sim.Games)
+	}
+}
+
+func TestBetterPlayerClearsMore(t *testing.T) {
+	slow := average
+	slow.WPM, slow.Accuracy = 15, 0.85
+	fast := average
+	fast.WPM, fast.Accuracy = 90, 0.99
+
+	slowSummary, err := testSimulation(t, slow).Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fastSummary, err := testSimulation(t, fast).Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fastSummary.ClearRate <= slowSummary.ClearRate {
+		t.Errorf("clear rate: fast %.3f <= slow %.3f", fastSummary.ClearRate, slowSummary.ClearRate)
+	}
+	if fastSummary.Score.Mean <= slowSummary.Score.Mean {
+		t.Errorf("mean score: fast %.0f <= slow %.0f", fastSummary.Score.Mean, slowSummary.Score.Mean)
+	}
+}
+
+func TestSensitivities(t *testing.T) {
+	sim := testSimulation(t, average)
+	sim.Games = 100
+	base, err := sim.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	maxHP := sim.Rules.Enemies[0].MaxHP
+
+	results, err := sim.Sensitivities(base, 0.2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2*len(Parameters) {
+		t.Fatalf("got %d results, want %d", len(results), 2*len(Parameters))
+	}
+	if sim.Rules.Enemies[0].MaxHP != maxHP {
+		t.Errorf("sensitivities changed the original ruleset")
+	}
+	for _, r := range results {
+		if r.Parameter == "player.wpm" && r.Change > 0 && r.MeanScoreDelta <= 0 {
+			t.Errorf("faster typing lowered the mean score: %+v", r)
+		}
+	}
+}
+
+func TestRunErrors(t *testing.T) {
+	sim := testSimulation(t, average)
+	delete(sim.Words, 2)
+	if _, err := sim.Run(); err == nil {
+		t.Error("missing words for round 2: want error")
+	}
+
+	sim = testSimulation(t, Player{WPM: 1, Accuracy: 2})
+	if _, err := sim.Run(); err == nil {
+		t.Error("invalid player: want error")
+	}
+}
+
+func TestKeystrokes(t *testing.T) {
+	tests := []struct {
+		word string
+		want int
+	}{
+		{"ねこ", 4},   // ne ko
+		{"あい", 2},   // a i
+		{"きゃっと", 6}, // kya t to
+		{"らーめん", 7}, // ra - me nn
+		{"コーヒー", 6}, // ko - hi -
+		{"cat", 3},
+	}
+	for _, tt := range tests {
+		if got := Keystrokes(tt.word); got != tt.want {
+			t.Errorf("Keystrokes(%q) = %d, want %d", tt.word, got, tt.want)
+		}
+	}
+}
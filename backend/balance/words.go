@@ -0,0 +1,115 @@
+package balance
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"typing-game-backend/game"
+)
+
+// Pool はラウンドの出題単語（種類ごと）
+type Pool struct {
+	Normal []string
+	Bonus  []string
+	Debuff []string
+}
+
+// Words はラウンド（1から）ごとの出題単語
+type Words map[int]*Pool
+
+// Add は単語を種類に応じてラウンドの出題単語に追加する。未知の種類は通常の単語として扱う
+func (w Words) Add(round int, t game.WordType, word string) {
+	p, ok := w[round]
+	if !ok {
+		p = &Pool{}
+		w[round] = p
+	}
+	switch t {
+	case game.Bonus:
+		p.Bonus = append(p.Bonus, word)
+	case game.Debuff:
+		p.Debuff = append(p.Debuff, word)
+	default:
+		p.Normal = append(p.Normal, word)
+	}
+}
+
+// check はルールセットのすべてのラウンドに通常の単語があるかを確認する
+func (w Words) check(rules *game.Ruleset) error {
+	for round := 1; round <= rules.Rounds(); round++ {
+		if p, ok := w[round]; !ok || len(p.Normal) == 0 {
+			return fmt.Errorf("no normal words for round %d", round)
+		}
+	}
+	return nil
+}
+
+// deck は重複なしで単語を引く山札。すべて引いたら混ぜ直す（フロントエンドの usedWords と同じ）
+type deck struct {
+	words []string
+	next  int
+}
+
+func (d *deck) draw(rng *rand.Rand) string {
+	if d.next == 0 {
+		rng.Shuffle(len(d.words), func(i, j int) { d.words[i], d.words[j] = d.words[j], d.words[i] })
+	}
+	w := d.words[d.next]
+	d.next = (d.next + 1) % len(d.words)
+	return w
+}
+
+// picker はフロントエンドの generateRandomWordFromList と同じ確率で単語を選ぶ
+type picker struct {
+	special               game.SpecialWords
+	normal, bonus, debuff *deck
+}
+
+func newPicker(p *Pool, special game.SpecialWords) *picker {
+	newDeck := func(words []string) *deck {
+		if len(words) == 0 {
+			return nil
+		}
+		// 山札を混ぜるため、呼び出し元の単語リストとは別にする
+		return &deck{words: append([]string(nil), words...)}
+	}
+	return &picker{special: special, normal: newDeck(p.Normal), bonus: newDeck(p.Bonus), debuff: newDeck(p.Debuff)}
+}
+
+func (p *picker) pick(rng *rand.Rand) (string, game.WordType) {
+	// 特殊単語は出題できる場合だけ Chance の確率で選び、そのうち BonusShare をボーナス単語にする
+	if (p.bonus != nil || p.debuff != nil) && rng.Float64() < p.special.Chance {
+		bonus := rng.Float64() < p.special.BonusShare
+		if bonus && p.bonus != nil || p.debuff == nil {
+			return p.bonus.draw(rng), game.Bonus
+		}
+		return p.debuff.draw(rng), game.Debuff
+	}
+	return p.normal.draw(rng), game.Normal
+}
+
+// smallKana は直前のかなと合わせて拗音などになる小書きのかな（きゃ = kya で1打鍵増える）
+const smallKana = "ぁぃぅぇぉゃゅょゎァィゥェォャュョヮ"
+
+// vowels は1打鍵で入力できるかな
+const vowels = "あいうえおアイウエオ"
+
+// Keystrokes はローマ字入力での単語の打鍵数のおおよその値を返す。
+// かなは2打鍵（母音・長音・促音・小書きのかなは1打鍵、「ん」は nn の2打鍵）、それ以外の文字は1打鍵として数える
+func Keystrokes(word string) int {
+	n := 0
+	for _, r := range word {
+		switch {
+		case r == 'ー', r == 'っ', r == 'ッ':
+			n++
+		case strings.ContainsRune(smallKana, r), strings.ContainsRune(vowels, r):
+			n++
+		case (r >= 'ぁ' && r <= 'ゖ') || (r >= 'ァ' && r <= 'ヺ'):
+			n += 2
+		default:
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,284 @@
+package main
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"typing-game-backend/apierror"
+	"typing-game-backend/passage"
+	"typing-game-backend/wordlist"
+)
+
+// 単語・翻訳・カテゴリーはめったに変わらないため、DynamoDBの前にプロセス内キャッシュを置く
+// （TTLと件数は config.CacheConfig で設定）
+const categoriesCacheSize = 8
+
+// passagesCacheSize は文章のキャッシュの上限（言語ごとの一覧と、ID ごとの文章）
+const passagesCacheSize = 128
+
+// wordListsCacheSize はカスタム単語リストのキャッシュの上限（リストは作成後に変わらない）
+const wordListsCacheSize = 256
+
+// ttlCache はTTLと最大件数（LRUで追い出し）を持つスレッドセーフなキャッシュ
+type ttlCache[V any] struct {
+	mu       sync.Mutex
+	ttl      time.Duration
+	maxItems int
+	order    *list.List // 先頭が最近使われたエントリ
+	items    map[string]*list.Element
+}
+
+type cacheEntry[V any] struct {
+	key       string
+	value     V
+	expiresAt time.Time
+}
+
+func newTTLCache[V any](maxItems int, ttl time.Duration) *ttlCache[V] {
+	return &ttlCache[V]{
+		ttl:      ttl,
+		maxItems: maxItems,
+		order:    list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+// Get は有効期限内のエントリを返す。期限切れのエントリは GetStale 用に残し、LRUで追い出す
+func (c *ttlCache[V]) Get(key string) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var zero V
+	elem, ok := c.items[key]
+	if !ok {
+		return zero, false
+	}
+
+	entry := elem.Value.(*cacheEntry[V])
+	if time.Now().After(entry.expiresAt) {
+		return zero, false
+	}
+
+	c.order.MoveToFront(elem)
+	return entry.value, true
+}
+
+// GetStale は有効期限に関係なくエントリを返す（ストレージ障害時の縮退用）
+func (c *ttlCache[V]) GetStale(key string) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var zero V
+	elem, ok := c.items[key]
+	if !ok {
+		return zero, false
+	}
+	return elem.Value.(*cacheEntry[V]).value, true
+}
+
+func (c *ttlCache[V]) Set(key string, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiresAt := time.Now().Add(c.ttl)
+	if elem, ok := c.items[key]; ok {
+		entry := elem.Value.(*cacheEntry[V])
+		entry.value = value
+		entry.expiresAt = expiresAt
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.items[key] = c.order.PushFront(&cacheEntry[V]{key: key, value: value, expiresAt: expiresAt})
+
+	for c.order.Len() > c.maxItems {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.items, oldest.Value.(*cacheEntry[V]).key)
+	}
+}
+
+func (c *ttlCache[V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.order.Init()
+	c.items = make(map[string]*list.Element)
+}
+
+func (c *ttlCache[V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+// cachedFetchWords はキャッシュを経由して fetchWords を呼び出す
+func (s *server) cachedFetchWords(ctx context.Context, category string, round int, language string) ([]WordItem, error) {
+	key := wordLookupKey(category, language, round)
+	if words, ok := s.wordsCache.Get(key); ok {
+		return words, nil
+	}
+
+	words, err := s.store.fetchWords(ctx, category, round, language)
+	if err != nil {
+		return nil, err
+	}
+
+	s.wordsCache.Set(key, words)
+	return words, nil
+}
+
+// degradedWords は単語を取得できなかったときの代替を返す。
+// 期限切れでもキャッシュがあればそれを、なければフォールバック単語を使う
+func (s *server) degradedWords(category string, round int, language string) ([]WordItem, string) {
+	if words, ok := s.wordsCache.GetStale(wordLookupKey(category, language, round)); ok {
+		return words, "stale"
+	}
+	return fallbackWords(category, round, language), "fallback"
+}
+
+// cachedFetchTranslation はキャッシュを経由して fetchTranslation を呼び出す
+func (s *server) cachedFetchTranslation(ctx context.Context, wordID, targetLanguage string) (string, error) {
+	key := wordID + "#" + targetLanguage
+	if translation, ok := s.translationsCache.Get(key); ok {
+		return translation, nil
+	}
+
+	translation, err := s.store.fetchTranslation(ctx, wordID, targetLanguage)
+	if err != nil {
+		return "", err
+	}
+
+	s.translationsCache.Set(key, translation)
+	return translation, nil
+}
+
+// cachedFetchPassages はキャッシュを経由して fetchPassages を呼び出す
+func (s *server) cachedFetchPassages(ctx context.Context, language string) ([]passage.Passage, error) {
+	if passages, ok := s.passageListCache.Get(language); ok {
+		return passages, nil
+	}
+
+	passages, err := s.store.fetchPassages(ctx, language)
+	if err != nil {
+		return nil, err
+	}
+
+	s.passageListCache.Set(language, passages)
+	return passages, nil
+}
+
+// cachedFetchPassage はキャッシュを経由して fetchPassage を呼び出す（登録されていない文章はキャッシュしない）
+func (s *server) cachedFetchPassage(ctx context.Context, passageID string) (*passage.Passage, error) {
+	if p, ok := s.passageCache.Get(passageID); ok {
+		return p, nil
+	}
+
+	p, err := s.store.fetchPassage(ctx, passageID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.passageCache.Set(passageID, p)
+	return p, nil
+}
+
+// cachedFetchWordList はキャッシュを経由して fetchWordList を呼び出す（作成されていないリストはキャッシュしない）
+func (s *server) cachedFetchWordList(ctx context.Context, listID string) (*wordlist.List, error) {
+	if l, ok := s.wordListCache.Get(listID); ok {
+		return l, nil
+	}
+
+	l, err := s.store.fetchWordList(ctx, listID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.wordListCache.Set(listID, l)
+	return l, nil
+}
+
+// cachedCategories はキャッシュを経由してカテゴリー一覧を返す
+func (s *server) cachedCategories(language string) []map[string]interface{} {
+	if categories, ok := s.categoriesCache.Get(language); ok {
+		return categories
+	}
+
+	categories := categoryRegistry(language)
+	s.categoriesCache.Set(language, categories)
+	return categories
+}
+
+// invalidateContentCaches はコンテンツ（単語・翻訳・カテゴリー・文章・単語リスト）のキャッシュをすべて破棄する
+func (s *server) invalidateContentCaches() {
+	s.wordsCache.Purge()
+	s.translationsCache.Purge()
+	s.categoriesCache.Purge()
+	s.passageListCache.Purge()
+	s.passageCache.Purge()
+	s.wordListCache.Purge()
+	slog.Info("Content caches invalidated")
+}
+
+// invalidateCache はコンテンツ管理スクリプトが書き込み後に呼び出すフック
+// ADMIN_API_KEY が未設定の場合は無効
+func (s *server) invalidateCache(c *gin.Context) {
+	if !s.requireAdmin(c) {
+		return
+	}
+
+	s.invalidateContentCaches()
+
+	c.JSON(http.StatusOK, gin.H{
+		"message": "Content caches invalidated",
+	})
+}
+
+// respondCacheable はレスポンスにETag / Cache-Controlを付与し、
+// If-None-Match が一致する場合は 304 を返す
+func (s *server) respondCacheable(c *gin.Context, body gin.H) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		respondError(c, apierror.Wrap(apierror.CodeInternal, err))
+		return
+	}
+
+	sum := sha256.Sum256(payload)
+	etag := fmt.Sprintf(`W/"%s"`, hex.EncodeToString(sum[:16]))
+
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", int(s.cfg.Cache.TTL.Seconds())))
+
+	if etagMatches(c.GetHeader("If-None-Match"), etag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.Data(http.StatusOK, "application/json; charset=utf-8", payload)
+}
+
+// etagMatches は If-None-Match ヘッダー（カンマ区切り・弱い比較）とETagを比較する
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,3803 @@
+// Package client provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.4.1 DO NOT EDIT.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/oapi-codegen/runtime"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+const (
+	AdminKeyScopes = "adminKey.Scopes"
+)
+
+// Defines values for DependencyStatusStatus.
+const (
+	DependencyStatusStatusError   DependencyStatusStatus = "error"
+	DependencyStatusStatusOk      DependencyStatusStatus = "ok"
+	DependencyStatusStatusSkipped DependencyStatusStatus = "skipped"
+)
+
+// Defines values for Duration.
+const (
+	N120 Duration = 120
+	N300 Duration = 300
+	N60  Duration = 60
+)
+
+// Defines values for ErasureReportMode.
+const (
+	ErasureReportModeAnonymize ErasureReportMode = "anonymize"
+	ErasureReportModeDelete    ErasureReportMode = "delete"
+)
+
+// Defines values for ErrorDetailCode.
+const (
+	Conflict   ErrorDetailCode = "conflict"
+	Invalid    ErrorDetailCode = "invalid"
+	OutOfRange ErrorDetailCode = "out_of_range"
+	Required   ErrorDetailCode = "required"
+)
+
+// Defines values for Mode.
+const (
+	ModeEndless    Mode = "endless"
+	ModePassage    Mode = "passage"
+	ModePractice   Mode = "practice"
+	ModeStandard   Mode = "standard"
+	ModeTimeAttack Mode = "time_attack"
+)
+
+// Defines values for PlayerDataRequestMode.
+const (
+	PlayerDataRequestModeAnonymize PlayerDataRequestMode = "anonymize"
+	PlayerDataRequestModeDelete    PlayerDataRequestMode = "delete"
+)
+
+// Defines values for ReadinessStatusStatus.
+const (
+	ReadinessStatusStatusOk          ReadinessStatusStatus = "ok"
+	ReadinessStatusStatusUnavailable ReadinessStatusStatus = "unavailable"
+)
+
+// Defines values for TypingWordsResponseDegraded.
+const (
+	TypingWordsResponseDegradedFallback TypingWordsResponseDegraded = "fallback"
+	TypingWordsResponseDegradedStale    TypingWordsResponseDegraded = "stale"
+)
+
+// Defines values for WordItemType.
+const (
+	WordItemTypeBonus  WordItemType = "bonus"
+	WordItemTypeDebuff WordItemType = "debuff"
+	WordItemTypeNormal WordItemType = "normal"
+)
+
+// Defines values for WordListImportFormAcceptReview.
+const (
+	WordListImportFormAcceptReviewFalse WordListImportFormAcceptReview = "false"
+	WordListImportFormAcceptReviewTrue  WordListImportFormAcceptReview = "true"
+)
+
+// Defines values for WordListImportFormSkipInvalid.
+const (
+	WordListImportFormSkipInvalidFalse WordListImportFormSkipInvalid = "false"
+	WordListImportFormSkipInvalidTrue  WordListImportFormSkipInvalid = "true"
+)
+
+// Defines values for WordListItemType.
+const (
+	WordListItemTypeBonus  WordListItemType = "bonus"
+	WordListItemTypeDebuff WordListItemType = "debuff"
+	WordListItemTypeNormal WordListItemType = "normal"
+)
+
+// Defines values for WordListWordType.
+const (
+	Bonus  WordListWordType = "bonus"
+	Debuff WordListWordType = "debuff"
+	Normal WordListWordType = "normal"
+)
+
+// Defines values for WordModifier.
+const (
+	Compound WordModifier = "compound"
+	NoBonus  WordModifier = "no_bonus"
+)
+
+// Defines values for WordsResponseDegraded.
+const (
+	WordsResponseDegradedFallback WordsResponseDegraded = "fallback"
+	WordsResponseDegradedStale    WordsResponseDegraded = "stale"
+)
+
+// Defines values for MessageLanguage.
+const (
+	MessageLanguageEn MessageLanguage = "en"
+	MessageLanguageJp MessageLanguage = "jp"
+)
+
+// Defines values for GetLeaderboardParamsPeriod.
+const (
+	Monthly GetLeaderboardParamsPeriod = "monthly"
+	Weekly  GetLeaderboardParamsPeriod = "weekly"
+)
+
+// Defines values for GetLeaderboardParamsLanguage.
+const (
+	GetLeaderboardParamsLanguageEn GetLeaderboardParamsLanguage = "en"
+	GetLeaderboardParamsLanguageJp GetLeaderboardParamsLanguage = "jp"
+)
+
+// Defines values for GetTypingWordsParamsMode.
+const (
+	GetTypingWordsParamsModePractice   GetTypingWordsParamsMode = "practice"
+	GetTypingWordsParamsModeTimeAttack GetTypingWordsParamsMode = "time_attack"
+)
+
+// CategoriesResponse defines model for CategoriesResponse.
+type CategoriesResponse struct {
+	Categories []Category `json:"categories"`
+}
+
+// Category defines model for Category.
+type Category struct {
+	Description string `json:"description"`
+	Icon        string `json:"icon"`
+	Id          string `json:"id"`
+	Name        string `json:"name"`
+}
+
+// ComboRules defines model for ComboRules.
+type ComboRules struct {
+	DamageStep float32 `json:"damage_step"`
+
+	// MaxMultiplier ダメージ倍率の上限
+	MaxMultiplier float32 `json:"max_multiplier"`
+	ScoreBase     float32 `json:"score_base"`
+	ScoreExponent float32 `json:"score_exponent"`
+
+	// Threshold ボーナスが付き始めるコンボ数
+	Threshold int `json:"threshold"`
+}
+
+// ConfigResponse defines model for ConfigResponse.
+type ConfigResponse struct {
+	Game GameLimits `json:"game"`
+
+	// Ruleset 新しいゲームに使うルールセットのバージョン
+	Ruleset string `json:"ruleset"`
+}
+
+// DeckImportIssue defines model for DeckImportIssue.
+type DeckImportIssue struct {
+	// Note デッキのノートの番号（0から）
+	Note   int    `json:"note"`
+	Reason string `json:"reason"`
+
+	// Ref ファイルの中の位置（note 3・line 5）
+	Ref string `json:"ref"`
+}
+
+// DependencyStatus defines model for DependencyStatus.
+type DependencyStatus struct {
+	Error     *string                `json:"error,omitempty"`
+	LatencyMs float32                `json:"latency_ms"`
+	Name      string                 `json:"name"`
+	Status    DependencyStatusStatus `json:"status"`
+	Target    *string                `json:"target,omitempty"`
+}
+
+// DependencyStatusStatus defines model for DependencyStatus.Status.
+type DependencyStatusStatus string
+
+// Duration タイムアタックの制限時間（秒）
+type Duration int
+
+// EndlessRules エンドレスモードのルール（ない場合はエンドレスモードでプレイできない）
+type EndlessRules struct {
+	// HpGrowth 最後の敵より後のウェーブごとのHPの増加率
+	HpGrowth            float32 `json:"hp_growth"`
+	MaxHp               int     `json:"max_hp"`
+	MinTimeLimitSeconds int     `json:"min_time_limit_seconds"`
+
+	// Modifiers 単語を一巡するごとに先頭から1つずつ加える変化
+	Modifiers []WordModifier `json:"modifiers"`
+
+	// TimeDecaySeconds 最後の敵より後のウェーブごとに減らす制限時間
+	TimeDecaySeconds int `json:"time_decay_seconds"`
+}
+
+// Enemy defines model for Enemy.
+type Enemy struct {
+	BackgroundImage string `json:"background_image"`
+
+	// BackgroundOverlay 背景に重ねるCSSクラス
+	BackgroundOverlay *string `json:"background_overlay,omitempty"`
+	DefeatedIcon      string  `json:"defeated_icon"`
+	Icon              string  `json:"icon"`
+	MaxHp             int     `json:"max_hp"`
+
+	// Name 言語（jp / en）ごとの名前
+	Name             map[string]string `json:"name"`
+	Theme            *string           `json:"theme,omitempty"`
+	TimeLimitSeconds int               `json:"time_limit_seconds"`
+}
+
+// ErasureReport defines model for ErasureReport.
+type ErasureReport struct {
+	CompletedAt time.Time `json:"completed_at"`
+
+	// Digest digest を空にした状態のレポートのJSONのSHA-256
+	Digest     string            `json:"digest"`
+	Mode       ErasureReportMode `json:"mode"`
+	Notes      *[]string         `json:"notes"`
+	PlayerName string            `json:"player_name"`
+	Pseudonym  *string           `json:"pseudonym,omitempty"`
+	StartedAt  time.Time         `json:"started_at"`
+	Tables     []TableResult     `json:"tables"`
+	Verified   bool              `json:"verified"`
+}
+
+// ErasureReportMode defines model for ErasureReport.Mode.
+type ErasureReportMode string
+
+// Error defines model for Error.
+type Error struct {
+	// Code 安定したエラーコード（invalid_request, validation_failed, name_taken など）
+	Code    string         `json:"code"`
+	Details *[]ErrorDetail `json:"details,omitempty"`
+
+	// Error リクエストの言語のメッセージ
+	Error     string  `json:"error"`
+	RequestId *string `json:"request_id,omitempty"`
+}
+
+// ErrorDetail defines model for ErrorDetail.
+type ErrorDetail struct {
+	Code    ErrorDetailCode `json:"code"`
+	Field   string          `json:"field"`
+	Message string          `json:"message"`
+}
+
+// ErrorDetailCode defines model for ErrorDetail.Code.
+type ErrorDetailCode string
+
+// FuriganaSegment 表示テキストの区間。reading は漢字を含む区間だけに付く
+type FuriganaSegment struct {
+	Reading *string `json:"reading,omitempty"`
+	Text    string  `json:"text"`
+}
+
+// GameLimits defines model for GameLimits.
+type GameLimits struct {
+	LeaderboardSize      int      `json:"leaderboard_size"`
+	MaxGameTimeSeconds   int      `json:"max_game_time_seconds"`
+	MaxPlayerNameLength  int      `json:"max_player_name_length"`
+	MaxScore             int      `json:"max_score"`
+	TranslationLanguages []string `json:"translation_languages"`
+	WordLanguages        []string `json:"word_languages"`
+}
+
+// HealthStatus defines model for HealthStatus.
+type HealthStatus struct {
+	Message string `json:"message"`
+	Status  string `json:"status"`
+}
+
+// LeaderboardEntry defines model for LeaderboardEntry.
+type LeaderboardEntry struct {
+	// Accuracy タイピングテストのビューのみ
+	Accuracy *float32 `json:"accuracy,omitempty"`
+	Category string   `json:"category"`
+
+	// ElapsedMs タイピングテストのビューのみ（入力にかかった時間）
+	ElapsedMs  *int   `json:"elapsed_ms,omitempty"`
+	PlayerName string `json:"player_name"`
+	Rank       int    `json:"rank"`
+	Round      int    `json:"round"`
+	Score      int    `json:"score"`
+
+	// Wpm タイピングテストのビューのみ
+	Wpm *float32 `json:"wpm,omitempty"`
+}
+
+// LeaderboardResponse defines model for LeaderboardResponse.
+type LeaderboardResponse struct {
+	Leaderboard []LeaderboardEntry `json:"leaderboard"`
+
+	// View global、category#<カテゴリー>、weekly#<年-週>、monthly#<年-月>、mode#<モード>、mode#time_attack#<制限時間>、
+	// list#<リストの ID>（standard 以外のモードは list#<リストの ID>#mode#<モード>）
+	View string `json:"view"`
+}
+
+// LivenessStatus defines model for LivenessStatus.
+type LivenessStatus struct {
+	Status  string `json:"status"`
+	Version string `json:"version"`
+}
+
+// MessageResponse defines model for MessageResponse.
+type MessageResponse struct {
+	Message string `json:"message"`
+}
+
+// Mode standard は固定のラウンド、endless は負けるまで続くエンドレスモード（round は到達したウェーブ）、
+// time_attack は制限時間内にできるだけ多く入力するタイピングテスト、practice は50語をできるだけ速く入力するタイピングテスト、
+// passage は複数の文からなる文章を入力する文章モード
+type Mode string
+
+// Passage defines model for Passage.
+type Passage struct {
+	// Characters すべての文の読みの文字数
+	Characters int    `json:"characters"`
+	Language   string `json:"language"`
+
+	// License 利用条件
+	License   string     `json:"license"`
+	PassageId string     `json:"passage_id"`
+	Sentences []Sentence `json:"sentences"`
+
+	// Source 出典
+	Source string `json:"source"`
+	Title  string `json:"title"`
+}
+
+// PassageProgress 文章モードの進捗。sentences は文章の先頭から入力を完了した文ごとの記録
+type PassageProgress struct {
+	PassageId string           `json:"passage_id"`
+	Sentences []SentenceResult `json:"sentences"`
+}
+
+// PassageResponse defines model for PassageResponse.
+type PassageResponse struct {
+	Passage Passage `json:"passage"`
+}
+
+// PassageSummary defines model for PassageSummary.
+type PassageSummary struct {
+	// Characters すべての文の読みの文字数
+	Characters int    `json:"characters"`
+	Language   string `json:"language"`
+
+	// License 利用条件
+	License   string `json:"license"`
+	PassageId string `json:"passage_id"`
+
+	// Sentences 文の数
+	Sentences int `json:"sentences"`
+
+	// Source 出典
+	Source string `json:"source"`
+	Title  string `json:"title"`
+}
+
+// PassagesResponse defines model for PassagesResponse.
+type PassagesResponse struct {
+	Language string           `json:"language"`
+	Passages []PassageSummary `json:"passages"`
+}
+
+// PlayerDataRequest defines model for PlayerDataRequest.
+type PlayerDataRequest struct {
+	// Mode erase のみ（デフォルトは delete）
+	Mode       *PlayerDataRequestMode `json:"mode,omitempty"`
+	PlayerName string                 `json:"player_name"`
+}
+
+// PlayerDataRequestMode erase のみ（デフォルトは delete）
+type PlayerDataRequestMode string
+
+// PlayerExport defines model for PlayerExport.
+type PlayerExport struct {
+	ExportedAt time.Time `json:"exported_at"`
+
+	// Leaderboard テーブルに保存されている項目（ない場合は null）
+	Leaderboard      *StoredItem  `json:"leaderboard"`
+	LeaderboardViews *StoredItems `json:"leaderboard_views"`
+
+	// NameClaim テーブルに保存されている項目（ない場合は null）
+	NameClaim  *StoredItem `json:"name_claim"`
+	PlayerName string      `json:"player_name"`
+
+	// PlayerStats テーブルに保存されている項目（ない場合は null）
+	PlayerStats *StoredItem  `json:"player_stats"`
+	Replays     *StoredItems `json:"replays"`
+	Scores      *StoredItems `json:"scores"`
+}
+
+// ReadinessStatus defines model for ReadinessStatus.
+type ReadinessStatus struct {
+	Config struct {
+		Warnings *[]string `json:"warnings"`
+	} `json:"config"`
+	Dependencies []DependencyStatus    `json:"dependencies"`
+	Environment  string                `json:"environment"`
+	Status       ReadinessStatusStatus `json:"status"`
+	Version      string                `json:"version"`
+}
+
+// ReadinessStatusStatus defines model for ReadinessStatus.Status.
+type ReadinessStatusStatus string
+
+// Ruleset defines model for Ruleset.
+type Ruleset struct {
+	Combo ComboRules `json:"combo"`
+
+	// Endless エンドレスモードのルール（ない場合はエンドレスモードでプレイできない）
+	Endless *EndlessRules `json:"endless,omitempty"`
+
+	// Enemies ラウンド順の敵（ラウンド数は要素数）
+	Enemies []Enemy `json:"enemies"`
+
+	// MistakeDamage 入力ミスでプレイヤーが受けるダメージ
+	MistakeDamage int `json:"mistake_damage"`
+	PlayerMaxHp   int `json:"player_max_hp"`
+
+	// RoundClearHeal 敵を倒したときのHP回復
+	RoundClearHeal int          `json:"round_clear_heal"`
+	Scoring        ScoreRules   `json:"scoring"`
+	SpecialWords   SpecialWords `json:"special_words"`
+	Version        string       `json:"version"`
+
+	// Words 単語の種類（normal / bonus / debuff）ごとの効果
+	Words map[string]WordEffect `json:"words"`
+}
+
+// RulesetsResponse defines model for RulesetsResponse.
+type RulesetsResponse struct {
+	// Current 新しいゲームに使うバージョン
+	Current  string    `json:"current"`
+	Rulesets []Ruleset `json:"rulesets"`
+}
+
+// ScoreRules defines model for ScoreRules.
+type ScoreRules struct {
+	PerBonusSecond int `json:"per_bonus_second"`
+	PerDamage      int `json:"per_damage"`
+}
+
+// ScoreSubmission score・round・ruleset_version はバトルのモード（standard・endless）のみ。
+// タイピングテストのモード（time_attack・practice）は typing を送り、score・time・wpm・accuracy はサーバーが計算する。
+// 文章モード（passage）は progress を送り、category・score・time・wpm・accuracy はサーバーが計算する
+type ScoreSubmission struct {
+	// Accuracy 打鍵のうち正しかった割合（0〜1）。サーバーが計算する
+	Accuracy *float32 `json:"accuracy,omitempty"`
+
+	// Category 単語のカテゴリーまたはカスタム単語リストの ID（文章モード以外では必須。文章モードでは文章の ID）
+	Category *string `json:"category,omitempty"`
+
+	// Duration タイムアタックの制限時間（秒）
+	Duration *Duration `json:"duration,omitempty"`
+
+	// Mode standard は固定のラウンド、endless は負けるまで続くエンドレスモード（round は到達したウェーブ）、
+	// time_attack は制限時間内にできるだけ多く入力するタイピングテスト、practice は50語をできるだけ速く入力するタイピングテスト、
+	// passage は複数の文からなる文章を入力する文章モード
+	Mode       *Mode  `json:"mode,omitempty"`
+	PlayerName string `json:"player_name"`
+
+	// Progress 文章モードの進捗。sentences は文章の先頭から入力を完了した文ごとの記録
+	Progress *PassageProgress `json:"progress,omitempty"`
+
+	// Round バトルのモードでは 1 以上（エンドレスモードは到達したウェーブ）
+	Round *int `json:"round,omitempty"`
+
+	// RulesetVersion プレイしたルールセットのバージョン（省略時は現在のバージョン）
+	RulesetVersion *string `json:"ruleset_version,omitempty"`
+	Score          *int    `json:"score,omitempty"`
+
+	// Time プレイ時間（秒）
+	Time *int `json:"time,omitempty"`
+
+	// Typing タイピングテストのモードの入力の記録
+	Typing *TypingStats `json:"typing,omitempty"`
+
+	// Wpm 1分あたりの語数（正しい5打鍵を1語とする）。サーバーが計算する
+	Wpm *float32 `json:"wpm,omitempty"`
+}
+
+// ScoreSubmittedResponse defines model for ScoreSubmittedResponse.
+type ScoreSubmittedResponse struct {
+	// Data score・round・ruleset_version はバトルのモード（standard・endless）のみ。
+	// タイピングテストのモード（time_attack・practice）は typing を送り、score・time・wpm・accuracy はサーバーが計算する。
+	// 文章モード（passage）は progress を送り、category・score・time・wpm・accuracy はサーバーが計算する
+	Data    ScoreSubmission `json:"data"`
+	Message string          `json:"message"`
+
+	// PersonalBest 自己ベストを更新したか（初回登録を含む）
+	PersonalBest bool `json:"personal_best"`
+
+	// PreviousBest 更新前の自己ベスト（初回は 0）
+	PreviousBest int `json:"previous_best"`
+}
+
+// Sentence defines model for Sentence.
+type Sentence struct {
+	// Furigana 表示テキストの区間ごとの読み（日本語のみ）
+	Furigana *[]FuriganaSegment `json:"furigana,omitempty"`
+
+	// Paragraph 文を含む段落（0から）
+	Paragraph int `json:"paragraph"`
+
+	// Reading 入力する読み（日本語以外は text と同じ）
+	Reading string `json:"reading"`
+
+	// Text 表示するテキスト（漢字かな混じり・句読点を含む）
+	Text string `json:"text"`
+}
+
+// SentenceResult 文章モードの1文の入力の記録
+type SentenceResult struct {
+	// Characters 正しく入力した打鍵数（日本語はローマ字の打鍵数）
+	Characters int `json:"characters"`
+
+	// ElapsedMs 文の入力にかかった時間（ミリ秒）
+	ElapsedMs int `json:"elapsed_ms"`
+
+	// Keystrokes 間違いを含むすべての打鍵数
+	Keystrokes int `json:"keystrokes"`
+}
+
+// SpecialWords defines model for SpecialWords.
+type SpecialWords struct {
+	// BonusShare 特殊単語のうちボーナス単語の割合（残りはデバフ単語）
+	BonusShare float32 `json:"bonus_share"`
+
+	// Chance 特殊単語を出題する確率
+	Chance float32 `json:"chance"`
+}
+
+// StoredItem テーブルに保存されている項目（ない場合は null）
+type StoredItem map[string]interface{}
+
+// StoredItems defines model for StoredItems.
+type StoredItems = []map[string]interface{}
+
+// TableResult defines model for TableResult.
+type TableResult struct {
+	Found     int    `json:"found"`
+	Processed int    `json:"processed"`
+	Remaining int    `json:"remaining"`
+	Skipped   *bool  `json:"skipped,omitempty"`
+	Table     string `json:"table"`
+}
+
+// TranslationResponse defines model for TranslationResponse.
+type TranslationResponse struct {
+	Language    string `json:"language"`
+	Translation string `json:"translation"`
+	WordId      string `json:"word_id"`
+}
+
+// TypingStats タイピングテストのモードの入力の記録
+type TypingStats struct {
+	// Characters 正しく入力した打鍵数（日本語はローマ字の打鍵数）
+	Characters int `json:"characters"`
+
+	// ElapsedMs 入力にかかった時間（ミリ秒）。タイムアタックでは制限時間を使うため省略できる
+	ElapsedMs *int `json:"elapsed_ms,omitempty"`
+
+	// Keystrokes 間違いを含むすべての打鍵数
+	Keystrokes int `json:"keystrokes"`
+
+	// Words 入力を完了した単語数（練習モードは 50）
+	Words int `json:"words"`
+}
+
+// TypingWordsResponse defines model for TypingWordsResponse.
+type TypingWordsResponse struct {
+	Category string `json:"category"`
+
+	// Degraded ストレージの障害時だけ付く（stale または fallback）
+	Degraded *TypingWordsResponseDegraded `json:"degraded,omitempty"`
+	Language string                       `json:"language"`
+
+	// Mode standard は固定のラウンド、endless は負けるまで続くエンドレスモード（round は到達したウェーブ）、
+	// time_attack は制限時間内にできるだけ多く入力するタイピングテスト、practice は50語をできるだけ速く入力するタイピングテスト、
+	// passage は複数の文からなる文章を入力する文章モード
+	Mode  Mode       `json:"mode"`
+	Words []WordItem `json:"words"`
+}
+
+// TypingWordsResponseDegraded ストレージの障害時だけ付く（stale または fallback）
+type TypingWordsResponseDegraded string
+
+// Wave エンドレスモードのウェーブ（mode=endless のときだけ付く）
+type Wave struct {
+	Enemy     Enemy          `json:"enemy"`
+	Modifiers []WordModifier `json:"modifiers"`
+
+	// Round 単語を出題したラウンド
+	Round int `json:"round"`
+	Wave  int `json:"wave"`
+}
+
+// WordEffect defines model for WordEffect.
+type WordEffect struct {
+	// Damage コンボ倍率をかける前のダメージ
+	Damage     int `json:"damage"`
+	Heal       int `json:"heal"`
+	ScoreBonus int `json:"score_bonus"`
+
+	// TimeBonus 残り時間に加える秒数
+	TimeBonus int `json:"time_bonus"`
+}
+
+// WordItem defines model for WordItem.
+type WordItem struct {
+	Category string `json:"category"`
+
+	// Display 表示形（漢字かな混じり・カタカナ）。表示形のない単語は word と同じ
+	Display string `json:"display"`
+
+	// Furigana 表示形の区間ごとの読み（日本語のみ）
+	Furigana *[]FuriganaSegment `json:"furigana,omitempty"`
+	Language string             `json:"language"`
+	Round    int                `json:"round"`
+	Type     WordItemType       `json:"type"`
+
+	// Word 入力する読み（日本語はひらがな）
+	Word   string `json:"word"`
+	WordId string `json:"word_id"`
+}
+
+// WordItemType defines model for WordItem.Type.
+type WordItemType string
+
+// WordList defines model for WordList.
+type WordList struct {
+	// CreatedAt 作成した時刻（Unix秒）
+	CreatedAt int    `json:"created_at"`
+	Language  string `json:"language"`
+
+	// ListId リストの ID（list_<共有コード>）。単語の取得・スコア・リーダーボードの category に使う
+	ListId string `json:"list_id"`
+	Name   string `json:"name"`
+
+	// Rounds リストのラウンド数（ゲームのラウンド数より少ない場合は最初のラウンドから繰り返す）
+	Rounds int `json:"rounds"`
+
+	// ShareCode リストを遊ぶための8文字の共有コード
+	ShareCode           string  `json:"share_code"`
+	TranslationLanguage *string `json:"translation_language,omitempty"`
+
+	// Translations 単語の ID ごとの翻訳
+	Translations  *map[string]string `json:"translations,omitempty"`
+	Words         []WordListItem     `json:"words"`
+	WordsPerRound int                `json:"words_per_round"`
+}
+
+// WordListCreatedResponse defines model for WordListCreatedResponse.
+type WordListCreatedResponse struct {
+	List    WordList `json:"list"`
+	Message string   `json:"message"`
+}
+
+// WordListImportForm defines model for WordListImportForm.
+type WordListImportForm struct {
+	AcceptReview *WordListImportFormAcceptReview `json:"accept_review,omitempty"`
+
+	// BackField 裏面に使うフィールドの名前か番号（省略時は Back、なければ2番目）
+	BackField *string `json:"back_field,omitempty"`
+
+	// File Anki の .apkg（collection.anki2・collection.anki21）か、UTF-8 のタブ区切りのテキスト（Anki のヘッダー付きでもよい）
+	File openapi_types.File `json:"file"`
+
+	// FrontField 表面に使うフィールドの名前か1から始まる番号（省略時は Front、なければ1番目）
+	FrontField *string `json:"front_field,omitempty"`
+
+	// Language 表面の言語（省略時はテキストから推定する）
+	Language *string `json:"language,omitempty"`
+	Name     string  `json:"name"`
+
+	// ReadingField 日本語の読みのフィールドの名前か番号（かな、または 日本語[にほんご] の形式。省略時は Reading）
+	ReadingField *string `json:"reading_field,omitempty"`
+
+	// SkipInvalid 単語にできないノートを取り込まずに作成する
+	SkipInvalid *WordListImportFormSkipInvalid `json:"skip_invalid,omitempty"`
+
+	// TranslationLanguage 裏面（翻訳）の言語（省略時はテキストから推定する）
+	TranslationLanguage *string `json:"translation_language,omitempty"`
+
+	// WordsPerRound 1ラウンドの単語数（1〜50）
+	WordsPerRound string `json:"words_per_round"`
+}
+
+// WordListImportFormAcceptReview defines model for WordListImportForm.AcceptReview.
+type WordListImportFormAcceptReview string
+
+// WordListImportFormSkipInvalid 単語にできないノートを取り込まずに作成する
+type WordListImportFormSkipInvalid string
+
+// WordListImportedResponse defines model for WordListImportedResponse.
+type WordListImportedResponse struct {
+	List    WordList `json:"list"`
+	Message string   `json:"message"`
+
+	// Skipped 取り込まなかったノート（表面が空・重複、skip_invalid の場合は単語にできないノート）
+	Skipped []DeckImportIssue `json:"skipped"`
+}
+
+// WordListItem defines model for WordListItem.
+type WordListItem struct {
+	Category string `json:"category"`
+
+	// Display 表示形（漢字かな混じり・カタカナ）。表示形のない単語は word と同じ
+	Display string `json:"display"`
+
+	// Furigana 表示形の区間ごとの読み（日本語のみ）
+	Furigana *[]FuriganaSegment `json:"furigana,omitempty"`
+	Language string             `json:"language"`
+
+	// Review 作成時のレスポンスだけに付く、辞書で付けた読みのうち確認が必要な語と読みの候補
+	Review *[]string        `json:"review,omitempty"`
+	Round  int              `json:"round"`
+	Type   WordListItemType `json:"type"`
+
+	// Word 入力する読み（日本語はひらがな）
+	Word   string `json:"word"`
+	WordId string `json:"word_id"`
+}
+
+// WordListItemType defines model for WordListItem.Type.
+type WordListItemType string
+
+// WordListRequest defines model for WordListRequest.
+type WordListRequest struct {
+	// AcceptReview 辞書で付けた読みのうち、読みが複数あり確認が必要な読みをそのまま使う
+	AcceptReview *bool `json:"accept_review,omitempty"`
+
+	// Language 単語の言語（word_languages のいずれか）
+	Language string `json:"language"`
+
+	// Name リストの名前（1〜40文字）
+	Name string `json:"name"`
+
+	// TranslationLanguage 単語の翻訳の言語（translation_languages のいずれか。翻訳のある単語がある場合は必須）
+	TranslationLanguage *string `json:"translation_language,omitempty"`
+
+	// Words 単語（1語以上、1ラウンドの単語数 × ルールセットの最も多いラウンド数まで）
+	Words []WordListWord `json:"words"`
+
+	// WordsPerRound 1ラウンドの単語数（1〜50）。単語は先頭からこの数ずつラウンドに分ける
+	WordsPerRound int `json:"words_per_round"`
+}
+
+// WordListResponse defines model for WordListResponse.
+type WordListResponse struct {
+	List WordList `json:"list"`
+}
+
+// WordListWord defines model for WordListWord.
+type WordListWord struct {
+	// Display 表示形。日本語は漢字かな混じりで、青空文庫形式のルビ（水《みず》）で読みを書ける
+	Display string `json:"display"`
+
+	// Reading 入力する読み（かな）。省略した場合はルビと辞書で読みを付ける
+	Reading *string `json:"reading,omitempty"`
+
+	// Translation 単語の翻訳（100文字まで）
+	Translation *string           `json:"translation,omitempty"`
+	Type        *WordListWordType `json:"type,omitempty"`
+}
+
+// WordListWordType defines model for WordListWord.Type.
+type WordListWordType string
+
+// WordModifier compound は通常の単語を2つつなげる、no_bonus はボーナス単語を出題しない
+type WordModifier string
+
+// WordsResponse defines model for WordsResponse.
+type WordsResponse struct {
+	Category string `json:"category"`
+
+	// Degraded ストレージの障害時だけ付く（stale または fallback）
+	Degraded *WordsResponseDegraded `json:"degraded,omitempty"`
+	Language string                 `json:"language"`
+	Round    int                    `json:"round"`
+
+	// Wave エンドレスモードのウェーブ（mode=endless のときだけ付く）
+	Wave  *Wave      `json:"wave,omitempty"`
+	Words []WordItem `json:"words"`
+}
+
+// WordsResponseDegraded ストレージの障害時だけ付く（stale または fallback）
+type WordsResponseDegraded string
+
+// IfNoneMatch defines model for IfNoneMatch.
+type IfNoneMatch = string
+
+// MessageLanguage defines model for MessageLanguage.
+type MessageLanguage string
+
+// GetCategoriesParams defines parameters for GetCategories.
+type GetCategoriesParams struct {
+	// Language カテゴリー名・説明の言語（en 以外は日本語）
+	Language    *string      `form:"language,omitempty" json:"language,omitempty"`
+	IfNoneMatch *IfNoneMatch `json:"If-None-Match,omitempty"`
+}
+
+// GetLeaderboardParams defines parameters for GetLeaderboard.
+type GetLeaderboardParams struct {
+	Category *string                     `form:"category,omitempty" json:"category,omitempty"`
+	Period   *GetLeaderboardParamsPeriod `form:"period,omitempty" json:"period,omitempty"`
+
+	// Mode endless はエンドレスモード（ウェーブ数、スコアの順）、time_attack はタイムアタック（スコアの順）、
+	// practice は練習モード（入力時間の短い順）、passage は文章モード（スコアの順）のリーダーボード
+	Mode *Mode `form:"mode,omitempty" json:"mode,omitempty"`
+
+	// Duration タイムアタックの制限時間（秒、60・120・300）。mode=time_attack のときは必須で、それ以外では指定できない
+	// （値はハンドラーで検証する）
+	Duration *int `form:"duration,omitempty" json:"duration,omitempty"`
+
+	// Language エラーメッセージの言語
+	Language *GetLeaderboardParamsLanguage `form:"language,omitempty" json:"language,omitempty"`
+}
+
+// GetLeaderboardParamsPeriod defines parameters for GetLeaderboard.
+type GetLeaderboardParamsPeriod string
+
+// GetLeaderboardParamsLanguage defines parameters for GetLeaderboard.
+type GetLeaderboardParamsLanguage string
+
+// GetWordListParams defines parameters for GetWordList.
+type GetWordListParams struct {
+	IfNoneMatch *IfNoneMatch `json:"If-None-Match,omitempty"`
+}
+
+// GetPassagesParams defines parameters for GetPassages.
+type GetPassagesParams struct {
+	// Language 文章の言語（デフォルトは jp）
+	Language    *string      `form:"language,omitempty" json:"language,omitempty"`
+	IfNoneMatch *IfNoneMatch `json:"If-None-Match,omitempty"`
+}
+
+// GetPassageParams defines parameters for GetPassage.
+type GetPassageParams struct {
+	IfNoneMatch *IfNoneMatch `json:"If-None-Match,omitempty"`
+}
+
+// GetRulesetsParams defines parameters for GetRulesets.
+type GetRulesetsParams struct {
+	IfNoneMatch *IfNoneMatch `json:"If-None-Match,omitempty"`
+}
+
+// GetTranslationParams defines parameters for GetTranslation.
+type GetTranslationParams struct {
+	// Language 翻訳先の言語
+	Language    string       `form:"language" json:"language"`
+	IfNoneMatch *IfNoneMatch `json:"If-None-Match,omitempty"`
+}
+
+// GetTypingWordsParams defines parameters for GetTypingWords.
+type GetTypingWordsParams struct {
+	Mode GetTypingWordsParamsMode `form:"mode" json:"mode"`
+
+	// Language 単語の言語（デフォルトは jp）
+	Language    *string      `form:"language,omitempty" json:"language,omitempty"`
+	IfNoneMatch *IfNoneMatch `json:"If-None-Match,omitempty"`
+}
+
+// GetTypingWordsParamsMode defines parameters for GetTypingWords.
+type GetTypingWordsParamsMode string
+
+// GetWordsParams defines parameters for GetWords.
+type GetWordsParams struct {
+	// Language 単語の言語（デフォルトは jp）
+	Language *string `form:"language,omitempty" json:"language,omitempty"`
+
+	// Mode endless はウェーブの単語（変化を加えたもの）とウェーブの敵を返す
+	Mode *Mode `form:"mode,omitempty" json:"mode,omitempty"`
+
+	// Ruleset エンドレスモードのウェーブを決めるルールセットのバージョン（デフォルトは現在のバージョン）
+	Ruleset     *string      `form:"ruleset,omitempty" json:"ruleset,omitempty"`
+	IfNoneMatch *IfNoneMatch `json:"If-None-Match,omitempty"`
+}
+
+// ErasePlayerDataJSONRequestBody defines body for ErasePlayerData for application/json ContentType.
+type ErasePlayerDataJSONRequestBody = PlayerDataRequest
+
+// ExportPlayerDataJSONRequestBody defines body for ExportPlayerData for application/json ContentType.
+type ExportPlayerDataJSONRequestBody = PlayerDataRequest
+
+// CreateWordListJSONRequestBody defines body for CreateWordList for application/json ContentType.
+type CreateWordListJSONRequestBody = WordListRequest
+
+// ImportWordListMultipartRequestBody defines body for ImportWordList for multipart/form-data ContentType.
+type ImportWordListMultipartRequestBody = WordListImportForm
+
+// SubmitScoreJSONRequestBody defines body for SubmitScore for application/json ContentType.
+type SubmitScoreJSONRequestBody = ScoreSubmission
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// InvalidateCache request
+	InvalidateCache(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ErasePlayerDataWithBody request with any body
+	ErasePlayerDataWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	ErasePlayerData(ctx context.Context, body ErasePlayerDataJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ExportPlayerDataWithBody request with any body
+	ExportPlayerDataWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	ExportPlayerData(ctx context.Context, body ExportPlayerDataJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetConfig request
+	GetConfig(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetCategories request
+	GetCategories(ctx context.Context, params *GetCategoriesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetLeaderboard request
+	GetLeaderboard(ctx context.Context, params *GetLeaderboardParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// CreateWordListWithBody request with any body
+	CreateWordListWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	CreateWordList(ctx context.Context, body CreateWordListJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// ImportWordListWithBody request with any body
+	ImportWordListWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetWordList request
+	GetWordList(ctx context.Context, shareCode string, params *GetWordListParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetPassages request
+	GetPassages(ctx context.Context, params *GetPassagesParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetPassage request
+	GetPassage(ctx context.Context, passageId string, params *GetPassageParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetRulesets request
+	GetRulesets(ctx context.Context, params *GetRulesetsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// SubmitScoreWithBody request with any body
+	SubmitScoreWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	SubmitScore(ctx context.Context, body SubmitScoreJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetTranslation request
+	GetTranslation(ctx context.Context, wordId string, params *GetTranslationParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetTypingWords request
+	GetTypingWords(ctx context.Context, category string, params *GetTypingWordsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetWords request
+	GetWords(ctx context.Context, category string, round int, params *GetWordsParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// HealthCheck request
+	HealthCheck(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// Liveness request
+	Liveness(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// Readiness request
+	Readiness(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetOpenAPISpec request
+	GetOpenAPISpec(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) InvalidateCache(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewInvalidateCacheRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ErasePlayerDataWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewErasePlayerDataRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ErasePlayerData(ctx context.Context, body ErasePlayerDataJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewErasePlayerDataRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ExportPlayerDataWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewExportPlayerDataRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ExportPlayerData(ctx context.Context, body ExportPlayerDataJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewExportPlayerDataRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetConfig(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetConfigRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetCategories(ctx context.Context, params *GetCategoriesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetCategoriesRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetLeaderboard(ctx context.Context, params *GetLeaderboardParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetLeaderboardRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateWordListWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateWordListRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) CreateWordList(ctx context.Context, body CreateWordListJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewCreateWordListRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) ImportWordListWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewImportWordListRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetWordList(ctx context.Context, shareCode string, params *GetWordListParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetWordListRequest(c.Server, shareCode, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetPassages(ctx context.Context, params *GetPassagesParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetPassagesRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetPassage(ctx context.Context, passageId string, params *GetPassageParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetPassageRequest(c.Server, passageId, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetRulesets(ctx context.Context, params *GetRulesetsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetRulesetsRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SubmitScoreWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSubmitScoreRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) SubmitScore(ctx context.Context, body SubmitScoreJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewSubmitScoreRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetTranslation(ctx context.Context, wordId string, params *GetTranslationParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetTranslationRequest(c.Server, wordId, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetTypingWords(ctx context.Context, category string, params *GetTypingWordsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetTypingWordsRequest(c.Server, category, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetWords(ctx context.Context, category string, round int, params *GetWordsParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetWordsRequest(c.Server, category, round, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) HealthCheck(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewHealthCheckRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) Liveness(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewLivenessRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) Readiness(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewReadinessRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetOpenAPISpec(ctx context.Context, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetOpenAPISpecRequest(c.Server)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewInvalidateCacheRequest generates requests for InvalidateCache
+func NewInvalidateCacheRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/cache/invalidate")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewErasePlayerDataRequest calls the generic ErasePlayerData builder with application/json body
+func NewErasePlayerDataRequest(server string, body ErasePlayerDataJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewErasePlayerDataRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewErasePlayerDataRequestWithBody generates requests for ErasePlayerData with any type of body
+func NewErasePlayerDataRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/players/erase")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewExportPlayerDataRequest calls the generic ExportPlayerData builder with application/json body
+func NewExportPlayerDataRequest(server string, body ExportPlayerDataJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewExportPlayerDataRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewExportPlayerDataRequestWithBody generates requests for ExportPlayerData with any type of body
+func NewExportPlayerDataRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/admin/players/export")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetConfigRequest generates requests for GetConfig
+func NewGetConfigRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/config")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetCategoriesRequest generates requests for GetCategories
+func NewGetCategoriesRequest(server string, params *GetCategoriesParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/game/categories")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Language != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "language", runtime.ParamLocationQuery, *params.Language); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.IfNoneMatch != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "If-None-Match", runtime.ParamLocationHeader, *params.IfNoneMatch)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("If-None-Match", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGetLeaderboardRequest generates requests for GetLeaderboard
+func NewGetLeaderboardRequest(server string, params *GetLeaderboardParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/game/leaderboard")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Category != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "category", runtime.ParamLocationQuery, *params.Category); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Period != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "period", runtime.ParamLocationQuery, *params.Period); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Mode != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "mode", runtime.ParamLocationQuery, *params.Mode); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Duration != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "duration", runtime.ParamLocationQuery, *params.Duration); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Language != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "language", runtime.ParamLocationQuery, *params.Language); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewCreateWordListRequest calls the generic CreateWordList builder with application/json body
+func NewCreateWordListRequest(server string, body CreateWordListJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewCreateWordListRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewCreateWordListRequestWithBody generates requests for CreateWordList with any type of body
+func NewCreateWordListRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/game/lists")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewImportWordListRequestWithBody generates requests for ImportWordList with any type of body
+func NewImportWordListRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/game/lists/import")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetWordListRequest generates requests for GetWordList
+func NewGetWordListRequest(server string, shareCode string, params *GetWordListParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "share_code", runtime.ParamLocationPath, shareCode)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/game/lists/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.IfNoneMatch != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "If-None-Match", runtime.ParamLocationHeader, *params.IfNoneMatch)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("If-None-Match", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGetPassagesRequest generates requests for GetPassages
+func NewGetPassagesRequest(server string, params *GetPassagesParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/game/passages")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Language != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "language", runtime.ParamLocationQuery, *params.Language); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.IfNoneMatch != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "If-None-Match", runtime.ParamLocationHeader, *params.IfNoneMatch)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("If-None-Match", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGetPassageRequest generates requests for GetPassage
+func NewGetPassageRequest(server string, passageId string, params *GetPassageParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "passage_id", runtime.ParamLocationPath, passageId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/game/passages/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.IfNoneMatch != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "If-None-Match", runtime.ParamLocationHeader, *params.IfNoneMatch)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("If-None-Match", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGetRulesetsRequest generates requests for GetRulesets
+func NewGetRulesetsRequest(server string, params *GetRulesetsParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/game/rulesets")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.IfNoneMatch != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "If-None-Match", runtime.ParamLocationHeader, *params.IfNoneMatch)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("If-None-Match", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewSubmitScoreRequest calls the generic SubmitScore builder with application/json body
+func NewSubmitScoreRequest(server string, body SubmitScoreJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewSubmitScoreRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewSubmitScoreRequestWithBody generates requests for SubmitScore with any type of body
+func NewSubmitScoreRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/game/score")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetTranslationRequest generates requests for GetTranslation
+func NewGetTranslationRequest(server string, wordId string, params *GetTranslationParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "word_id", runtime.ParamLocationPath, wordId)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/game/translation/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "language", runtime.ParamLocationQuery, params.Language); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.IfNoneMatch != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "If-None-Match", runtime.ParamLocationHeader, *params.IfNoneMatch)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("If-None-Match", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGetTypingWordsRequest generates requests for GetTypingWords
+func NewGetTypingWordsRequest(server string, category string, params *GetTypingWordsParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "category", runtime.ParamLocationPath, category)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/game/words/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if queryFrag, err := runtime.StyleParamWithLocation("form", true, "mode", runtime.ParamLocationQuery, params.Mode); err != nil {
+			return nil, err
+		} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+			return nil, err
+		} else {
+			for k, v := range parsed {
+				for _, v2 := range v {
+					queryValues.Add(k, v2)
+				}
+			}
+		}
+
+		if params.Language != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "language", runtime.ParamLocationQuery, *params.Language); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.IfNoneMatch != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "If-None-Match", runtime.ParamLocationHeader, *params.IfNoneMatch)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("If-None-Match", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGetWordsRequest generates requests for GetWords
+func NewGetWordsRequest(server string, category string, round int, params *GetWordsParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "category", runtime.ParamLocationPath, category)
+	if err != nil {
+		return nil, err
+	}
+
+	var pathParam1 string
+
+	pathParam1, err = runtime.StyleParamWithLocation("simple", false, "round", runtime.ParamLocationPath, round)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/game/words/%s/%s", pathParam0, pathParam1)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Language != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "language", runtime.ParamLocationQuery, *params.Language); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Mode != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "mode", runtime.ParamLocationQuery, *params.Mode); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Ruleset != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "ruleset", runtime.ParamLocationQuery, *params.Ruleset); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.IfNoneMatch != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "If-None-Match", runtime.ParamLocationHeader, *params.IfNoneMatch)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("If-None-Match", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewHealthCheckRequest generates requests for HealthCheck
+func NewHealthCheckRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/health")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewLivenessRequest generates requests for Liveness
+func NewLivenessRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/health/live")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewReadinessRequest generates requests for Readiness
+func NewReadinessRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/health/ready")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewGetOpenAPISpecRequest generates requests for GetOpenAPISpec
+func NewGetOpenAPISpecRequest(server string) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/openapi.json")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// InvalidateCacheWithResponse request
+	InvalidateCacheWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*InvalidateCacheResponse, error)
+
+	// ErasePlayerDataWithBodyWithResponse request with any body
+	ErasePlayerDataWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ErasePlayerDataResponse, error)
+
+	ErasePlayerDataWithResponse(ctx context.Context, body ErasePlayerDataJSONRequestBody, reqEditors ...RequestEditorFn) (*ErasePlayerDataResponse, error)
+
+	// ExportPlayerDataWithBodyWithResponse request with any body
+	ExportPlayerDataWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ExportPlayerDataResponse, error)
+
+	ExportPlayerDataWithResponse(ctx context.Context, body ExportPlayerDataJSONRequestBody, reqEditors ...RequestEditorFn) (*ExportPlayerDataResponse, error)
+
+	// GetConfigWithResponse request
+	GetConfigWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetConfigResponse, error)
+
+	// GetCategoriesWithResponse request
+	GetCategoriesWithResponse(ctx context.Context, params *GetCategoriesParams, reqEditors ...RequestEditorFn) (*GetCategoriesResponse, error)
+
+	// GetLeaderboardWithResponse request
+	GetLeaderboardWithResponse(ctx context.Context, params *GetLeaderboardParams, reqEditors ...RequestEditorFn) (*GetLeaderboardResponse, error)
+
+	// CreateWordListWithBodyWithResponse request with any body
+	CreateWordListWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateWordListResponse, error)
+
+	CreateWordListWithResponse(ctx context.Context, body CreateWordListJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateWordListResponse, error)
+
+	// ImportWordListWithBodyWithResponse request with any body
+	ImportWordListWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ImportWordListResponse, error)
+
+	// GetWordListWithResponse request
+	GetWordListWithResponse(ctx context.Context, shareCode string, params *GetWordListParams, reqEditors ...RequestEditorFn) (*GetWordListResponse, error)
+
+	// GetPassagesWithResponse request
+	GetPassagesWithResponse(ctx context.Context, params *GetPassagesParams, reqEditors ...RequestEditorFn) (*GetPassagesResponse, error)
+
+	// GetPassageWithResponse request
+	GetPassageWithResponse(ctx context.Context, passageId string, params *GetPassageParams, reqEditors ...RequestEditorFn) (*GetPassageResponse, error)
+
+	// GetRulesetsWithResponse request
+	GetRulesetsWithResponse(ctx context.Context, params *GetRulesetsParams, reqEditors ...RequestEditorFn) (*GetRulesetsResponse, error)
+
+	// SubmitScoreWithBodyWithResponse request with any body
+	SubmitScoreWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SubmitScoreResponse, error)
+
+	SubmitScoreWithResponse(ctx context.Context, body SubmitScoreJSONRequestBody, reqEditors ...RequestEditorFn) (*SubmitScoreResponse, error)
+
+	// GetTranslationWithResponse request
+	GetTranslationWithResponse(ctx context.Context, wordId string, params *GetTranslationParams, reqEditors ...RequestEditorFn) (*GetTranslationResponse, error)
+
+	// GetTypingWordsWithResponse request
+	GetTypingWordsWithResponse(ctx context.Context, category string, params *GetTypingWordsParams, reqEditors ...RequestEditorFn) (*GetTypingWordsResponse, error)
+
+	// GetWordsWithResponse request
+	GetWordsWithResponse(ctx context.Context, category string, round int, params *GetWordsParams, reqEditors ...RequestEditorFn) (*GetWordsResponse, error)
+
+	// HealthCheckWithResponse request
+	HealthCheckWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*HealthCheckResponse, error)
+
+	// LivenessWithResponse request
+	LivenessWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*LivenessResponse, error)
+
+	// ReadinessWithResponse request
+	ReadinessWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ReadinessResponse, error)
+
+	// GetOpenAPISpecWithResponse request
+	GetOpenAPISpecWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetOpenAPISpecResponse, error)
+}
+
+type InvalidateCacheResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *MessageResponse
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r InvalidateCacheResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r InvalidateCacheResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ErasePlayerDataResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ErasureReport
+	JSON500      *ErasureReport
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r ErasePlayerDataResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ErasePlayerDataResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ExportPlayerDataResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *PlayerExport
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r ExportPlayerDataResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ExportPlayerDataResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetConfigResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ConfigResponse
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetConfigResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetConfigResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetCategoriesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *CategoriesResponse
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetCategoriesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetCategoriesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetLeaderboardResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *LeaderboardResponse
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetLeaderboardResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetLeaderboardResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type CreateWordListResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *WordListCreatedResponse
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r CreateWordListResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r CreateWordListResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ImportWordListResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *WordListImportedResponse
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r ImportWordListResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ImportWordListResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetWordListResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *WordListResponse
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetWordListResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetWordListResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetPassagesResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *PassagesResponse
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetPassagesResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetPassagesResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetPassageResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *PassageResponse
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetPassageResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetPassageResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetRulesetsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *RulesetsResponse
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetRulesetsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetRulesetsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type SubmitScoreResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ScoreSubmittedResponse
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r SubmitScoreResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r SubmitScoreResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetTranslationResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *TranslationResponse
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetTranslationResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetTranslationResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetTypingWordsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *TypingWordsResponse
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetTypingWordsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetTypingWordsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetWordsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *WordsResponse
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetWordsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetWordsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type HealthCheckResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *HealthStatus
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r HealthCheckResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r HealthCheckResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type LivenessResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *LivenessStatus
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r LivenessResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r LivenessResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type ReadinessResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *ReadinessStatus
+	JSON503      *ReadinessStatus
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r ReadinessResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r ReadinessResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetOpenAPISpecResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *map[string]interface{}
+	JSONDefault  *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r GetOpenAPISpecResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetOpenAPISpecResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// InvalidateCacheWithResponse request returning *InvalidateCacheResponse
+func (c *ClientWithResponses) InvalidateCacheWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*InvalidateCacheResponse, error) {
+	rsp, err := c.InvalidateCache(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseInvalidateCacheResponse(rsp)
+}
+
+// ErasePlayerDataWithBodyWithResponse request with arbitrary body returning *ErasePlayerDataResponse
+func (c *ClientWithResponses) ErasePlayerDataWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ErasePlayerDataResponse, error) {
+	rsp, err := c.ErasePlayerDataWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseErasePlayerDataResponse(rsp)
+}
+
+func (c *ClientWithResponses) ErasePlayerDataWithResponse(ctx context.Context, body ErasePlayerDataJSONRequestBody, reqEditors ...RequestEditorFn) (*ErasePlayerDataResponse, error) {
+	rsp, err := c.ErasePlayerData(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseErasePlayerDataResponse(rsp)
+}
+
+// ExportPlayerDataWithBodyWithResponse request with arbitrary body returning *ExportPlayerDataResponse
+func (c *ClientWithResponses) ExportPlayerDataWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ExportPlayerDataResponse, error) {
+	rsp, err := c.ExportPlayerDataWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseExportPlayerDataResponse(rsp)
+}
+
+func (c *ClientWithResponses) ExportPlayerDataWithResponse(ctx context.Context, body ExportPlayerDataJSONRequestBody, reqEditors ...RequestEditorFn) (*ExportPlayerDataResponse, error) {
+	rsp, err := c.ExportPlayerData(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseExportPlayerDataResponse(rsp)
+}
+
+// GetConfigWithResponse request returning *GetConfigResponse
+func (c *ClientWithResponses) GetConfigWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetConfigResponse, error) {
+	rsp, err := c.GetConfig(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetConfigResponse(rsp)
+}
+
+// GetCategoriesWithResponse request returning *GetCategoriesResponse
+func (c *ClientWithResponses) GetCategoriesWithResponse(ctx context.Context, params *GetCategoriesParams, reqEditors ...RequestEditorFn) (*GetCategoriesResponse, error) {
+	rsp, err := c.GetCategories(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetCategoriesResponse(rsp)
+}
+
+// GetLeaderboardWithResponse request returning *GetLeaderboardResponse
+func (c *ClientWithResponses) GetLeaderboardWithResponse(ctx context.Context, params *GetLeaderboardParams, reqEditors ...RequestEditorFn) (*GetLeaderboardResponse, error) {
+	rsp, err := c.GetLeaderboard(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetLeaderboardResponse(rsp)
+}
+
+// CreateWordListWithBodyWithResponse request with arbitrary body returning *CreateWordListResponse
+func (c *ClientWithResponses) CreateWordListWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*CreateWordListResponse, error) {
+	rsp, err := c.CreateWordListWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateWordListResponse(rsp)
+}
+
+func (c *ClientWithResponses) CreateWordListWithResponse(ctx context.Context, body CreateWordListJSONRequestBody, reqEditors ...RequestEditorFn) (*CreateWordListResponse, error) {
+	rsp, err := c.CreateWordList(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseCreateWordListResponse(rsp)
+}
+
+// ImportWordListWithBodyWithResponse request with arbitrary body returning *ImportWordListResponse
+func (c *ClientWithResponses) ImportWordListWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*ImportWordListResponse, error) {
+	rsp, err := c.ImportWordListWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseImportWordListResponse(rsp)
+}
+
+// GetWordListWithResponse request returning *GetWordListResponse
+func (c *ClientWithResponses) GetWordListWithResponse(ctx context.Context, shareCode string, params *GetWordListParams, reqEditors ...RequestEditorFn) (*GetWordListResponse, error) {
+	rsp, err := c.GetWordList(ctx, shareCode, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetWordListResponse(rsp)
+}
+
+// GetPassagesWithResponse request returning *GetPassagesResponse
+func (c *ClientWithResponses) GetPassagesWithResponse(ctx context.Context, params *GetPassagesParams, reqEditors ...RequestEditorFn) (*GetPassagesResponse, error) {
+	rsp, err := c.GetPassages(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetPassagesResponse(rsp)
+}
+
+// GetPassageWithResponse request returning *GetPassageResponse
+func (c *ClientWithResponses) GetPassageWithResponse(ctx context.Context, passageId string, params *GetPassageParams, reqEditors ...RequestEditorFn) (*GetPassageResponse, error) {
+	rsp, err := c.GetPassage(ctx, passageId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetPassageResponse(rsp)
+}
+
+// GetRulesetsWithResponse request returning *GetRulesetsResponse
+func (c *ClientWithResponses) GetRulesetsWithResponse(ctx context.Context, params *GetRulesetsParams, reqEditors ...RequestEditorFn) (*GetRulesetsResponse, error) {
+	rsp, err := c.GetRulesets(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetRulesetsResponse(rsp)
+}
+
+// SubmitScoreWithBodyWithResponse request with arbitrary body returning *SubmitScoreResponse
+func (c *ClientWithResponses) SubmitScoreWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*SubmitScoreResponse, error) {
+	rsp, err := c.SubmitScoreWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSubmitScoreResponse(rsp)
+}
+
+func (c *ClientWithResponses) SubmitScoreWithResponse(ctx context.Context, body SubmitScoreJSONRequestBody, reqEditors ...RequestEditorFn) (*SubmitScoreResponse, error) {
+	rsp, err := c.SubmitScore(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseSubmitScoreResponse(rsp)
+}
+
+// GetTranslationWithResponse request returning *GetTranslationResponse
+func (c *ClientWithResponses) GetTranslationWithResponse(ctx context.Context, wordId string, params *GetTranslationParams, reqEditors ...RequestEditorFn) (*GetTranslationResponse, error) {
+	rsp, err := c.GetTranslation(ctx, wordId, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetTranslationResponse(rsp)
+}
+
+// GetTypingWordsWithResponse request returning *GetTypingWordsResponse
+func (c *ClientWithResponses) GetTypingWordsWithResponse(ctx context.Context, category string, params *GetTypingWordsParams, reqEditors ...RequestEditorFn) (*GetTypingWordsResponse, error) {
+	rsp, err := c.GetTypingWords(ctx, category, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetTypingWordsResponse(rsp)
+}
+
+// GetWordsWithResponse request returning *GetWordsResponse
+func (c *ClientWithResponses) GetWordsWithResponse(ctx context.Context, category string, round int, params *GetWordsParams, reqEditors ...RequestEditorFn) (*GetWordsResponse, error) {
+	rsp, err := c.GetWords(ctx, category, round, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetWordsResponse(rsp)
+}
+
+// HealthCheckWithResponse request returning *HealthCheckResponse
+func (c *ClientWithResponses) HealthCheckWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*HealthCheckResponse, error) {
+	rsp, err := c.HealthCheck(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseHealthCheckResponse(rsp)
+}
+
+// LivenessWithResponse request returning *LivenessResponse
+func (c *ClientWithResponses) LivenessWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*LivenessResponse, error) {
+	rsp, err := c.Liveness(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseLivenessResponse(rsp)
+}
+
+// ReadinessWithResponse request returning *ReadinessResponse
+func (c *ClientWithResponses) ReadinessWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*ReadinessResponse, error) {
+	rsp, err := c.Readiness(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseReadinessResponse(rsp)
+}
+
+// GetOpenAPISpecWithResponse request returning *GetOpenAPISpecResponse
+func (c *ClientWithResponses) GetOpenAPISpecWithResponse(ctx context.Context, reqEditors ...RequestEditorFn) (*GetOpenAPISpecResponse, error) {
+	rsp, err := c.GetOpenAPISpec(ctx, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetOpenAPISpecResponse(rsp)
+}
+
+// ParseInvalidateCacheResponse parses an HTTP response from a InvalidateCacheWithResponse call
+func ParseInvalidateCacheResponse(rsp *http.Response) (*InvalidateCacheResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &InvalidateCacheResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest MessageResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseErasePlayerDataResponse parses an HTTP response from a ErasePlayerDataWithResponse call
+func ParseErasePlayerDataResponse(rsp *http.Response) (*ErasePlayerDataResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ErasePlayerDataResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ErasureReport
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 500:
+		var dest ErasureReport
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON500 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseExportPlayerDataResponse parses an HTTP response from a ExportPlayerDataWithResponse call
+func ParseExportPlayerDataResponse(rsp *http.Response) (*ExportPlayerDataResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ExportPlayerDataResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PlayerExport
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetConfigResponse parses an HTTP response from a GetConfigWithResponse call
+func ParseGetConfigResponse(rsp *http.Response) (*GetConfigResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetConfigResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ConfigResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetCategoriesResponse parses an HTTP response from a GetCategoriesWithResponse call
+func ParseGetCategoriesResponse(rsp *http.Response) (*GetCategoriesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetCategoriesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest CategoriesResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetLeaderboardResponse parses an HTTP response from a GetLeaderboardWithResponse call
+func ParseGetLeaderboardResponse(rsp *http.Response) (*GetLeaderboardResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetLeaderboardResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest LeaderboardResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseCreateWordListResponse parses an HTTP response from a CreateWordListWithResponse call
+func ParseCreateWordListResponse(rsp *http.Response) (*CreateWordListResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &CreateWordListResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest WordListCreatedResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseImportWordListResponse parses an HTTP response from a ImportWordListWithResponse call
+func ParseImportWordListResponse(rsp *http.Response) (*ImportWordListResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ImportWordListResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest WordListImportedResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetWordListResponse parses an HTTP response from a GetWordListWithResponse call
+func ParseGetWordListResponse(rsp *http.Response) (*GetWordListResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetWordListResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest WordListResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetPassagesResponse parses an HTTP response from a GetPassagesWithResponse call
+func ParseGetPassagesResponse(rsp *http.Response) (*GetPassagesResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetPassagesResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PassagesResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetPassageResponse parses an HTTP response from a GetPassageWithResponse call
+func ParseGetPassageResponse(rsp *http.Response) (*GetPassageResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetPassageResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest PassageResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetRulesetsResponse parses an HTTP response from a GetRulesetsWithResponse call
+func ParseGetRulesetsResponse(rsp *http.Response) (*GetRulesetsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetRulesetsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest RulesetsResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseSubmitScoreResponse parses an HTTP response from a SubmitScoreWithResponse call
+func ParseSubmitScoreResponse(rsp *http.Response) (*SubmitScoreResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &SubmitScoreResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ScoreSubmittedResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetTranslationResponse parses an HTTP response from a GetTranslationWithResponse call
+func ParseGetTranslationResponse(rsp *http.Response) (*GetTranslationResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetTranslationResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest TranslationResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetTypingWordsResponse parses an HTTP response from a GetTypingWordsWithResponse call
+func ParseGetTypingWordsResponse(rsp *http.Response) (*GetTypingWordsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetTypingWordsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest TypingWordsResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetWordsResponse parses an HTTP response from a GetWordsWithResponse call
+func ParseGetWordsResponse(rsp *http.Response) (*GetWordsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetWordsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest WordsResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseHealthCheckResponse parses an HTTP response from a HealthCheckWithResponse call
+func ParseHealthCheckResponse(rsp *http.Response) (*HealthCheckResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &HealthCheckResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest HealthStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseLivenessResponse parses an HTTP response from a LivenessWithResponse call
+func ParseLivenessResponse(rsp *http.Response) (*LivenessResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &LivenessResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest LivenessStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseReadinessResponse parses an HTTP response from a ReadinessWithResponse call
+func ParseReadinessResponse(rsp *http.Response) (*ReadinessResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &ReadinessResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest ReadinessStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 503:
+		var dest ReadinessStatus
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON503 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetOpenAPISpecResponse parses an HTTP response from a GetOpenAPISpecWithResponse call
+func ParseGetOpenAPISpecResponse(rsp *http.Response) (*GetOpenAPISpecResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetOpenAPISpecResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest map[string]interface{}
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && true:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSONDefault = &dest
+
+	}
+
+	return response, nil
+}
@@ -0,0 +1,12 @@
+// Package client は /api/v1 のGoクライアント。
+// client.gen.go は openapi/openapi.yaml から oapi-codegen で生成したもので、直接編集しない。
+// 定義を変更したら go generate ./client で再生成する。
+//
+// Usage:
+//
+//	c, err := client.NewClientWithResponses("https://example.com/api/v1")
+//	resp, err := c.GetWordsWithResponse(ctx, "beginner_words", 1, &client.GetWordsParams{})
+//	for _, w := range resp.JSON200.Words { ... }
+package client
+
+//go:generate go run github.com/oapi-codegen/oapi-codegen/v2/cmd/oapi-codegen@v2.4.1 -config oapi-codegen.yaml ../openapi/openapi.yaml
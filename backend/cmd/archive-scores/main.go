@@ -0,0 +1,273 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"typing-game-backend/archive"
+)
+
+// scores テーブルの保持ポリシー（TTL属性 expires_at）を運用するためのコマンド。
+//
+//	archive:  まもなく期限切れになるスコアを gzip 圧縮したJSONLファイルに書き出す。
+//	          前回どこまで書き出したかを状態ファイルに記録し、次回はその続きから書き出す
+//	backfill: expires_at のない既存のスコアに期限を設定し、同じトランザクションでプレイヤー累計に加算する。
+//	          登録時に加算済みのスコア（stats_counted、または -stats-since 以降のスコア）は期限だけを設定する
+//
+// Usage:
+//
+//	go run ./cmd/archive-scores -mode archive -out score-archive
+//	go run ./cmd/archive-scores -mode backfill -retention-days 365 -dry-run
+//	go run ./cmd/archive-scores -mode backfill -retention-days 365 -stats-since 2025-01-15T00:00:00+09:00
+
+// backfillGrace は backfill で設定する期限の最短猶予。既に保持期間を過ぎたスコアも
+// 次回の archive で書き出されるまで削除されないようにする
+const backfillGrace = 7 * 24 * time.Hour
+
+type archiveState struct {
+	// ArchivedUntil までに期限切れになるスコアは書き出し済み（Unix秒）
+	ArchivedUntil int64     `json:"archived_until"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+type scoreKey struct {
+	PlayerName string `dynamodbav:"player_name"`
+	Score      int    `dynamodbav:"score"`
+	Time       int    `dynamodbav:"time"`
+	Timestamp  int64  `dynamodbav:"timestamp"`
+	// StatsCounted は登録時にプレイヤー累計へ加算済みのスコア
+	StatsCounted bool `dynamodbav:"stats_counted"`
+}
+
+func main() {
+	var (
+		region      = flag.String("region", "ap-northeast-1", "AWS region")
+		mode        = flag.String("mode", "archive", "archive or backfill")
+		scoresTable = flag.String("scores-table", os.Getenv("SCORES_TABLE_NAME"), "DynamoDB scores table")
+		statsTable  = flag.String("player-stats-table", os.Getenv("PLAYER_STATS_TABLE_NAME"), "DynamoDB player stats table (backfill)")
+		days        = flag.Int("retention-days", envInt("SCORE_RETENTION_DAYS"), "score retention in days (backfill)")
+		out         = flag.String("out", "score-archive", "output directory (archive)")
+		ahead       = flag.Duration("ahead", 48*time.Hour, "archive scores expiring within this duration (archive)")
+		dryRun      = flag.Bool("dry-run", false, "count rows without writing (backfill)")
+		statsSince  = flag.String("stats-since", "", "RFC3339 time the player stats table was introduced; scores recorded since then are already counted (backfill)")
+	)
+	flag.Parse()
+
+	if *scoresTable == "" {
+		log.Fatalf("scores table is required (-scores-table or SCORES_TABLE_NAME)")
+	}
+
+	ctx := context.Background()
+
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(*region))
+	if err != nil {
+		log.Fatalf("failed to load AWS config: %v", err)
+	}
+	client := dynamodb.NewFromConfig(cfg)
+
+	switch *mode {
+	case "archive":
+		err = archiveExpiring(ctx, client, *scoresTable, *out, *ahead)
+	case "backfill":
+		if *days < 1 {
+			log.Fatalf("retention-days must be at least 1 for backfill")
+		}
+		var since int64
+		if *statsSince != "" {
+			t, err := time.Parse(time.RFC3339, *statsSince)
+			if err != nil {
+				log.Fatalf("invalid stats-since %q: %v", *statsSince, err)
+			}
+			since = t.Unix()
+		}
+		err = backfill(ctx, client, *scoresTable, *statsTable, *days, since, *dryRun)
+	default:
+		log.Fatalf("invalid mode %q (expected archive or backfill)", *mode)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// archiveExpiring は前回の続きから now+ahead までに期限切れになるスコアを1つのファイルに書き出す。
+// 途中で失敗した場合は状態ファイルを進めないため、再実行すると同じ範囲を書き出し直す
+func archiveExpiring(ctx context.Context, client *dynamodb.Client, table, out string, ahead time.Duration) error {
+	statePath := filepath.Join(out, "state.json")
+
+	var state archiveState
+	if _, err := archive.LoadState(statePath, &state); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	from := state.ArchivedUntil
+	until := now.Add(ahead).Unix()
+	if from >= until {
+		fmt.Printf("Nothing to archive (archived until %s)\n", time.Unix(from, 0).UTC().Format(time.RFC3339))
+		return nil
+	}
+	if from > 0 && from < now.Unix() {
+		fmt.Printf("Warning: last archive ended at %s; scores that expired since then may already be deleted\n", time.Unix(from, 0).UTC().Format(time.RFC3339))
+	}
+
+	path := filepath.Join(out, fmt.Sprintf("scores-expiring-%d-%d.jsonl.gz", from, until))
+	w, err := archive.Create(path)
+	if err != nil {
+		return err
+	}
+
+	paginator := dynamodb.NewScanPaginator(client, &dynamodb.ScanInput{
+		TableName:        aws.String(table),
+		FilterExpression: aws.String("expires_at >= :from AND expires_at < :until"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":from":  &types.AttributeValueMemberN{Value: strconv.FormatInt(from, 10)},
+			":until": &types.AttributeValueMemberN{Value: strconv.FormatInt(until, 10)},
+		},
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			w.Abort()
+			return fmt.Errorf("scan scores failed: %w", err)
+		}
+		for _, item := range page.Items {
+			if err := w.WriteItem(item); err != nil {
+				w.Abort()
+				return err
+			}
+		}
+	}
+
+	count := w.Count()
+	if err := w.Close(); err != nil {
+		return err
+	}
+
+	state = archiveState{ArchivedUntil: until, UpdatedAt: now.UTC()}
+	if err := archive.SaveState(statePath, state); err != nil {
+		return err
+	}
+
+	fmt.Printf("Archived %d scores expiring before %s to %s\n", count, time.Unix(until, 0).UTC().Format(time.RFC3339), path)
+	return nil
+}
+
+// backfill は expires_at のないスコアに期限を設定する。プレイヤー累計の導入前のスコアは、
+// 期限の設定と累計への加算を同じトランザクションで行い、二重に加算されないようにする。
+// 保持期間が無期限の間に登録されたスコアも expires_at を持たないが、登録時に累計へ加算済みのため
+// （stats_counted、または statsSince 以降の時刻）期限だけを設定する
+func backfill(ctx context.Context, client *dynamodb.Client, scoresTable, statsTable string, days int, statsSince int64, dryRun bool) error {
+	minExpiry := time.Now().Add(backfillGrace).Unix()
+
+	var scanned, updated, counted, skipped int
+	paginator := dynamodb.NewScanPaginator(client, &dynamodb.ScanInput{
+		TableName:        aws.String(scoresTable),
+		FilterExpression: aws.String("attribute_not_exists(expires_at)"),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("scan scores failed: %w", err)
+		}
+
+		var scores []scoreKey
+		if err := attributevalue.UnmarshalListOfMaps(page.Items, &scores); err != nil {
+			return fmt.Errorf("unmarshal scores failed: %w", err)
+		}
+
+		for _, score := range scores {
+			scanned++
+			if statsSince > 0 && score.Timestamp >= statsSince {
+				score.StatsCounted = true
+			}
+			if statsTable != "" && !score.StatsCounted {
+				counted++
+			}
+			if dryRun {
+				continue
+			}
+
+			expiresAt := time.Unix(score.Timestamp, 0).AddDate(0, 0, days).Unix()
+			if expiresAt < minExpiry {
+				expiresAt = minExpiry
+			}
+
+			_, err := client.TransactWriteItems(ctx, backfillWrites(scoresTable, statsTable, score, expiresAt))
+			var canceled *types.TransactionCanceledException
+			switch {
+			case err == nil:
+				updated++
+			case errors.As(err, &canceled):
+				// 別の実行で設定済み
+				skipped++
+			default:
+				return fmt.Errorf("backfill %s/%d failed: %w", score.PlayerName, score.Timestamp, err)
+			}
+		}
+	}
+
+	fmt.Printf("Done. scanned=%d updated=%d stats_added=%d skipped=%d dry_run=%t\n", scanned, updated, counted, skipped, dryRun)
+	return nil
+}
+
+// backfillWrites は期限を設定する書き込みを返す。累計に加算していないスコアは、加算と
+// stats_counted の設定を同じトランザクションに含める（条件で加算済みのスコアへの再加算を防ぐ）
+func backfillWrites(scoresTable, statsTable string, score scoreKey, expiresAt int64) *dynamodb.TransactWriteItemsInput {
+	ts := strconv.FormatInt(score.Timestamp, 10)
+	update := &types.Update{
+		TableName: aws.String(scoresTable),
+		Key: map[string]types.AttributeValue{
+			"player_name": &types.AttributeValueMemberS{Value: score.PlayerName},
+			"timestamp":   &types.AttributeValueMemberN{Value: ts},
+		},
+		UpdateExpression:    aws.String("SET expires_at = :expires_at"),
+		ConditionExpression: aws.String("attribute_not_exists(expires_at)"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":expires_at": &types.AttributeValueMemberN{Value: strconv.FormatInt(expiresAt, 10)},
+		},
+	}
+	writes := []types.TransactWriteItem{{Update: update}}
+
+	if statsTable != "" && !score.StatsCounted {
+		update.UpdateExpression = aws.String("SET expires_at = :expires_at, stats_counted = :counted")
+		update.ConditionExpression = aws.String("attribute_not_exists(expires_at) AND attribute_not_exists(stats_counted)")
+		update.ExpressionAttributeValues[":counted"] = &types.AttributeValueMemberBOOL{Value: true}
+		writes = append(writes, types.TransactWriteItem{Update: &types.Update{
+			TableName: aws.String(statsTable),
+			Key: map[string]types.AttributeValue{
+				"player_name": &types.AttributeValueMemberS{Value: score.PlayerName},
+			},
+			UpdateExpression: aws.String("ADD games_played :one, total_score :score, total_time :time " +
+				"SET first_played = if_not_exists(first_played, :ts), last_played = if_not_exists(last_played, :ts)"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":one":   &types.AttributeValueMemberN{Value: "1"},
+				":score": &types.AttributeValueMemberN{Value: strconv.Itoa(score.Score)},
+				":time":  &types.AttributeValueMemberN{Value: strconv.Itoa(score.Time)},
+				":ts":    &types.AttributeValueMemberN{Value: ts},
+			},
+		}})
+	}
+
+	return &dynamodb.TransactWriteItemsInput{TransactItems: writes}
+}
+
+func envInt(key string) int {
+	n, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return 0
+	}
+	return n
+}
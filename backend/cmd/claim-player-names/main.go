@@ -0,0 +1,117 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"typing-game-backend/names"
+)
+
+// リーダーボードにいる既存のプレイヤーの名前を player names テーブルに登録する（名前の登録を導入する前のデータ用）。
+// 登録しておかないと、既存のプレイヤーと紛らわしい名前を新しいプレイヤーが先に登録できてしまう。
+// 紛らわしい名前の既存プレイヤーが複数いる場合は、先に記録したプレイヤーで登録し、残りを一覧として出力する
+// （残りのプレイヤーもリーダーボードに記録があるため、引き続き投稿できる）。
+//
+// Usage:
+//
+//	go run ./cmd/claim-player-names -dry-run
+//	go run ./cmd/claim-player-names
+
+type leaderboardEntry struct {
+	PlayerName string `dynamodbav:"player_name"`
+	Timestamp  int64  `dynamodbav:"timestamp"`
+}
+
+func main() {
+	var (
+		region           = flag.String("region", "ap-northeast-1", "AWS region")
+		leaderboardTable = flag.String("leaderboard-table", os.Getenv("LEADERBOARD_TABLE_NAME"), "DynamoDB leaderboard table")
+		namesTable       = flag.String("player-names-table", os.Getenv("PLAYER_NAMES_TABLE_NAME"), "DynamoDB player names table")
+		dryRun           = flag.Bool("dry-run", false, "list collisions without writing")
+	)
+	flag.Parse()
+
+	if *leaderboardTable == "" || *namesTable == "" {
+		log.Fatalf("leaderboard and player names tables are required (-leaderboard-table / -player-names-table)")
+	}
+
+	ctx := context.Background()
+
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(*region))
+	if err != nil {
+		log.Fatalf("failed to load AWS config: %v", err)
+	}
+	client := dynamodb.NewFromConfig(cfg)
+
+	var entries []leaderboardEntry
+	paginator := dynamodb.NewScanPaginator(client, &dynamodb.ScanInput{
+		TableName:            aws.String(*leaderboardTable),
+		ProjectionExpression: aws.String("player_name, #ts"),
+		ExpressionAttributeNames: map[string]string{
+			"#ts": "timestamp",
+		},
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			log.Fatalf("scan leaderboard failed: %v", err)
+		}
+		var pageEntries []leaderboardEntry
+		if err := attributevalue.UnmarshalListOfMaps(page.Items, &pageEntries); err != nil {
+			log.Fatalf("unmarshal leaderboard failed: %v", err)
+		}
+		entries = append(entries, pageEntries...)
+	}
+
+	// 先に記録したプレイヤーを優先する（timestamp のない古い記録は最初）
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Timestamp < entries[j].Timestamp })
+
+	owners := make(map[string]string)
+	var claimed, existing, collisions int
+	for _, entry := range entries {
+		key := names.Skeleton(entry.PlayerName)
+		if owner, ok := owners[key]; ok && owner != entry.PlayerName {
+			fmt.Printf("collision: %q looks like %q (key %q)\n", entry.PlayerName, owner, key)
+			collisions++
+			continue
+		}
+		owners[key] = entry.PlayerName
+		if *dryRun {
+			continue
+		}
+
+		_, err := client.PutItem(ctx, &dynamodb.PutItemInput{
+			TableName: aws.String(*namesTable),
+			Item: map[string]types.AttributeValue{
+				"name_key":    &types.AttributeValueMemberS{Value: key},
+				"player_name": &types.AttributeValueMemberS{Value: entry.PlayerName},
+				"claimed_at":  &types.AttributeValueMemberN{Value: strconv.FormatInt(entry.Timestamp, 10)},
+				"last_used":   &types.AttributeValueMemberN{Value: strconv.FormatInt(entry.Timestamp, 10)},
+			},
+			ConditionExpression: aws.String("attribute_not_exists(name_key)"),
+		})
+		var alreadyClaimed *types.ConditionalCheckFailedException
+		switch {
+		case err == nil:
+			claimed++
+		case errors.As(err, &alreadyClaimed):
+			existing++
+		default:
+			log.Fatalf("claim %q failed: %v", entry.PlayerName, err)
+		}
+	}
+
+	fmt.Printf("Done. players=%d claimed=%d already_claimed=%d collisions=%d dry_run=%t\n", len(entries), claimed, existing, collisions, *dryRun)
+}
@@ -0,0 +1,189 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"typing-game-backend/config"
+	"typing-game-backend/deckimport"
+	"typing-game-backend/furigana"
+	"typing-game-backend/game"
+	"typing-game-backend/wordlist"
+)
+
+// shareCodeAttempts は共有コードが使われていた場合に作り直す回数（API と同じ）
+const shareCodeAttempts = 5
+
+// translationItem は translations テーブルの項目
+type translationItem struct {
+	WordID      string `dynamodbav:"word_id"`
+	Language    string `dynamodbav:"language"`
+	Translation string `dynamodbav:"translation"`
+	Category    string `dynamodbav:"category"`
+	CreatedAt   string `dynamodbav:"created_at"`
+	UpdatedAt   string `dynamodbav:"updated_at"`
+}
+
+// parseDeck は単語帳のファイルから maxNotes 個までのノートを読み、単語にする
+func parseDeck(path string, opts deckimport.Options, maxNotes int) (*deckimport.Deck, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	notes, err := deckimport.Read(data, maxNotes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to import %s: %w", path, err)
+	}
+	deck, err := deckimport.Build(notes, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to import %s: %w", path, err)
+	}
+	if len(deck.Entries) == 0 && len(deck.Invalid) == 0 {
+		return nil, fmt.Errorf("failed to import %s: no notes with a front", path)
+	}
+	return deck, nil
+}
+
+// loadMaxRounds はルールセットの最も多いラウンド数を返す
+func loadMaxRounds(rulesetsDir string) (int, error) {
+	// 組み込みの v1 は常にあるため、新しいゲームのバージョンには v1 を使う（最も多いラウンド数はすべてのルールセットから求める）
+	rulesets, err := game.Load(game.Options{Dir: rulesetsDir, Current: "v1"})
+	if err != nil {
+		return 0, fmt.Errorf("failed to load rulesets: %w", err)
+	}
+	return rulesets.MaxRounds(), nil
+}
+
+// checkDeckLimits は1ラウンドの単語数と、ラウンド数が最も多いラウンド数を超えないかを確かめる
+// （超えたラウンドの単語は遊ばれない）
+func checkDeckLimits(deck *deckimport.Deck, wordsPerRound, maxRounds int) error {
+	if wordsPerRound < 1 || wordsPerRound > wordlist.MaxWordsPerRound {
+		return fmt.Errorf("words per round must be between 1 and %d", wordlist.MaxWordsPerRound)
+	}
+	if limit := wordsPerRound * maxRounds; len(deck.Entries) > limit {
+		return fmt.Errorf("%d words do not fit in %d rounds of %d words; raise -words-per-round or split the deck", len(deck.Entries), maxRounds, wordsPerRound)
+	}
+	return nil
+}
+
+// printDeck は取り込む単語を易しい順に表示し（日本語はルビ付きの表示形と読み）、取り込まないノートを理由とともに表示する。
+// 確認が必要な単語の数を返す
+func printDeck(deck *deckimport.Deck) int {
+	fmt.Printf("%d words (%s, translations: %s), %d skipped, %d invalid\n",
+		len(deck.Entries), deck.Language, orNone(deck.TranslationLanguage), len(deck.Skipped), len(deck.Invalid))
+	review := 0
+	for _, e := range deck.Entries {
+		w := e.Word
+		id := w.WordID
+		if id == "" {
+			id = fmt.Sprintf("round %d", w.Round)
+		}
+		display := w.Display
+		if len(w.Furigana) > 0 {
+			display = furigana.Ruby(w.Furigana)
+		}
+		fmt.Printf("  %-36s %s\t%s\t%s\n", id, display, w.Word, e.Translation)
+		if len(w.Review) > 0 {
+			review++
+			fmt.Printf("  %-36s REVIEW %s (%s)\n", "", strings.Join(w.Review, ", "), e.Ref)
+		}
+	}
+	for _, issue := range deck.Skipped {
+		fmt.Printf("  SKIPPED %s: %s\n", issue.Ref, issue.Reason)
+	}
+	for _, issue := range deck.Invalid {
+		fmt.Printf("  INVALID %s: %s\n", issue.Ref, issue.Reason)
+	}
+	if review > 0 {
+		fmt.Printf("%d words need review: the first reading was used for words with more than one reading\n", review)
+	}
+	return review
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "none"
+	}
+	return s
+}
+
+// putCategory は単語を words テーブルに、翻訳を translations テーブルに書き込む
+func putCategory(ctx context.Context, client *dynamodb.Client, wordsTable, translationsTable string, deck *deckimport.Deck) error {
+	now := time.Now().Format(time.RFC3339)
+	for _, e := range deck.Entries {
+		if err := putItem(ctx, client, wordsTable, e.Word); err != nil {
+			return fmt.Errorf("word %s: %w", e.Word.WordID, err)
+		}
+		if e.Translation == "" {
+			continue
+		}
+		t := translationItem{
+			WordID:      e.Word.WordID,
+			Language:    deck.TranslationLanguage,
+			Translation: e.Translation,
+			Category:    e.Word.Category,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+		if err := putItem(ctx, client, translationsTable, t); err != nil {
+			return fmt.Errorf("translation of %s: %w", e.Word.WordID, err)
+		}
+	}
+	return nil
+}
+
+// createList は共有コードを付けたカスタム単語リストを word_lists テーブルに作成する。
+// 共有コードが使われていた場合は作り直す
+func createList(ctx context.Context, client *dynamodb.Client, table string, spec wordlist.Spec) (*wordlist.List, error) {
+	for attempt := 1; attempt <= shareCodeAttempts; attempt++ {
+		code, err := wordlist.NewShareCode()
+		if err != nil {
+			return nil, err
+		}
+		l := wordlist.New(code, spec, time.Now())
+		item, err := attributevalue.MarshalMap(l)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal word list: %w", err)
+		}
+		_, err = client.PutItem(ctx, &dynamodb.PutItemInput{
+			TableName:           aws.String(table),
+			Item:                item,
+			ConditionExpression: aws.String("attribute_not_exists(list_id)"),
+		})
+		var taken *types.ConditionalCheckFailedException
+		if errors.As(err, &taken) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("put item into %s failed: %w", table, err)
+		}
+		return l, nil
+	}
+	return nil, fmt.Errorf("failed to generate an unused share code after %d attempts", shareCodeAttempts)
+}
+
+// checkListSpec は API と同じリストの名前・言語の条件を確かめる（言語は API の既定の設定）
+func checkListSpec(spec wordlist.Spec) error {
+	if n := utf8.RuneCountInString(spec.Name); n < 1 || n > wordlist.MaxNameLength {
+		return fmt.Errorf("list name must be 1 to %d characters", wordlist.MaxNameLength)
+	}
+	defaults := config.Default().Game
+	if !slices.Contains(defaults.WordLanguages, spec.Language) {
+		return fmt.Errorf("word lists do not support language %q (supported: %v)", spec.Language, defaults.WordLanguages)
+	}
+	if spec.TranslationLanguage != "" && !slices.Contains(defaults.TranslationLanguages, spec.TranslationLanguage) {
+		return fmt.Errorf("word lists do not support translation language %q (supported: %v)", spec.TranslationLanguage, defaults.TranslationLanguages)
+	}
+	return nil
+}
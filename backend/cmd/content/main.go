@@ -0,0 +1,341 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+
+	"typing-game-backend/deckimport"
+	"typing-game-backend/furigana"
+	"typing-game-backend/kanadict"
+	"typing-game-backend/names"
+	"typing-game-backend/passage"
+	"typing-game-backend/wordimport"
+	"typing-game-backend/wordlist"
+)
+
+// ゲームのコンテンツ（文章・単語）をファイルから取り込むためのコマンド。
+//
+//	import-passages: プレーンテキストの文章を passages テーブルに取り込む。空行で段落を分け、
+//	                 段落を 。！？. などで文に分ける。日本語の漢字には青空文庫形式のルビ
+//	                 （漢字《かんじ》・｜漢字かな《かんじかな》）で読みを付ける
+//	import-words:    タブ区切りの単語リスト（round・type・display・reading）を words テーブルに取り込む。
+//	                 日本語の単語は読みが表示形の区間に一致するかを検証し、区間ごとの読み（ふりがな）を保存する。
+//	                 読みのない漢字には組み込みの辞書（kanadict）で読みを付け、読みが複数ある語は確認が必要な語として表示する
+//	import-deck:     Anki のデッキ（.apkg）・タブ区切りのテキストの単語帳を、カテゴリー（-category: words・translations テーブル）か
+//	                 カスタム単語リスト（-list-name: word_lists テーブル、共有コードを表示する）に取り込む。
+//	                 ノートの表面を単語、裏面を翻訳にし、言語を省略した場合は文字の種類から推定して、打鍵数の少ない順にラウンドに分ける
+//	build-dictionary: IPADIC 形式の CSV（UTF-8、カンマ区切りで複数指定）から組み込みの辞書のファイルを作る
+//
+// 文章・単語の ID は内容・行の順序から決まるため、同じファイルを取り込み直しても項目は増えない。
+//
+// Usage:
+//
+//	go run ./cmd/content -mode import-passages -in walk.txt -language jp -title "あさのさんぽ" -source "..." -license CC0-1.0 -dry-run
+//	go run ./cmd/content -mode import-words -in beginner_words.tsv -category beginner_words -language jp -dry-run
+//	go run ./cmd/content -mode import-deck -in japanese.apkg -list-name "JLPT N5" -words-per-round 10 -dry-run
+//	go run ./cmd/content -mode build-dictionary -in Noun.csv,Verb.csv -out kanadict/dict/ipadic.tsv
+func main() {
+	var (
+		region              = flag.String("region", "ap-northeast-1", "AWS region")
+		mode                = flag.String("mode", "import-passages", "import-passages, import-words, import-deck or build-dictionary")
+		passagesTable       = flag.String("passages-table", os.Getenv("PASSAGES_TABLE_NAME"), "DynamoDB passages table (import-passages)")
+		wordsTable          = flag.String("words-table", os.Getenv("WORDS_TABLE_NAME"), "DynamoDB words table (import-words, import-deck)")
+		translationsTable   = flag.String("translations-table", os.Getenv("TRANSLATIONS_TABLE_NAME"), "DynamoDB translations table (import-deck)")
+		wordListsTable      = flag.String("word-lists-table", os.Getenv("WORD_LISTS_TABLE_NAME"), "DynamoDB word lists table (import-deck)")
+		rulesetsDir         = flag.String("rulesets-dir", os.Getenv("RULESETS_DIR"), "directory of additional rulesets (import-deck: the most rounds limits the words)")
+		in                  = flag.String("in", "", "input file (UTF-8, or .apkg for import-deck)")
+		language            = flag.String("language", "jp", "content language (import-deck infers it from the fronts when omitted)")
+		title               = flag.String("title", "", "passage title (import-passages, defaults to the file name)")
+		source              = flag.String("source", "", "source of the text (import-passages: book title, URL, ...)")
+		license             = flag.String("license", "", "license of the text (import-passages: CC0-1.0, Public Domain, ...)")
+		category            = flag.String("category", "", "word category (import-words, import-deck)")
+		listName            = flag.String("list-name", "", "custom word list name (import-deck, instead of -category)")
+		wordsPerRound       = flag.Int("words-per-round", 10, "words per round (import-deck)")
+		translationLanguage = flag.String("translation-language", "", "language of the backs (import-deck, inferred when omitted)")
+		frontField          = flag.String("front-field", "", "field name or number (from 1) of the word (import-deck, default: Front or the first field)")
+		backField           = flag.String("back-field", "", "field name or number of the translation (import-deck, default: Back or the second field)")
+		readingField        = flag.String("reading-field", "", "field name or number of the Japanese reading (import-deck, default: Reading)")
+		skipInvalid         = flag.Bool("skip-invalid", false, "import the other notes when some cannot be words (import-deck)")
+		useDictionary       = flag.Bool("dictionary", true, "generate readings for kanji without one from the embedded dictionary (import-words, import-deck)")
+		acceptReview        = flag.Bool("accept-review", false, "write words whose generated readings need review (import-words, import-deck)")
+		out                 = flag.String("out", "", "output dictionary file (build-dictionary)")
+		dryRun              = flag.Bool("dry-run", false, "print the parsed content without writing")
+	)
+	flag.Parse()
+	languageSet := false
+	flag.Visit(func(f *flag.Flag) { languageSet = languageSet || f.Name == "language" })
+
+	if *in == "" {
+		log.Fatalf("input file is required (-in)")
+	}
+
+	ctx := context.Background()
+
+	switch *mode {
+	case "import-passages":
+		if *title == "" {
+			*title = strings.TrimSuffix(filepath.Base(*in), filepath.Ext(*in))
+		}
+		p, err := parsePassage(*in, passage.Options{Language: *language, Title: *title, Source: *source, License: *license})
+		if err != nil {
+			log.Fatal(err)
+		}
+		printPassage(p)
+		if *dryRun {
+			fmt.Println("Dry run: nothing was written")
+			return
+		}
+		if *passagesTable == "" {
+			log.Fatalf("passages table is required (-passages-table or PASSAGES_TABLE_NAME)")
+		}
+		if err := putItem(ctx, newClient(ctx, *region), *passagesTable, p); err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("Imported passage %s into %s\n", p.PassageID, *passagesTable)
+	case "import-words":
+		if *category == "" {
+			log.Fatalf("category is required for import-words (-category)")
+		}
+		opts := wordimport.Options{Category: *category, Language: *language}
+		if *useDictionary && *language == "jp" {
+			opts.Dictionary = kanadict.Default()
+		}
+		words, err := parseWords(*in, opts)
+		if err != nil {
+			log.Fatal(err)
+		}
+		review := printWords(words)
+		if *dryRun {
+			fmt.Println("Dry run: nothing was written")
+			return
+		}
+		if review > 0 && !*acceptReview {
+			log.Fatalf("%d words have generated readings that need review; add readings or ruby, or pass -accept-review", review)
+		}
+		if *wordsTable == "" {
+			log.Fatalf("words table is required (-words-table or WORDS_TABLE_NAME)")
+		}
+		client := newClient(ctx, *region)
+		for _, w := range words {
+			if err := putItem(ctx, client, *wordsTable, w); err != nil {
+				log.Fatalf("word %s: %v", w.WordID, err)
+			}
+		}
+		fmt.Printf("Imported %d words into %s\n", len(words), *wordsTable)
+	case "import-deck":
+		if (*category == "") == (*listName == "") {
+			log.Fatalf("either -category or -list-name is required for import-deck")
+		}
+		opts := deckimport.Options{
+			Category:            *category,
+			TranslationLanguage: *translationLanguage,
+			WordsPerRound:       max(*wordsPerRound, 1),
+			Fields:              deckimport.Fields{Front: *frontField, Back: *backField, Reading: *readingField},
+			// カスタム単語リストと同じ上限（カテゴリーの翻訳も同じ画面に表示する）
+			MaxWordLength:        wordlist.MaxWordLength,
+			MaxTranslationLength: wordlist.MaxTranslationLength,
+		}
+		if languageSet {
+			opts.Language = *language
+		}
+		if *useDictionary {
+			opts.Dictionary = kanadict.Default()
+		}
+		maxRounds, err := loadMaxRounds(*rulesetsDir)
+		if err != nil {
+			log.Fatal(err)
+		}
+		// 単語リスト・カテゴリーに入りきらないほどのノートは読まない
+		deck, err := parseDeck(*in, opts, wordlist.MaxWordsPerRound*maxRounds)
+		if err != nil {
+			log.Fatal(err)
+		}
+		review := printDeck(deck)
+		if err := checkDeckLimits(deck, *wordsPerRound, maxRounds); err != nil {
+			log.Fatal(err)
+		}
+		spec := wordlist.Spec{
+			Name:                names.Normalize(*listName),
+			Language:            deck.Language,
+			WordsPerRound:       *wordsPerRound,
+			Words:               deck.Words(),
+			TranslationLanguage: deck.TranslationLanguage,
+			Translations:        deck.Translations(),
+		}
+		if *listName != "" {
+			if err := checkListSpec(spec); err != nil {
+				log.Fatal(err)
+			}
+		}
+		if *dryRun {
+			fmt.Println("Dry run: nothing was written")
+			return
+		}
+		if len(deck.Invalid) > 0 && !*skipInvalid {
+			log.Fatalf("%d notes cannot be words; fix them, or pass -skip-invalid to import the others", len(deck.Invalid))
+		}
+		if review > 0 && !*acceptReview {
+			log.Fatalf("%d words have generated readings that need review; add readings or furigana, or pass -accept-review", review)
+		}
+		if len(deck.Entries) == 0 {
+			log.Fatalf("no words to import")
+		}
+
+		if *listName != "" {
+			if *wordListsTable == "" {
+				log.Fatalf("word lists table is required (-word-lists-table or WORD_LISTS_TABLE_NAME)")
+			}
+			l, err := createList(ctx, newClient(ctx, *region), *wordListsTable, spec)
+			if err != nil {
+				log.Fatal(err)
+			}
+			fmt.Printf("Imported %d words into word list %q (share code %s, %d rounds)\n", len(l.Words), l.Name, l.ShareCode, l.Rounds)
+			return
+		}
+		if *wordsTable == "" {
+			log.Fatalf("words table is required (-words-table or WORDS_TABLE_NAME)")
+		}
+		if deck.TranslationLanguage != "" && *translationsTable == "" {
+			log.Fatalf("translations table is required (-translations-table or TRANSLATIONS_TABLE_NAME)")
+		}
+		if err := putCategory(ctx, newClient(ctx, *region), *wordsTable, *translationsTable, deck); err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("Imported %d words into %s (category %s)\n", len(deck.Entries), *wordsTable, *category)
+	case "build-dictionary":
+		if *out == "" {
+			log.Fatalf("output file is required for build-dictionary (-out)")
+		}
+		if err := buildDictionary(strings.Split(*in, ","), *out); err != nil {
+			log.Fatal(err)
+		}
+	default:
+		log.Fatalf("invalid mode %q (expected import-passages, import-words, import-deck or build-dictionary)", *mode)
+	}
+}
+
+func newClient(ctx context.Context, region string) *dynamodb.Client {
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(region))
+	if err != nil {
+		log.Fatalf("failed to load AWS config: %v", err)
+	}
+	return dynamodb.NewFromConfig(cfg)
+}
+
+func parsePassage(path string, opts passage.Options) (*passage.Passage, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	p, err := passage.Parse(f, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to import %s:\n%w", path, err)
+	}
+	return p, nil
+}
+
+// printPassage は取り込む文章を文ごとに表示する（読みが表示テキストと違う文は読みも表示する）
+func printPassage(p *passage.Passage) {
+	fmt.Printf("Passage %s: %q (%s, %d sentences, %d characters, source %q, license %q)\n",
+		p.PassageID, p.Title, p.Language, len(p.Sentences), p.Characters, p.Source, p.License)
+	for i, s := range p.Sentences {
+		fmt.Printf("  %d-%d %s\n", s.Paragraph+1, i+1, s.Text)
+		if s.Reading != s.Text {
+			fmt.Printf("  %s %s\n", strings.Repeat(" ", utf8.RuneCountInString(fmt.Sprintf("%d-%d", s.Paragraph+1, i+1))), s.Reading)
+		}
+	}
+}
+
+func parseWords(path string, opts wordimport.Options) ([]wordimport.Word, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	words, err := wordimport.Parse(f, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to import %s:\n%w", path, err)
+	}
+	return words, nil
+}
+
+// printWords は取り込む単語を表示し（日本語はルビ付きの表示形と読み）、確認が必要な単語の数を返す
+func printWords(words []wordimport.Word) int {
+	fmt.Printf("%d words\n", len(words))
+	review := 0
+	for _, w := range words {
+		display := w.Display
+		if len(w.Furigana) > 0 {
+			display = furigana.Ruby(w.Furigana)
+		}
+		fmt.Printf("  %-36s %s\t%s\n", w.WordID, display, w.Word)
+		if len(w.Review) > 0 {
+			review++
+			fmt.Printf("  %-36s REVIEW %s\n", "", strings.Join(w.Review, ", "))
+		}
+	}
+	if review > 0 {
+		fmt.Printf("%d words need review: the first reading was used for words with more than one reading\n", review)
+	}
+	return review
+}
+
+// buildDictionary は IPADIC 形式の CSV から辞書のファイルを作る
+func buildDictionary(paths []string, out string) error {
+	d := kanadict.New()
+	for _, p := range paths {
+		f, err := os.Open(p)
+		if err != nil {
+			return fmt.Errorf("failed to open %s: %w", p, err)
+		}
+		added, err := d.LoadIPADIC(f)
+		f.Close()
+		if err != nil {
+			return fmt.Errorf("%s: %w", p, err)
+		}
+		fmt.Printf("%s: %d entries\n", p, added)
+	}
+
+	f, err := os.Create(out)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", out, err)
+	}
+	fmt.Fprintln(f, "# IPADIC 形式の CSV から cmd/content -mode build-dictionary で作った辞書（手で編集しない）")
+	if err := d.Write(f); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write %s: %w", out, err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", out, err)
+	}
+	fmt.Printf("Wrote %d entries to %s\n", d.Len(), out)
+	return nil
+}
+
+func putItem(ctx context.Context, client *dynamodb.Client, table string, v any) error {
+	item, err := attributevalue.MarshalMap(v)
+	if err != nil {
+		return fmt.Errorf("failed to marshal item: %w", err)
+	}
+	if _, err := client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(table),
+		Item:      item,
+	}); err != nil {
+		return fmt.Errorf("put item into %s failed: %w", table, err)
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+
+	"typing-game-backend/archive"
+)
+
+// scores・leaderboard テーブルの全件を分析用にローカルファイルへ書き出す。
+// 1ページごとに <out>/<table>/part-00001.jsonl.gz を作成し、checkpoint.json に続きのキーを記録するため、
+// 中断しても同じコマンドで途中から再開できる。
+//
+// Usage:
+//
+//	go run ./cmd/export-tables -out export
+//	go run ./cmd/export-tables -out export -tables typing-game-scores-production -restart
+
+type checkpoint struct {
+	Table     string                      `json:"table"`
+	NextPart  int                         `json:"next_part"`
+	LastKey   map[string]archive.KeyValue `json:"last_key,omitempty"`
+	Items     int64                       `json:"items"`
+	Complete  bool                        `json:"complete"`
+	UpdatedAt time.Time                   `json:"updated_at"`
+}
+
+func main() {
+	defaultTables := strings.Join(nonEmpty(os.Getenv("SCORES_TABLE_NAME"), os.Getenv("LEADERBOARD_TABLE_NAME")), ",")
+
+	var (
+		region   = flag.String("region", "ap-northeast-1", "AWS region")
+		out      = flag.String("out", "export", "output directory")
+		tables   = flag.String("tables", defaultTables, "comma-separated tables to export (default: SCORES_TABLE_NAME, LEADERBOARD_TABLE_NAME)")
+		pageSize = flag.Int("page-size", 1000, "items per scan page (one output file per page)")
+		restart  = flag.Bool("restart", false, "ignore existing checkpoints and export from the beginning")
+	)
+	flag.Parse()
+
+	names := nonEmpty(strings.Split(*tables, ",")...)
+	if len(names) == 0 {
+		log.Fatalf("no tables to export (-tables or SCORES_TABLE_NAME / LEADERBOARD_TABLE_NAME)")
+	}
+	if *pageSize < 1 {
+		log.Fatalf("page-size must be at least 1")
+	}
+
+	ctx := context.Background()
+
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(*region))
+	if err != nil {
+		log.Fatalf("failed to load AWS config: %v", err)
+	}
+	client := dynamodb.NewFromConfig(cfg)
+
+	for _, table := range names {
+		if err := exportTable(ctx, client, table, filepath.Join(*out, table), int32(*pageSize), *restart); err != nil {
+			log.Fatalf("export %s failed: %v", table, err)
+		}
+	}
+}
+
+func exportTable(ctx context.Context, client *dynamodb.Client, table, dir string, pageSize int32, restart bool) error {
+	checkpointPath := filepath.Join(dir, "checkpoint.json")
+
+	state := checkpoint{Table: table, NextPart: 1}
+	if !restart {
+		found, err := archive.LoadState(checkpointPath, &state)
+		if err != nil {
+			return err
+		}
+		if found && state.Complete {
+			fmt.Printf("%s: already complete (%d items, %d files), use -restart to export again\n", table, state.Items, state.NextPart-1)
+			return nil
+		}
+		if found {
+			fmt.Printf("%s: resuming from part %d (%d items exported)\n", table, state.NextPart, state.Items)
+		}
+	}
+
+	paginator := dynamodb.NewScanPaginator(client, &dynamodb.ScanInput{
+		TableName:         aws.String(table),
+		Limit:             aws.Int32(pageSize),
+		ExclusiveStartKey: archive.DecodeKey(state.LastKey),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("scan failed: %w", err)
+		}
+
+		if len(page.Items) > 0 {
+			w, err := archive.Create(filepath.Join(dir, fmt.Sprintf("part-%05d.jsonl.gz", state.NextPart)))
+			if err != nil {
+				return err
+			}
+			for _, item := range page.Items {
+				if err := w.WriteItem(item); err != nil {
+					w.Abort()
+					return err
+				}
+			}
+			if err := w.Close(); err != nil {
+				return err
+			}
+			state.NextPart++
+			state.Items += int64(len(page.Items))
+		}
+
+		// ファイルを書き終えてからチェックポイントを進める
+		lastKey, err := archive.EncodeKey(page.LastEvaluatedKey)
+		if err != nil {
+			return err
+		}
+		state.LastKey = lastKey
+		state.Complete = len(page.LastEvaluatedKey) == 0
+		state.UpdatedAt = time.Now().UTC()
+		if err := archive.SaveState(checkpointPath, state); err != nil {
+			return err
+		}
+	}
+
+	// 空のテーブルではページが返らないことがあるため、ここで完了を記録する
+	state.Complete = true
+	state.LastKey = nil
+	state.UpdatedAt = time.Now().UTC()
+	if err := archive.SaveState(checkpointPath, state); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s: exported %d items to %s\n", table, state.Items, dir)
+	return nil
+}
+
+func nonEmpty(values ...string) []string {
+	var result []string
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			result = append(result, v)
+		}
+	}
+	return result
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+
+	"typing-game-backend/privacy"
+)
+
+// プレイヤー1人分のデータをエクスポート・削除・匿名化する（保護者からの依頼などに対応するため）。
+// 削除・匿名化の結果は、処理後の再検索による検証とダイジェストを含むレポートとして出力する。
+//
+// Usage:
+//
+//	go run ./cmd/player-data -mode export -player "プレイヤー名" -out export.json
+//	go run ./cmd/player-data -mode delete -player "プレイヤー名" -out report.json
+//	go run ./cmd/player-data -mode anonymize -player "プレイヤー名"
+//	go run ./cmd/player-data -mode verify -report report.json
+
+func main() {
+	var (
+		region  = flag.String("region", "ap-northeast-1", "AWS region")
+		mode    = flag.String("mode", "export", "export, delete, anonymize or verify")
+		player  = flag.String("player", "", "player name")
+		out     = flag.String("out", "", "output file (default: stdout)")
+		report  = flag.String("report", "", "report file to verify (verify)")
+		confirm = flag.Bool("yes", false, "skip the confirmation prompt for delete / anonymize")
+		tables  privacy.Tables
+	)
+	flag.StringVar(&tables.Scores, "scores-table", os.Getenv("SCORES_TABLE_NAME"), "DynamoDB scores table")
+	flag.StringVar(&tables.Leaderboard, "leaderboard-table", os.Getenv("LEADERBOARD_TABLE_NAME"), "DynamoDB leaderboard table")
+	flag.StringVar(&tables.LeaderboardViews, "views-table", os.Getenv("LEADERBOARD_VIEWS_TABLE_NAME"), "DynamoDB leaderboard views table")
+	flag.StringVar(&tables.PlayerStats, "player-stats-table", os.Getenv("PLAYER_STATS_TABLE_NAME"), "DynamoDB player stats table")
+	flag.StringVar(&tables.PlayerNames, "player-names-table", os.Getenv("PLAYER_NAMES_TABLE_NAME"), "DynamoDB player names table")
+	flag.Parse()
+
+	if *mode == "verify" {
+		if err := verifyReport(*report); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	if *player == "" {
+		log.Fatalf("-player is required")
+	}
+
+	ctx := context.Background()
+
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(*region))
+	if err != nil {
+		log.Fatalf("failed to load AWS config: %v", err)
+	}
+	svc := privacy.New(dynamodb.NewFromConfig(cfg), tables)
+
+	var (
+		result   interface{}
+		verified = true
+	)
+	switch *mode {
+	case "export":
+		result, err = svc.Export(ctx, *player)
+	default:
+		eraseMode, parseErr := privacy.ParseMode(*mode)
+		if parseErr != nil {
+			log.Fatalf("invalid mode %q (expected export, delete, anonymize or verify)", *mode)
+		}
+		if !*confirm && !askConfirmation(fmt.Sprintf("%s all data for player %q?", eraseMode, *player)) {
+			log.Fatalf("aborted")
+		}
+
+		var r *privacy.Report
+		r, err = svc.Erase(ctx, *player, eraseMode)
+		if err == nil {
+			verified = r.Verified
+		}
+		result = r
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := writeJSON(*out, result); err != nil {
+		log.Fatal(err)
+	}
+	if !verified {
+		log.Fatalf("verification failed: data remains for player %q", *player)
+	}
+}
+
+// verifyReport はレポートのダイジェストを再計算し、内容が変わっていないことと検証結果を確認する
+func verifyReport(path string) error {
+	if path == "" {
+		return fmt.Errorf("-report is required for verify")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read report: %w", err)
+	}
+
+	var r privacy.Report
+	if err := json.Unmarshal(data, &r); err != nil {
+		return fmt.Errorf("failed to parse report: %w", err)
+	}
+
+	digest, err := r.ComputeDigest()
+	if err != nil {
+		return err
+	}
+	if digest != r.Digest {
+		return fmt.Errorf("digest mismatch: report has %s, computed %s", r.Digest, digest)
+	}
+	if !r.Verified {
+		return fmt.Errorf("digest ok, but the report records remaining data")
+	}
+
+	fmt.Printf("OK: %s of %q completed at %s, digest %s\n", r.Mode, r.PlayerName, r.CompletedAt.Format("2006-01-02T15:04:05Z07:00"), r.Digest)
+	return nil
+}
+
+func askConfirmation(prompt string) bool {
+	fmt.Fprintf(os.Stderr, "%s [y/N]: ", prompt)
+	var answer string
+	fmt.Scanln(&answer)
+	return answer == "y" || answer == "Y"
+}
+
+func writeJSON(path string, v interface{}) error {
+	var w io.Writer = os.Stdout
+	if path != "" {
+		f, err := os.Create(path)
+		if err != nil {
+			return fmt.Errorf("failed to create %s: %w", path, err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.SetEscapeHTML(false)
+	return enc.Encode(v)
+}
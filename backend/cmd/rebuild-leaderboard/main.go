@@ -0,0 +1,422 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"typing-game-backend/leaderboard"
+)
+
+// scores テーブルからリーダーボードのビュー（全体・カテゴリー別・期間別・モード別）を再構築し、
+// 保存済みのデータと比較する。
+//
+//	compare: 差分を表示する（書き込みなし）
+//	apply:   差分を表示し、条件付き書き込みで修復する
+//	report:  差分の集計をJSONで出力し、ずれがあれば終了コード 2 で終了する（定期チェック用）
+//
+// Usage:
+//
+//	go run ./cmd/rebuild-leaderboard -mode compare
+//	go run ./cmd/rebuild-leaderboard -mode apply -view category#beginner_words
+//	go run ./cmd/rebuild-leaderboard -mode report -view weekly#2025-W03
+//	go run ./cmd/rebuild-leaderboard -mode compare -view mode#endless
+
+const driftExitCode = 2
+
+type tables struct {
+	scores      string
+	leaderboard string
+	views       string
+}
+
+// viewReport はビューごとの比較結果
+type viewReport struct {
+	View     string `json:"view"`
+	Expected int    `json:"expected"`
+	Live     int    `json:"live"`
+	Missing  int    `json:"missing"`
+	Stale    int    `json:"stale"`
+	Extra    int    `json:"extra"`
+}
+
+type report struct {
+	GeneratedAt   time.Time    `json:"generated_at"`
+	ScoresScanned int64        `json:"scores_scanned"`
+	Drift         bool         `json:"drift"`
+	Views         []viewReport `json:"views"`
+}
+
+func main() {
+	var (
+		region   = flag.String("region", "ap-northeast-1", "AWS region")
+		mode     = flag.String("mode", "compare", "compare, apply or report")
+		view     = flag.String("view", "all", "all, global, category#<category>, weekly#YYYY-Www, monthly#YYYY-MM, mode#<mode>, mode#time_attack#<seconds> or list#<list>[#mode#<mode>]")
+		segments = flag.Int("segments", 4, "number of parallel scan segments")
+		days     = flag.Int("retention-days", envInt("SCORE_RETENTION_DAYS"), "score retention in days (0 = scores never expire)")
+		t        tables
+	)
+	flag.StringVar(&t.scores, "scores-table", os.Getenv("SCORES_TABLE_NAME"), "DynamoDB scores table")
+	flag.StringVar(&t.leaderboard, "leaderboard-table", os.Getenv("LEADERBOARD_TABLE_NAME"), "DynamoDB leaderboard table")
+	flag.StringVar(&t.views, "views-table", os.Getenv("LEADERBOARD_VIEWS_TABLE_NAME"), "DynamoDB leaderboard views table")
+	flag.Parse()
+
+	if *mode != "compare" && *mode != "apply" && *mode != "report" {
+		log.Fatalf("invalid mode %q (expected compare, apply or report)", *mode)
+	}
+	if *view != "all" {
+		if err := leaderboard.ValidateView(*view); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if *segments < 1 {
+		log.Fatalf("segments must be at least 1")
+	}
+	if t.scores == "" {
+		log.Fatalf("scores table is required (-scores-table or SCORES_TABLE_NAME)")
+	}
+
+	include := func(v string) bool {
+		if *view != "all" && v != *view {
+			return false
+		}
+		if v == leaderboard.Global {
+			return t.leaderboard != ""
+		}
+		return t.views != ""
+	}
+
+	ctx := context.Background()
+
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(*region))
+	if err != nil {
+		log.Fatalf("failed to load AWS config: %v", err)
+	}
+	client := dynamodb.NewFromConfig(cfg)
+
+	// scores テーブルから再構築
+	builder := leaderboard.NewBuilder(include)
+	var scanned atomic.Int64
+	err = parallelScan(ctx, client, t.scores, *segments, func(items []map[string]types.AttributeValue) error {
+		var scores []leaderboard.Entry
+		if err := attributevalue.UnmarshalListOfMaps(items, &scores); err != nil {
+			return fmt.Errorf("unmarshal scores failed: %w", err)
+		}
+		for _, score := range scores {
+			builder.Add(score)
+		}
+		scanned.Add(int64(len(scores)))
+		return nil
+	})
+	if err != nil {
+		log.Fatalf("scan scores failed: %v", err)
+	}
+	expected := builder.Views()
+
+	// 保存済みのビューを読み込む
+	live, err := loadLive(ctx, client, t, *view, *segments, include)
+	if err != nil {
+		log.Fatalf("load live leaderboard failed: %v", err)
+	}
+
+	views := make([]string, 0, len(expected)+len(live))
+	for v := range expected {
+		views = append(views, v)
+	}
+	for v := range live {
+		if _, ok := expected[v]; !ok {
+			views = append(views, v)
+		}
+	}
+	sort.Strings(views)
+
+	// 保持期間を過ぎたスコアは削除されているため、それ以前の記録はずれとして扱わない
+	var expiredBefore int64
+	if *days > 0 {
+		expiredBefore = time.Now().AddDate(0, 0, -*days).Unix()
+	}
+
+	rep := report{GeneratedAt: time.Now().UTC(), ScoresScanned: scanned.Load(), Views: []viewReport{}}
+	var changes []leaderboard.Change
+	for _, v := range views {
+		diff := leaderboard.Diff(v, expected[v], live[v], expiredBefore)
+		vr := viewReport{View: v, Expected: len(expected[v]), Live: len(live[v])}
+		for _, change := range diff {
+			switch change.Kind {
+			case leaderboard.Missing:
+				vr.Missing++
+			case leaderboard.Stale:
+				vr.Stale++
+			case leaderboard.Extra:
+				vr.Extra++
+			}
+		}
+		if len(diff) > 0 {
+			rep.Drift = true
+		}
+		rep.Views = append(rep.Views, vr)
+		changes = append(changes, diff...)
+	}
+
+	if *mode == "report" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(rep); err != nil {
+			log.Fatalf("encode report failed: %v", err)
+		}
+		if rep.Drift {
+			os.Exit(driftExitCode)
+		}
+		return
+	}
+
+	fmt.Printf("Scanned %d scores, %d views (mode: %s)\n", rep.ScoresScanned, len(views), *mode)
+	for _, change := range changes {
+		fmt.Println(describe(change))
+	}
+
+	if *mode == "compare" {
+		fmt.Printf("Done. changes=%d\n", len(changes))
+		return
+	}
+
+	var applied, skipped, failed int
+	for _, change := range changes {
+		err := apply(ctx, client, t, change)
+		var conflict *types.ConditionalCheckFailedException
+		switch {
+		case err == nil:
+			applied++
+		case errors.As(err, &conflict):
+			// 比較後にスコアが登録された場合は上書きしない
+			skipped++
+			fmt.Printf("  skipped %s %s: changed since comparison\n", change.View, change.PlayerName)
+		default:
+			failed++
+			fmt.Printf("  failed %s %s: %v\n", change.View, change.PlayerName, err)
+		}
+	}
+
+	fmt.Printf("Done. changes=%d applied=%d skipped=%d failed=%d\n", len(changes), applied, skipped, failed)
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// parallelScan はテーブルを segments 個のセグメントに分けて並行にスキャンし、ページごとに fn を呼び出す
+func parallelScan(ctx context.Context, client *dynamodb.Client, table string, segments int, fn func(items []map[string]types.AttributeValue) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	for segment := 0; segment < segments; segment++ {
+		wg.Add(1)
+		go func(segment int) {
+			defer wg.Done()
+
+			paginator := dynamodb.NewScanPaginator(client, &dynamodb.ScanInput{
+				TableName:     aws.String(table),
+				Segment:       aws.Int32(int32(segment)),
+				TotalSegments: aws.Int32(int32(segments)),
+			})
+			for paginator.HasMorePages() {
+				page, err := paginator.NextPage(ctx)
+				if err == nil {
+					err = fn(page.Items)
+				}
+				if err != nil {
+					once.Do(func() {
+						firstErr = fmt.Errorf("segment %d: %w", segment, err)
+						cancel()
+					})
+					return
+				}
+			}
+		}(segment)
+	}
+	wg.Wait()
+
+	return firstErr
+}
+
+// loadLive は保存済みのビューを読み込む。特定のビューはQueryで、すべてのビューはスキャンで取得する
+func loadLive(ctx context.Context, client *dynamodb.Client, t tables, view string, segments int, include func(string) bool) (map[string]map[string]leaderboard.Entry, error) {
+	live := make(map[string]map[string]leaderboard.Entry)
+	var mu sync.Mutex
+	add := func(v string, entry leaderboard.Entry) {
+		mu.Lock()
+		defer mu.Unlock()
+		if live[v] == nil {
+			live[v] = make(map[string]leaderboard.Entry)
+		}
+		live[v][entry.PlayerName] = entry
+	}
+
+	if include(leaderboard.Global) {
+		err := parallelScan(ctx, client, t.leaderboard, segments, func(items []map[string]types.AttributeValue) error {
+			var entries []leaderboard.Entry
+			if err := attributevalue.UnmarshalListOfMaps(items, &entries); err != nil {
+				return fmt.Errorf("unmarshal leaderboard failed: %w", err)
+			}
+			for _, entry := range entries {
+				add(leaderboard.Global, entry)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("scan leaderboard failed: %w", err)
+		}
+	}
+
+	if t.views == "" || view == leaderboard.Global {
+		return live, nil
+	}
+
+	addViewItems := func(items []map[string]types.AttributeValue) error {
+		for _, item := range items {
+			var entry leaderboard.Entry
+			if err := attributevalue.UnmarshalMap(item, &entry); err != nil {
+				return fmt.Errorf("unmarshal leaderboard view failed: %w", err)
+			}
+			v, ok := item["view"].(*types.AttributeValueMemberS)
+			if !ok {
+				continue
+			}
+			if include(v.Value) {
+				add(v.Value, entry)
+			}
+		}
+		return nil
+	}
+
+	if view == "all" {
+		if err := parallelScan(ctx, client, t.views, segments, addViewItems); err != nil {
+			return nil, fmt.Errorf("scan leaderboard views failed: %w", err)
+		}
+		return live, nil
+	}
+
+	paginator := dynamodb.NewQueryPaginator(client, &dynamodb.QueryInput{
+		TableName:              aws.String(t.views),
+		KeyConditionExpression: aws.String("#view = :view"),
+		ExpressionAttributeNames: map[string]string{
+			"#view": "view",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":view": &types.AttributeValueMemberS{Value: view},
+		},
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("query leaderboard view %s failed: %w", view, err)
+		}
+		if err := addViewItems(page.Items); err != nil {
+			return nil, err
+		}
+	}
+	return live, nil
+}
+
+// apply は差分を1件修復する。比較時の状態から変わっていない場合だけ書き込む
+func apply(ctx context.Context, client *dynamodb.Client, t tables, change leaderboard.Change) error {
+	table := t.views
+	key := map[string]types.AttributeValue{
+		"view":        &types.AttributeValueMemberS{Value: change.View},
+		"player_name": &types.AttributeValueMemberS{Value: change.PlayerName},
+	}
+	if change.View == leaderboard.Global {
+		table = t.leaderboard
+		key = map[string]types.AttributeValue{
+			"player_name": &types.AttributeValueMemberS{Value: change.PlayerName},
+		}
+	}
+
+	condition := "attribute_not_exists(player_name)"
+	names := map[string]string(nil)
+	values := map[string]types.AttributeValue(nil)
+	if change.Live != nil {
+		condition = "score = :live"
+		values = map[string]types.AttributeValue{
+			":live": &types.AttributeValueMemberN{Value: strconv.Itoa(change.Live.Score)},
+		}
+		switch leaderboard.OrderOf(change.View) {
+		case leaderboard.ByRound:
+			condition += " AND #round = :live_round"
+			names = map[string]string{"#round": "round"}
+			values[":live_round"] = &types.AttributeValueMemberN{Value: strconv.Itoa(change.Live.Round)}
+		case leaderboard.ByTime:
+			condition += " AND elapsed_ms = :live_elapsed_ms"
+			values[":live_elapsed_ms"] = &types.AttributeValueMemberN{Value: strconv.Itoa(change.Live.ElapsedMs)}
+		}
+	}
+
+	if change.Kind == leaderboard.Extra {
+		_, err := client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+			TableName:                 aws.String(table),
+			Key:                       key,
+			ConditionExpression:       aws.String(condition),
+			ExpressionAttributeNames:  names,
+			ExpressionAttributeValues: values,
+		})
+		return err
+	}
+
+	item, err := attributevalue.MarshalMap(change.Expected)
+	if err != nil {
+		return fmt.Errorf("marshal entry failed: %w", err)
+	}
+	for k, v := range key {
+		item[k] = v
+	}
+	if change.View == leaderboard.Global {
+		item["rank"] = &types.AttributeValueMemberN{Value: "0"} // Will be calculated when fetching
+	}
+
+	_, err = client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName:                 aws.String(table),
+		Item:                      item,
+		ConditionExpression:       aws.String(condition),
+		ExpressionAttributeNames:  names,
+		ExpressionAttributeValues: values,
+	})
+	return err
+}
+
+func describe(change leaderboard.Change) string {
+	switch change.Kind {
+	case leaderboard.Missing:
+		return fmt.Sprintf("  [%s] %s: missing (expected %d)", change.View, change.PlayerName, change.Expected.Score)
+	case leaderboard.Stale:
+		return fmt.Sprintf("  [%s] %s: stale (expected %d, live %d)", change.View, change.PlayerName, change.Expected.Score, change.Live.Score)
+	default:
+		return fmt.Sprintf("  [%s] %s: extra (live %d, no matching score)", change.View, change.PlayerName, change.Live.Score)
+	}
+}
+
+func envInt(key string) int {
+	n, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return 0
+	}
+	return n
+}
@@ -0,0 +1,306 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+
+	"typing-game-backend/archive"
+	"typing-game-backend/balance"
+	"typing-game-backend/game"
+)
+
+// 合成プレイヤー（WPM・正確さ・ばらつき）にゲームのルールをプレイさせるモンテカルロシミュレーション。
+// 単語は words テーブルか、export-tables で書き出した words テーブルのエクスポートから読み込む。
+// ルールセットごとにラウンド別の勝率・スコアの分布と、各パラメータを ±sensitivity 変えたときの変化を出力する。
+//
+// Usage:
+//
+//	go run ./cmd/simulate-balance -words-table typing-game-words-production -category beginner_words
+//	go run ./cmd/simulate-balance -words-export export/typing-game-words-production -rulesets v1,v2 -rulesets-dir rulesets -format csv -out balance.csv
+//	go run ./cmd/simulate-balance -words-export export/typing-game-words-production -wpm 60 -accuracy 0.97 -games 5000
+
+// wordRecord は words テーブルの項目（テーブル・エクスポートの共通部分）
+type wordRecord struct {
+	Category string `dynamodbav:"category" json:"category"`
+	Word     string `dynamodbav:"word" json:"word"`
+	Round    int    `dynamodbav:"round" json:"round"`
+	Type     string `dynamodbav:"type" json:"type"`
+	Language string `dynamodbav:"language" json:"language"`
+}
+
+// rulesetReport はルールセットごとの結果
+type rulesetReport struct {
+	Ruleset     string                `json:"ruleset"`
+	Summary     balance.Summary       `json:"summary"`
+	Sensitivity []balance.Sensitivity `json:"sensitivity,omitempty"`
+}
+
+type report struct {
+	GeneratedAt time.Time       `json:"generated_at"`
+	Category    string          `json:"category"`
+	Language    string          `json:"language"`
+	Words       int             `json:"words"`
+	Games       int             `json:"games"`
+	Seed        int64           `json:"seed"`
+	Player      balance.Player  `json:"player"`
+	Change      float64         `json:"sensitivity_change"`
+	Results     []rulesetReport `json:"results"`
+}
+
+func main() {
+	var (
+		region      = flag.String("region", "ap-northeast-1", "AWS region")
+		wordsTable  = flag.String("words-table", os.Getenv("WORDS_TABLE_NAME"), "DynamoDB words table to read words from")
+		wordsExport = flag.String("words-export", "", "directory of a words table export (part-*.jsonl.gz from export-tables); used instead of -words-table")
+		category    = flag.String("category", "beginner_words", "word category")
+		language    = flag.String("language", "jp", "word language")
+		rulesetList = flag.String("rulesets", "v1", "comma-separated ruleset versions to simulate")
+		rulesetsDir = flag.String("rulesets-dir", os.Getenv("RULESETS_DIR"), "directory of additional rulesets (*.json)")
+		games       = flag.Int("games", 2000, "games per simulation")
+		seed        = flag.Int64("seed", 1, "random seed (the same seed gives the same results)")
+		change      = flag.Float64("sensitivity", 0.1, "relative change of each parameter for the sensitivity analysis (0 disables it)")
+		format      = flag.String("format", "json", "output format: json or csv")
+		out         = flag.String("out", "", "output file (default: stdout)")
+
+		wpm        = flag.Float64("wpm", 40, "mean words per minute (5 keystrokes per word)")
+		wpmStdDev  = flag.Float64("wpm-stddev", 8, "standard deviation of WPM between games")
+		wordJitter = flag.Float64("word-jitter", 0.25, "coefficient of variation of the typing time per word")
+		accuracy   = flag.Float64("accuracy", 0.95, "probability of typing each key correctly")
+		correction = flag.Float64("correction", 0.8, "probability of noticing and fixing a typo before submitting")
+		reaction   = flag.Float64("reaction", 0.5, "seconds before starting to type a new word")
+	)
+	flag.Parse()
+
+	if *format != "json" && *format != "csv" {
+		log.Fatalf("format must be json or csv, got %q", *format)
+	}
+	if *change < 0 || *change >= 1 {
+		log.Fatalf("sensitivity must be in [0, 1)")
+	}
+	versions := nonEmpty(strings.Split(*rulesetList, ",")...)
+	if len(versions) == 0 {
+		log.Fatalf("no rulesets to simulate (-rulesets)")
+	}
+	rulesets, err := game.Load(game.Options{Dir: *rulesetsDir, Current: versions[0]})
+	if err != nil {
+		log.Fatalf("failed to load rulesets: %v", err)
+	}
+
+	ctx := context.Background()
+	var records []wordRecord
+	switch {
+	case *wordsExport != "":
+		records, err = readExport(*wordsExport)
+	case *wordsTable != "":
+		records, err = scanTable(ctx, *region, *wordsTable)
+	default:
+		log.Fatalf("no word source (-words-export, -words-table or WORDS_TABLE_NAME)")
+	}
+	if err != nil {
+		log.Fatalf("failed to read words: %v", err)
+	}
+
+	words := balance.Words{}
+	count := 0
+	for _, r := range records {
+		if r.Category == *category && r.Language == *language {
+			words.Add(r.Round, game.WordType(r.Type), r.Word)
+			count++
+		}
+	}
+	log.Printf("loaded %d words for %s (%s)", count, *category, *language)
+
+	rep := report{
+		GeneratedAt: time.Now().UTC(),
+		Category:    *category,
+		Language:    *language,
+		Words:       count,
+		Games:       *games,
+		Seed:        *seed,
+		Player: balance.Player{
+			WPM:             *wpm,
+			WPMStdDev:       *wpmStdDev,
+			WordJitter:      *wordJitter,
+			Accuracy:        *accuracy,
+			Correction:      *correction,
+			ReactionSeconds: *reaction,
+		},
+		Change: *change,
+	}
+	for _, version := range versions {
+		rules, ok := rulesets.Get(version)
+		if !ok {
+			log.Fatalf("ruleset %q not found", version)
+		}
+		sim := balance.Simulation{Rules: rules, Player: rep.Player, Words: words, Games: *games, Seed: *seed}
+
+		start := time.Now()
+		result := rulesetReport{Ruleset: version}
+		if result.Summary, err = sim.Run(); err != nil {
+			log.Fatalf("ruleset %s: %v", version, err)
+		}
+		if *change > 0 {
+			if result.Sensitivity, err = sim.Sensitivities(result.Summary, *change); err != nil {
+				log.Fatalf("ruleset %s: %v", version, err)
+			}
+		}
+		log.Printf("ruleset %s: clear rate %.3f, mean score %.0f (%s)", version, result.Summary.ClearRate, result.Summary.Score.Mean, time.Since(start).Round(time.Millisecond))
+		rep.Results = append(rep.Results, result)
+	}
+
+	w := io.Writer(os.Stdout)
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			log.Fatalf("failed to create %s: %v", *out, err)
+		}
+		defer f.Close()
+		w = f
+	}
+	if *format == "csv" {
+		err = writeCSV(w, rep)
+	} else {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(rep)
+	}
+	if err != nil {
+		log.Fatalf("failed to write report: %v", err)
+	}
+}
+
+// writeCSV は結果を1行1指標（ruleset, parameter, change, metric, round, value）で書き出す。
+// parameter が空の行は変更前の結果
+func writeCSV(w io.Writer, rep report) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"ruleset", "parameter", "change", "metric", "round", "value"})
+	row := func(ruleset, parameter string, change float64, metric string, round int, value float64) {
+		r := ""
+		if round > 0 {
+			r = strconv.Itoa(round)
+		}
+		cw.Write([]string{ruleset, parameter, strconv.FormatFloat(change, 'f', -1, 64), metric, r, strconv.FormatFloat(math.Round(value*1e4)/1e4, 'f', -1, 64)})
+	}
+
+	for _, res := range rep.Results {
+		s := res.Summary
+		row(res.Ruleset, "", 0, "clear_rate", 0, s.ClearRate)
+		for _, rs := range s.Rounds {
+			row(res.Ruleset, "", 0, "win_rate", rs.Round, rs.WinRate)
+			row(res.Ruleset, "", 0, "reached", rs.Round, float64(rs.Reached))
+			row(res.Ruleset, "", 0, "timeouts", rs.Round, float64(rs.Timeouts))
+			row(res.Ruleset, "", 0, "defeats", rs.Round, float64(rs.Defeats))
+		}
+		for _, q := range []struct {
+			name  string
+			value float64
+		}{
+			{"score_mean", s.Score.Mean}, {"score_stddev", s.Score.StdDev}, {"score_min", s.Score.Min},
+			{"score_p10", s.Score.P10}, {"score_p25", s.Score.P25}, {"score_p50", s.Score.P50},
+			{"score_p75", s.Score.P75}, {"score_p90", s.Score.P90}, {"score_max", s.Score.Max},
+			{"max_combo_mean", s.MaxCombo.Mean}, {"words_per_second", s.WordsPerSec},
+			{"score_base_mean", s.Breakdown["base"]}, {"score_combo_mean", s.Breakdown["combo"]},
+			{"score_special_mean", s.Breakdown["special"]}, {"score_time_mean", s.Breakdown["time"]},
+		} {
+			row(res.Ruleset, "", 0, q.name, 0, q.value)
+		}
+
+		for _, sens := range res.Sensitivity {
+			row(res.Ruleset, sens.Parameter, sens.Change, "clear_rate", 0, sens.ClearRate)
+			row(res.Ruleset, sens.Parameter, sens.Change, "clear_rate_delta", 0, sens.ClearRateDelta)
+			row(res.Ruleset, sens.Parameter, sens.Change, "score_mean", 0, sens.MeanScore)
+			row(res.Ruleset, sens.Parameter, sens.Change, "score_mean_delta", 0, sens.MeanScoreDelta)
+			for i, rate := range sens.WinRates {
+				row(res.Ruleset, sens.Parameter, sens.Change, "win_rate", i+1, rate)
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// readExport は export-tables で書き出した words テーブルの全ファイルを読み込む
+func readExport(dir string) ([]wordRecord, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "part-*.jsonl.gz"))
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no part-*.jsonl.gz files in %s", dir)
+	}
+
+	var records []wordRecord
+	for _, path := range paths {
+		err := archive.ReadJSONL(path, func(line []byte) error {
+			var r wordRecord
+			if err := json.Unmarshal(line, &r); err != nil {
+				return err
+			}
+			records = append(records, r)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return records, nil
+}
+
+// scanTable は words テーブルの全項目を読み込む
+func scanTable(ctx context.Context, region, table string) ([]wordRecord, error) {
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(region))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+	client := dynamodb.NewFromConfig(cfg)
+
+	var records []wordRecord
+	paginator := dynamodb.NewScanPaginator(client, &dynamodb.ScanInput{
+		TableName: aws.String(table),
+		// round・type・language は予約語のため名前で参照する
+		ProjectionExpression: aws.String("category, word, #round, #type, #language"),
+		ExpressionAttributeNames: map[string]string{
+			"#round":    "round",
+			"#type":     "type",
+			"#language": "language",
+		},
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("scan failed: %w", err)
+		}
+		var items []wordRecord
+		if err := attributevalue.UnmarshalListOfMaps(page.Items, &items); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal words: %w", err)
+		}
+		records = append(records, items...)
+	}
+	return records, nil
+}
+
+func nonEmpty(values ...string) []string {
+	var result []string
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			result = append(result, v)
+		}
+	}
+	return result
+}
@@ -0,0 +1,556 @@
+// Package config はバックエンドの設定を読み込み・検証する。
+//
+// 設定値は次の優先順位で決まる（後のものが優先）:
+// デフォルト値 → 設定ファイル（JSON） → 環境変数 → コマンドラインフラグ
+package config
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config はバックエンド全体の設定
+type Config struct {
+	Environment string `json:"environment"`
+	Port        int    `json:"port"`
+
+	// Lambda は AWS Lambda 上で実行されているかどうか（AWS_LAMBDA_RUNTIME_API から判定）
+	Lambda bool `json:"-"`
+
+	Logging   LoggingConfig   `json:"logging"`
+	Tables    TablesConfig    `json:"tables"`
+	Storage   StorageConfig   `json:"storage"`
+	Retention RetentionConfig `json:"retention"`
+	Names     NamesConfig     `json:"names"`
+	API       APIConfig       `json:"api"`
+	CORS      CORSConfig      `json:"cors"`
+	Security  SecurityConfig  `json:"security"`
+	Cache     CacheConfig     `json:"cache"`
+	Health    HealthConfig    `json:"health"`
+	Game      GameLimits      `json:"game"`
+	Rulesets  RulesetsConfig  `json:"rulesets"`
+
+	// AdminAPIKey は管理用エンドポイント（キャッシュ破棄など）のキー。空の場合は無効
+	AdminAPIKey string `json:"-"`
+}
+
+// LoggingConfig は構造化ログの設定
+type LoggingConfig struct {
+	Level string `json:"level"` // debug, info, warn, error
+	// RedactPlayerData はプレイヤー名などの個人に関わる属性をログで秘匿する
+	RedactPlayerData bool `json:"redact_player_data"`
+	// RedactionKey は秘匿する属性の鍵付きハッシュ（HMAC）の鍵。空の場合は属性をハッシュにせず取り除く
+	RedactionKey string `json:"-"`
+}
+
+// TablesConfig はDynamoDBのテーブル名
+type TablesConfig struct {
+	Scores           string `json:"scores"`
+	Leaderboard      string `json:"leaderboard"`
+	LeaderboardViews string `json:"leaderboard_views"` // カテゴリー別・期間別のリーダーボード
+	PlayerStats      string `json:"player_stats"`      // プレイヤーごとの累計（保持期間を過ぎたスコアも含む）
+	PlayerNames      string `json:"player_names"`      // 名前の Skeleton の登録（紛らわしい名前の検出用）
+	Words            string `json:"words"`             // 空の場合はローカルのフォールバック単語を使用
+	Translations     string `json:"translations"`
+	Passages         string `json:"passages"`   // 文章モードの文章。空の場合は組み込みのサンプルを使用
+	WordLists        string `json:"word_lists"` // カスタム単語リスト
+}
+
+// StorageConfig はDynamoDB呼び出しのタイムアウト・リトライ・サーキットブレーカーの設定
+type StorageConfig struct {
+	ReadTimeout  Duration `json:"read_timeout"`
+	WriteTimeout Duration `json:"write_timeout"`
+	// MaxAttempts はスロットリング・サーバーエラー時の最大試行回数（初回を含む）
+	MaxAttempts    int      `json:"max_attempts"`
+	RetryBaseDelay Duration `json:"retry_base_delay"`
+	RetryMaxDelay  Duration `json:"retry_max_delay"`
+	// BreakerThreshold 回連続で失敗すると BreakerCooldown の間そのテーブルへの呼び出しを遮断する
+	BreakerThreshold int      `json:"breaker_threshold"`
+	BreakerCooldown  Duration `json:"breaker_cooldown"`
+}
+
+// RetentionConfig はスコア履歴の保持ポリシー
+type RetentionConfig struct {
+	// ScoreDays は scores テーブルの1ゲームごとの記録を保持する日数（TTL属性 expires_at で削除）。0 の場合は無期限
+	ScoreDays int `json:"score_days"`
+}
+
+// NamesConfig はプレイヤー名のポリシーの設定。禁止語・予約名は組み込みのリスト（names パッケージ）に追加される
+type NamesConfig struct {
+	BlocklistFile string   `json:"blocklist_file"` // 1行に1語の禁止語リスト
+	ReservedFile  string   `json:"reserved_file"`  // 1行に1語の予約名リスト
+	Blocked       []string `json:"blocked"`
+	Reserved      []string `json:"reserved"`
+}
+
+// APIConfig は /api/v1 を OpenAPI の定義（openapi/openapi.yaml）で検証するかどうか
+type APIConfig struct {
+	// ValidateRequests は定義と一致しないリクエストを validation_failed / invalid_request で拒否する
+	ValidateRequests bool `json:"validate_requests"`
+	// ValidateResponses は定義と一致しないレスポンスをログに出力する（ボディを記録するため開発・テスト向け）
+	ValidateResponses bool `json:"validate_responses"`
+}
+
+// CORSConfig はCORSの設定
+type CORSConfig struct {
+	// AllowedOrigins は許可するオリジン。"*" はすべてのオリジンを許可する（認証情報なしの場合のみ）
+	AllowedOrigins   []string `json:"allowed_origins"`
+	AllowCredentials bool     `json:"allow_credentials"`
+	// MaxAge はプリフライト結果をブラウザがキャッシュする時間
+	MaxAge Duration `json:"max_age"`
+}
+
+// SecurityConfig はセキュリティヘッダーとリクエスト制限の設定
+type SecurityConfig struct {
+	MaxRequestBytes int64 `json:"max_request_bytes"`
+	// MaxUploadBytes は単語帳のファイルのアップロード（/game/lists/import）のボディの上限。
+	// Lambda の同期呼び出しのペイロードの上限（6MB）に base64 で収まる大きさにする
+	MaxUploadBytes int64 `json:"max_upload_bytes"`
+	// HSTS は Strict-Transport-Security を付与するかどうか（HTTPSで配信される環境のみ）
+	HSTS bool `json:"hsts"`
+}
+
+// CacheConfig はコンテンツキャッシュの設定
+type CacheConfig struct {
+	TTL              Duration `json:"ttl"`
+	WordsSize        int      `json:"words_size"`
+	TranslationsSize int      `json:"translations_size"`
+}
+
+// HealthConfig はレディネスチェックの設定
+type HealthConfig struct {
+	// CheckTimeout は依存先1件あたりのチェックに使える時間
+	CheckTimeout Duration `json:"check_timeout"`
+}
+
+// GameLimits はゲームの制限値。/api/config でクライアントにも公開する
+type GameLimits struct {
+	MaxPlayerNameLength  int      `json:"max_player_name_length"`
+	MaxScore             int      `json:"max_score"`
+	MaxGameTimeSeconds   int      `json:"max_game_time_seconds"`
+	LeaderboardSize      int      `json:"leaderboard_size"`
+	WordLanguages        []string `json:"word_languages"`
+	TranslationLanguages []string `json:"translation_languages"`
+}
+
+// RulesetsConfig はゲームのルールセットの設定。ラウンド数・敵・ダメージなどはルールセットで決まる。
+// ルールセットは組み込みのもの（game/rulesets）に Dir のJSONファイルを追加する
+type RulesetsConfig struct {
+	Current string `json:"current"` // 新しいゲームに使うバージョン
+	Dir     string `json:"dir"`
+}
+
+// Duration は "5m" のような文字列でJSONに書けるtime.Duration
+type Duration struct {
+	time.Duration
+}
+
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("duration must be a string like \"5m\": %w", err)
+	}
+	parsed, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	d.Duration = parsed
+	return nil
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// Default はデフォルト設定を返す
+func Default() *Config {
+	return &Config{
+		Environment: "local",
+		Port:        8080,
+		Logging: LoggingConfig{
+			Level:            "info",
+			RedactPlayerData: true,
+		},
+		Storage: StorageConfig{
+			ReadTimeout:      Duration{2 * time.Second},
+			WriteTimeout:     Duration{3 * time.Second},
+			MaxAttempts:      3,
+			RetryBaseDelay:   Duration{50 * time.Millisecond},
+			RetryMaxDelay:    Duration{1 * time.Second},
+			BreakerThreshold: 5,
+			BreakerCooldown:  Duration{30 * time.Second},
+		},
+		API: APIConfig{
+			ValidateRequests: true,
+		},
+		CORS: CORSConfig{
+			// frontend/public/CNAME のGitHub Pagesドメインとローカル開発サーバー
+			AllowedOrigins: []string{"https://typing-game.kumalabo.com", "http://localhost:3000"},
+			MaxAge:         Duration{10 * time.Minute},
+		},
+		Security: SecurityConfig{
+			MaxRequestBytes: 16 << 10,
+			MaxUploadBytes:  4 << 20,
+		},
+		Cache: CacheConfig{
+			TTL:              Duration{5 * time.Minute},
+			WordsSize:        256,
+			TranslationsSize: 4096,
+		},
+		Health: HealthConfig{
+			CheckTimeout: Duration{2 * time.Second},
+		},
+		Game: GameLimits{
+			MaxPlayerNameLength:  20,
+			MaxScore:             1000000,
+			MaxGameTimeSeconds:   3600,
+			LeaderboardSize:      30,
+			WordLanguages:        []string{"jp", "en"},
+			TranslationLanguages: []string{"jp", "en", "es", "fr", "de", "zh", "ko"},
+		},
+		Rulesets: RulesetsConfig{
+			Current: "v1",
+		},
+	}
+}
+
+// Load はデフォルト値・設定ファイル・環境変数・フラグ（args）から設定を読み込み、検証する
+func Load(args []string) (*Config, error) {
+	cfg := Default()
+
+	fs := flag.NewFlagSet("typing-game-backend", flag.ContinueOnError)
+	var (
+		configFile        = fs.String("config", os.Getenv("CONFIG_FILE"), "path to a JSON config file")
+		port              = fs.Int("port", 0, "HTTP port for local mode")
+		scoresTable       = fs.String("scores-table", "", "DynamoDB scores table name")
+		leaderboardTable  = fs.String("leaderboard-table", "", "DynamoDB leaderboard table name")
+		viewsTable        = fs.String("leaderboard-views-table", "", "DynamoDB leaderboard views table name")
+		wordsTable        = fs.String("words-table", "", "DynamoDB words table name")
+		translationsTable = fs.String("translations-table", "", "DynamoDB translations table name")
+		passagesTable     = fs.String("passages-table", "", "DynamoDB passages table name")
+		wordListsTable    = fs.String("word-lists-table", "", "DynamoDB custom word lists table name")
+		corsOrigins       = fs.String("cors-origins", "", "comma-separated list of allowed CORS origins")
+	)
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if *configFile != "" {
+		if err := cfg.loadFile(*configFile); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := cfg.loadEnv(); err != nil {
+		return nil, err
+	}
+
+	// フラグは明示的に指定されたものだけを反映する
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "port":
+			cfg.Port = *port
+		case "scores-table":
+			cfg.Tables.Scores = *scoresTable
+		case "leaderboard-table":
+			cfg.Tables.Leaderboard = *leaderboardTable
+		case "leaderboard-views-table":
+			cfg.Tables.LeaderboardViews = *viewsTable
+		case "words-table":
+			cfg.Tables.Words = *wordsTable
+		case "translations-table":
+			cfg.Tables.Translations = *translationsTable
+		case "passages-table":
+			cfg.Tables.Passages = *passagesTable
+		case "word-lists-table":
+			cfg.Tables.WordLists = *wordListsTable
+		case "cors-origins":
+			cfg.CORS.AllowedOrigins = splitList(*corsOrigins)
+		}
+	})
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
+func (c *Config) loadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file %s: %w", path, err)
+	}
+	defer f.Close()
+
+	dec := json.NewDecoder(f)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(c); err != nil {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	return nil
+}
+
+func (c *Config) loadEnv() error {
+	c.Lambda = os.Getenv("AWS_LAMBDA_RUNTIME_API") != ""
+	if c.Lambda {
+		// Lambda は API Gateway 経由のHTTPSでのみ配信される
+		c.Security.HSTS = true
+	}
+
+	setString(&c.Environment, "ENVIRONMENT")
+	setString(&c.Tables.Scores, "SCORES_TABLE_NAME")
+	setString(&c.Tables.Leaderboard, "LEADERBOARD_TABLE_NAME")
+	setString(&c.Tables.LeaderboardViews, "LEADERBOARD_VIEWS_TABLE_NAME")
+	setString(&c.Tables.PlayerStats, "PLAYER_STATS_TABLE_NAME")
+	setString(&c.Tables.PlayerNames, "PLAYER_NAMES_TABLE_NAME")
+	setString(&c.Tables.Words, "WORDS_TABLE_NAME")
+	setString(&c.Tables.Translations, "TRANSLATIONS_TABLE_NAME")
+	setString(&c.Tables.Passages, "PASSAGES_TABLE_NAME")
+	setString(&c.Tables.WordLists, "WORD_LISTS_TABLE_NAME")
+	setString(&c.AdminAPIKey, "ADMIN_API_KEY")
+	setString(&c.Logging.Level, "LOG_LEVEL")
+	setString(&c.Logging.RedactionKey, "LOG_REDACTION_KEY")
+	setString(&c.Names.BlocklistFile, "NAME_BLOCKLIST_FILE")
+	setString(&c.Names.ReservedFile, "NAME_RESERVED_FILE")
+	setString(&c.Rulesets.Current, "RULESET_VERSION")
+	setString(&c.Rulesets.Dir, "RULESETS_DIR")
+
+	if v := os.Getenv("LOG_REDACT_PLAYER_DATA"); v != "" {
+		redact, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("LOG_REDACT_PLAYER_DATA must be true or false, got %q", v)
+		}
+		c.Logging.RedactPlayerData = redact
+	}
+
+	if v := os.Getenv("SCORE_RETENTION_DAYS"); v != "" {
+		days, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("SCORE_RETENTION_DAYS must be a number, got %q", v)
+		}
+		c.Retention.ScoreDays = days
+	}
+
+	if v := os.Getenv("API_VALIDATE_RESPONSES"); v != "" {
+		validate, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("API_VALIDATE_RESPONSES must be true or false, got %q", v)
+		}
+		c.API.ValidateResponses = validate
+	}
+
+	if v := os.Getenv("CORS_ALLOWED_ORIGINS"); v != "" {
+		c.CORS.AllowedOrigins = splitList(v)
+	}
+
+	if v := os.Getenv("CORS_ALLOW_CREDENTIALS"); v != "" {
+		allow, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("CORS_ALLOW_CREDENTIALS must be true or false, got %q", v)
+		}
+		c.CORS.AllowCredentials = allow
+	}
+
+	if v := os.Getenv("MAX_REQUEST_BYTES"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return fmt.Errorf("MAX_REQUEST_BYTES must be a number, got %q", v)
+		}
+		c.Security.MaxRequestBytes = n
+	}
+
+	if v := os.Getenv("MAX_UPLOAD_BYTES"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return fmt.Errorf("MAX_UPLOAD_BYTES must be a number, got %q", v)
+		}
+		c.Security.MaxUploadBytes = n
+	}
+
+	if v := os.Getenv("PORT"); v != "" {
+		port, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("PORT must be a number, got %q", v)
+		}
+		c.Port = port
+	}
+
+	if v := os.Getenv("CONTENT_CACHE_TTL"); v != "" {
+		ttl, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("CONTENT_CACHE_TTL must be a duration like \"5m\", got %q", v)
+		}
+		c.Cache.TTL = Duration{ttl}
+	}
+
+	return nil
+}
+
+// Validate は設定値を検証し、問題をまとめて1つのエラーとして返す
+func (c *Config) Validate() error {
+	var problems []string
+
+	switch strings.ToLower(c.Logging.Level) {
+	case "debug", "info", "warn", "error":
+	default:
+		problems = append(problems, fmt.Sprintf("logging.level must be one of debug, info, warn, error, got %q", c.Logging.Level))
+	}
+
+	if c.Port < 1 || c.Port > 65535 {
+		problems = append(problems, fmt.Sprintf("port must be between 1 and 65535, got %d", c.Port))
+	}
+
+	// Lambdaではスコア系テーブルが必須。ローカルでは Warnings で通知する
+	if c.Lambda {
+		if c.Tables.Scores == "" {
+			problems = append(problems, "scores table name is required (SCORES_TABLE_NAME)")
+		}
+		if c.Tables.Leaderboard == "" {
+			problems = append(problems, "leaderboard table name is required (LEADERBOARD_TABLE_NAME)")
+		}
+	}
+
+	st := c.Storage
+	if st.ReadTimeout.Duration <= 0 || st.WriteTimeout.Duration <= 0 {
+		problems = append(problems, "storage.read_timeout and storage.write_timeout must be positive")
+	}
+	if st.MaxAttempts < 1 {
+		problems = append(problems, "storage.max_attempts must be at least 1")
+	}
+	if st.RetryBaseDelay.Duration <= 0 || st.RetryMaxDelay.Duration < st.RetryBaseDelay.Duration {
+		problems = append(problems, "storage.retry_base_delay must be positive and not exceed storage.retry_max_delay")
+	}
+	if st.BreakerThreshold < 1 {
+		problems = append(problems, "storage.breaker_threshold must be at least 1")
+	}
+	if st.BreakerCooldown.Duration <= 0 {
+		problems = append(problems, "storage.breaker_cooldown must be positive")
+	}
+
+	if c.Retention.ScoreDays < 0 {
+		problems = append(problems, "retention.score_days must not be negative (SCORE_RETENTION_DAYS)")
+	}
+
+	if len(c.CORS.AllowedOrigins) == 0 {
+		problems = append(problems, "at least one CORS allowed origin is required (CORS_ALLOWED_ORIGINS)")
+	}
+	for _, origin := range c.CORS.AllowedOrigins {
+		if origin == "*" && c.CORS.AllowCredentials {
+			problems = append(problems, "CORS allowed origin \"*\" cannot be combined with allow_credentials")
+		} else if origin != "*" && !strings.HasPrefix(origin, "http://") && !strings.HasPrefix(origin, "https://") {
+			problems = append(problems, fmt.Sprintf("CORS allowed origin %q must start with http:// or https://", origin))
+		}
+	}
+	if c.CORS.MaxAge.Duration < 0 {
+		problems = append(problems, "cors.max_age must not be negative")
+	}
+
+	if c.Security.MaxRequestBytes < 1 {
+		problems = append(problems, "security.max_request_bytes must be at least 1")
+	}
+	if c.Security.MaxUploadBytes < 1 {
+		problems = append(problems, "security.max_upload_bytes must be at least 1")
+	}
+
+	if c.Cache.TTL.Duration < 0 {
+		problems = append(problems, "cache ttl must not be negative")
+	}
+	if c.Cache.WordsSize < 1 || c.Cache.TranslationsSize < 1 {
+		problems = append(problems, "cache sizes must be at least 1")
+	}
+
+	if c.Health.CheckTimeout.Duration <= 0 {
+		problems = append(problems, "health.check_timeout must be positive")
+	}
+
+	g := c.Game
+	if g.MaxPlayerNameLength < 1 {
+		problems = append(problems, "game.max_player_name_length must be at least 1")
+	}
+	if g.MaxScore < 1 {
+		problems = append(problems, "game.max_score must be at least 1")
+	}
+	if g.MaxGameTimeSeconds < 1 {
+		problems = append(problems, "game.max_game_time_seconds must be at least 1")
+	}
+	if g.LeaderboardSize < 1 {
+		problems = append(problems, "game.leaderboard_size must be at least 1")
+	}
+	if len(g.WordLanguages) == 0 {
+		problems = append(problems, "game.word_languages must not be empty")
+	}
+	if len(g.TranslationLanguages) == 0 {
+		problems = append(problems, "game.translation_languages must not be empty")
+	}
+
+	if c.Rulesets.Current == "" {
+		problems = append(problems, "rulesets.current must not be empty (RULESET_VERSION)")
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid configuration:\n  - %s", strings.Join(problems, "\n  - "))
+	}
+	return nil
+}
+
+// Warnings は起動を妨げないが注意が必要な設定を返す
+func (c *Config) Warnings() []string {
+	var warnings []string
+	if c.Tables.Scores == "" {
+		warnings = append(warnings, "SCORES_TABLE_NAME is not set; score submission will fail")
+	}
+	if c.Tables.Leaderboard == "" {
+		warnings = append(warnings, "LEADERBOARD_TABLE_NAME is not set; leaderboard is unavailable")
+	}
+	if c.Tables.LeaderboardViews == "" {
+		warnings = append(warnings, "LEADERBOARD_VIEWS_TABLE_NAME is not set; category and period leaderboards are unavailable")
+	}
+	if c.Retention.ScoreDays > 0 && c.Tables.PlayerStats == "" {
+		warnings = append(warnings, "PLAYER_STATS_TABLE_NAME is not set; per-player totals are lost when scores expire")
+	}
+	if c.Tables.PlayerNames == "" {
+		warnings = append(warnings, "PLAYER_NAMES_TABLE_NAME is not set; lookalike player names are not detected")
+	}
+	if c.Tables.Words == "" {
+		warnings = append(warnings, "WORDS_TABLE_NAME is not set; using local fallback words")
+	}
+	if c.Tables.Translations == "" {
+		warnings = append(warnings, "TRANSLATIONS_TABLE_NAME is not set; only custom word lists have translations")
+	}
+	if c.Tables.Passages == "" {
+		warnings = append(warnings, "PASSAGES_TABLE_NAME is not set; using built-in sample passages")
+	}
+	if c.Tables.WordLists == "" {
+		warnings = append(warnings, "WORD_LISTS_TABLE_NAME is not set; custom word lists are unavailable")
+	}
+	if c.AdminAPIKey == "" {
+		warnings = append(warnings, "ADMIN_API_KEY is not set; admin endpoints are disabled")
+	}
+	if c.Logging.RedactPlayerData && c.Logging.RedactionKey == "" {
+		warnings = append(warnings, "LOG_REDACTION_KEY is not set; player names and client IPs are dropped from logs instead of hashed")
+	}
+	return warnings
+}
+
+func setString(dst *string, key string) {
+	if v := os.Getenv(key); v != "" {
+		*dst = v
+	}
+}
+
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,392 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"typing-game-backend/furigana"
+	"typing-game-backend/wordimport"
+	"typing-game-backend/wordlist"
+)
+
+// update はゴールデンファイル（testdata/golden）を現在のレスポンスで書き換える: go test -run TestContract -update
+var update = flag.Bool("update", false, "update golden files in testdata/golden")
+
+// contractCase は契約テストの1リクエスト。ケースは順に実行し、前のケースで登録したスコアを後のケースで参照する
+type contractCase struct {
+	name    string // ゴールデンファイル名
+	method  string
+	path    string // /api/v1（または /api）からの相対パス
+	body    string
+	headers []string
+	status  int
+	// v1Only は /api/v1 にしかないルート（OpenAPI の定義）
+	v1Only bool
+	// noGolden はボディが大きい・内容を別に検証するためゴールデンファイルと比較しない
+	noGolden bool
+	// ifNoneMatch は直前のレスポンスのETagを If-None-Match に指定する
+	ifNoneMatch bool
+}
+
+const adminKey = "test-admin-key"
+
+var contractCases = []contractCase{
+	{name: "health", method: http.MethodGet, path: "/health", status: http.StatusOK},
+	{name: "health_live", method: http.MethodGet, path: "/health/live", status: http.StatusOK},
+	{name: "health_ready", method: http.MethodGet, path: "/health/ready", status: http.StatusOK},
+	{name: "config", method: http.MethodGet, path: "/config", status: http.StatusOK},
+	{name: "openapi_json", method: http.MethodGet, path: "/openapi.json", status: http.StatusOK, v1Only: true, noGolden: true},
+	{name: "openapi_yaml", method: http.MethodGet, path: "/openapi.yaml", status: http.StatusOK, v1Only: true, noGolden: true},
+
+	{name: "categories", method: http.MethodGet, path: "/game/categories", status: http.StatusOK},
+	{name: "categories_en", method: http.MethodGet, path: "/game/categories?language=en", status: http.StatusOK},
+	{name: "words", method: http.MethodGet, path: "/game/words/beginner_words/1", status: http.StatusOK},
+	{name: "words_not_modified", method: http.MethodGet, path: "/game/words/beginner_words/1", status: http.StatusNotModified, ifNoneMatch: true},
+	{name: "words_empty", method: http.MethodGet, path: "/game/words/intermediate_words/2?language=en", status: http.StatusOK},
+	{name: "words_invalid_round", method: http.MethodGet, path: "/game/words/beginner_words/6", status: http.StatusBadRequest},
+	{name: "words_endless", method: http.MethodGet, path: "/game/words/beginner_words/11?mode=endless", status: http.StatusOK},
+	{name: "words_invalid_mode", method: http.MethodGet, path: "/game/words/beginner_words/1?mode=arcade", status: http.StatusBadRequest},
+	{name: "typing_words", method: http.MethodGet, path: "/game/words/beginner_words?mode=time_attack", status: http.StatusOK},
+	{name: "typing_words_missing_mode", method: http.MethodGet, path: "/game/words/beginner_words", status: http.StatusBadRequest},
+	{name: "translation", method: http.MethodGet, path: "/game/translation/bw_001?language=en", status: http.StatusOK},
+	{name: "translation_not_found", method: http.MethodGet, path: "/game/translation/bw_999?language=en", status: http.StatusNotFound},
+	{name: "rulesets", method: http.MethodGet, path: "/game/rulesets", status: http.StatusOK},
+	{name: "passages", method: http.MethodGet, path: "/game/passages", status: http.StatusOK},
+	{name: "passage", method: http.MethodGet, path: "/game/passages/" + samplePassages[0].PassageID, status: http.StatusOK},
+	{name: "passage_not_found", method: http.MethodGet, path: "/game/passages/p_unknown", status: http.StatusNotFound},
+	{name: "word_list_create", method: http.MethodPost, path: "/game/lists", status: http.StatusCreated,
+		body: `{"name":"どうぶつ","language":"jp","words_per_round":2,"words":[{"display":"犬"},{"display":"猫","reading":"ねこ"},{"display":"ウサギ","type":"bonus"},{"display":"一日","translation":"a day"}],"translation_language":"en","accept_review":true}`},
+	{name: "word_list_create_review", method: http.MethodPost, path: "/game/lists", status: http.StatusBadRequest,
+		body: `{"name":"ひにち","language":"jp","words_per_round":2,"words":[{"display":"一日"}]}`},
+	{name: "word_list_create_invalid", method: http.MethodPost, path: "/game/lists", status: http.StatusBadRequest,
+		body: `{"name":"words","language":"fr","words_per_round":51,"words":[{"display":"chat"}]}`},
+	{name: "word_list_import", method: http.MethodPost, path: "/game/lists/import", status: http.StatusCreated,
+		headers: []string{"Content-Type", uploadContentType},
+		body: uploadBody("animals.tsv", []byte("#separator:tab\n#html:true\n#columns:Front\tBack\n一日\ta day\n<b>犬</b>\tdog\n[sound:cat.mp3]\tcat\n猫\tcat&nbsp;(animal)\n犬\tpuppy\n"),
+			"name", "どうぶつ", "words_per_round", "2", "accept_review", "true")},
+	{name: "word_list_import_invalid", method: http.MethodPost, path: "/game/lists/import", status: http.StatusBadRequest,
+		headers: []string{"Content-Type", uploadContentType},
+		body:    uploadBody("kanji.tsv", []byte("鬱\tdepression\n一日\ta day\n"), "name", "かんじ", "words_per_round", "60")},
+	{name: "word_list", method: http.MethodGet, path: "/game/lists/" + strings.ToLower(sampleWordList.ShareCode), status: http.StatusOK},
+	{name: "word_list_not_found", method: http.MethodGet, path: "/game/lists/ZZZZZZZZ", status: http.StatusNotFound},
+	{name: "words_list", method: http.MethodGet, path: "/game/words/" + sampleWordList.ListID + "/3", status: http.StatusOK},
+	{name: "words_list_invalid_language", method: http.MethodGet, path: "/game/words/" + sampleWordList.ListID + "/1?language=en", status: http.StatusBadRequest},
+	{name: "typing_words_list", method: http.MethodGet, path: "/game/words/" + sampleWordList.ListID + "?mode=time_attack", status: http.StatusOK},
+	{name: "translation_list", method: http.MethodGet, path: "/game/translation/" + sampleWordList.Words[0].WordID + "?language=en", status: http.StatusOK},
+
+	{name: "score", method: http.MethodPost, path: "/game/score", status: http.StatusOK,
+		body: `{"player_name":"たろう","score":1200,"round":3,"time":95,"category":"beginner_words"}`},
+	{name: "score_not_best", method: http.MethodPost, path: "/game/score", status: http.StatusOK,
+		body: `{"player_name":"たろう","score":900,"round":2,"time":80,"category":"beginner_words"}`},
+	{name: "score_other_player", method: http.MethodPost, path: "/game/score", status: http.StatusOK,
+		body: `{"player_name":"hanako","score":800,"round":2,"time":70,"category":"intermediate_words","ruleset_version":"v1"}`},
+	{name: "score_endless", method: http.MethodPost, path: "/game/score", status: http.StatusOK,
+		body: `{"player_name":"たろう","score":5400,"round":8,"time":310,"category":"beginner_words","mode":"endless"}`},
+	{name: "score_endless_not_best", method: http.MethodPost, path: "/game/score", status: http.StatusOK,
+		body: `{"player_name":"たろう","score":9000,"round":7,"time":280,"category":"beginner_words","mode":"endless"}`},
+	{name: "score_endless_too_many_waves", method: http.MethodPost, path: "/game/score", status: http.StatusBadRequest,
+		body: `{"player_name":"hanako","score":5000,"round":50,"time":30,"category":"beginner_words","mode":"endless"}`},
+	{name: "score_time_attack", method: http.MethodPost, path: "/game/score", status: http.StatusOK,
+		body: `{"player_name":"たろう","category":"beginner_words","mode":"time_attack","duration":60,"typing":{"words":70,"characters":300,"keystrokes":320}}`},
+	{name: "score_time_attack_too_fast", method: http.MethodPost, path: "/game/score", status: http.StatusBadRequest,
+		body: `{"player_name":"hanako","category":"beginner_words","mode":"time_attack","duration":60,"typing":{"words":400,"characters":2000,"keystrokes":2000}}`},
+	{name: "score_practice", method: http.MethodPost, path: "/game/score", status: http.StatusOK,
+		body: `{"player_name":"じろう","category":"beginner_words","mode":"practice","typing":{"words":50,"characters":260,"keystrokes":270,"elapsed_ms":52000}}`},
+	{name: "score_practice_missing_typing", method: http.MethodPost, path: "/game/score", status: http.StatusBadRequest,
+		body: `{"player_name":"hanako","category":"beginner_words","mode":"practice"}`},
+	{name: "score_passage", method: http.MethodPost, path: "/game/score", status: http.StatusOK,
+		body: `{"player_name":"さぶろう","mode":"passage","progress":{"passage_id":"` + samplePassages[0].PassageID + `","sentences":[{"characters":22,"keystrokes":24,"elapsed_ms":6000},{"characters":33,"keystrokes":33,"elapsed_ms":9000}]}}`},
+	{name: "score_passage_too_many_sentences", method: http.MethodPost, path: "/game/score", status: http.StatusBadRequest,
+		body: `{"player_name":"さぶろう","mode":"passage","progress":{"passage_id":"` + samplePassages[0].PassageID + `","sentences":[{"characters":1,"keystrokes":1,"elapsed_ms":1000},{"characters":1,"keystrokes":1,"elapsed_ms":1000},{"characters":1,"keystrokes":1,"elapsed_ms":1000},{"characters":1,"keystrokes":1,"elapsed_ms":1000},{"characters":1,"keystrokes":1,"elapsed_ms":1000}]}}`},
+	{name: "score_list", method: http.MethodPost, path: "/game/score", status: http.StatusOK,
+		body: `{"player_name":"しろう","score":700,"round":2,"time":60,"category":"` + sampleWordList.ListID + `"}`},
+	{name: "score_list_unknown", method: http.MethodPost, path: "/game/score", status: http.StatusBadRequest,
+		body: `{"player_name":"しろう","score":700,"round":2,"time":60,"category":"list_ZZZZZZZZ"}`},
+	{name: "score_unknown_ruleset", method: http.MethodPost, path: "/game/score", status: http.StatusBadRequest,
+		body: `{"player_name":"hanako","score":800,"round":2,"time":70,"category":"intermediate_words","ruleset_version":"v0"}`},
+	{name: "score_name_too_long", method: http.MethodPost, path: "/game/score", status: http.StatusBadRequest,
+		body: `{"player_name":"あいうえおかきくけこさしすせそたちつてとな","score":100,"round":1,"time":10,"category":"beginner_words"}`},
+	{name: "leaderboard", method: http.MethodGet, path: "/game/leaderboard", status: http.StatusOK},
+	{name: "leaderboard_category", method: http.MethodGet, path: "/game/leaderboard?category=beginner_words", status: http.StatusOK},
+	{name: "leaderboard_weekly", method: http.MethodGet, path: "/game/leaderboard?period=weekly", status: http.StatusOK},
+	{name: "leaderboard_endless", method: http.MethodGet, path: "/game/leaderboard?mode=endless", status: http.StatusOK},
+	{name: "leaderboard_time_attack", method: http.MethodGet, path: "/game/leaderboard?mode=time_attack&duration=60", status: http.StatusOK},
+	{name: "leaderboard_time_attack_missing_duration", method: http.MethodGet, path: "/game/leaderboard?mode=time_attack", status: http.StatusBadRequest},
+	{name: "leaderboard_practice", method: http.MethodGet, path: "/game/leaderboard?mode=practice", status: http.StatusOK},
+	{name: "leaderboard_passage", method: http.MethodGet, path: "/game/leaderboard?mode=passage", status: http.StatusOK},
+	{name: "leaderboard_list", method: http.MethodGet, path: "/game/leaderboard?category=" + sampleWordList.ListID, status: http.StatusOK},
+	{name: "leaderboard_list_endless", method: http.MethodGet, path: "/game/leaderboard?category=" + sampleWordList.ListID + "&mode=endless", status: http.StatusOK},
+	{name: "leaderboard_conflict", method: http.MethodGet, path: "/game/leaderboard?category=beginner_words&period=weekly", status: http.StatusBadRequest},
+
+	{name: "cache_invalidate_forbidden", method: http.MethodPost, path: "/admin/cache/invalidate", status: http.StatusForbidden},
+	{name: "cache_invalidate", method: http.MethodPost, path: "/admin/cache/invalidate", status: http.StatusOK,
+		headers: []string{"X-Admin-Key", adminKey}},
+	{name: "players_export", method: http.MethodPost, path: "/admin/players/export", status: http.StatusOK,
+		body: `{"player_name":"hanako"}`, headers: []string{"X-Admin-Key", adminKey}},
+	{name: "players_erase", method: http.MethodPost, path: "/admin/players/erase", status: http.StatusOK,
+		body: `{"player_name":"hanako","mode":"delete"}`, headers: []string{"X-Admin-Key", adminKey}},
+	{name: "leaderboard_after_erase", method: http.MethodGet, path: "/game/leaderboard", status: http.StatusOK},
+
+	{name: "not_found", method: http.MethodGet, path: "/game/unknown", status: http.StatusNotFound},
+}
+
+// sampleWordList は契約テストで使うカスタム単語リスト（2ラウンド、共有コードは固定）
+var sampleWordList = func() *wordlist.List {
+	spec := wordlist.Spec{Name: "しぜん", Language: "jp", WordsPerRound: 2, TranslationLanguage: "en"}
+	for i, w := range []struct{ display, wordType, translation string }{
+		{"山《やま》", "normal", "mountain"},
+		{"川《かわ》", "normal", "river"},
+		{"花《はな》", "bonus", ""},
+	} {
+		word, err := wordimport.NewWord(i/spec.WordsPerRound+1, w.wordType, w.display, "", wordimport.Options{Language: spec.Language})
+		if err != nil {
+			panic(err)
+		}
+		spec.Words = append(spec.Words, word)
+		spec.Translations = append(spec.Translations, w.translation)
+	}
+	return wordlist.New("K7Q2M9XA", spec, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+}()
+
+// contractResult は正規化したレスポンス（ゴールデンファイルの内容）
+type contractResult struct {
+	Status  int               `json:"status"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Body    interface{}       `json:"body"`
+}
+
+// goldenHeaders はゴールデンファイルに含めるヘッダー
+var goldenHeaders = []string{"Content-Type", "Cache-Control", "ETag", "Deprecation", "Link"}
+
+// TestContract は setupRoutes のすべてのルートにリクエストを送り、/api/v1 のレスポンスをゴールデンファイルと比較する。
+// 互換用の /api は、成功したレスポンスが /api/v1 と同じであることと Deprecation ヘッダーを確認する
+func TestContract(t *testing.T) {
+	v1 := runContract(t, apiV1Prefix)
+	legacy := runContract(t, "/api")
+
+	for _, tc := range contractCases {
+		got, ok := v1[tc.name]
+		if !ok || tc.noGolden {
+			continue
+		}
+		assertGolden(t, tc.name, got)
+
+		old, ok := legacy[tc.name]
+		if !ok || tc.status >= 300 {
+			continue
+		}
+		if old.Headers["Deprecation"] != "true" {
+			t.Errorf("%s: legacy /api response has no Deprecation header", tc.name)
+		}
+		if !jsonEqual(old.Body, got.Body) {
+			t.Errorf("%s: legacy /api body differs from /api/v1\nlegacy: %s\nv1:     %s", tc.name, mustJSON(old.Body), mustJSON(got.Body))
+		}
+	}
+}
+
+// runContract は新しい環境で contractCases を prefix に対して順に実行し、すべてのルートにリクエストしたことを確認する
+func runContract(t *testing.T, prefix string) map[string]contractResult {
+	e := newTestEnv(t)
+	e.put(e.cfg.Tables.Words,
+		WordItem{Category: "beginner_words", WordID: "bw_001", Word: "みず", Display: "水", Furigana: []furigana.Segment{{Text: "水", Reading: "みず"}}, Round: 1, Type: "normal", Language: "jp", LookupKey: wordLookupKey("beginner_words", "jp", 1)},
+		WordItem{Category: "beginner_words", WordID: "bw_002", Word: "ねこ", Round: 1, Type: "bonus", Language: "jp", LookupKey: wordLookupKey("beginner_words", "jp", 1)},
+		WordItem{Category: "beginner_words", WordID: "bw_003", Word: "いぬ", Round: 1, Type: "debuff", Language: "jp", LookupKey: wordLookupKey("beginner_words", "jp", 1)},
+	)
+	e.put(e.cfg.Tables.Translations,
+		TranslationItem{WordID: "bw_001", Language: "en", Translation: "water", Category: "beginner_words", CreatedAt: "2024-01-01T00:00:00Z", UpdatedAt: "2024-01-01T00:00:00Z"},
+	)
+	e.put(e.cfg.Tables.Passages, samplePassages[0])
+	e.put(e.cfg.Tables.WordLists, sampleWordList)
+
+	routes := e.routes()
+	covered := make(map[string]bool)
+	results := make(map[string]contractResult)
+	var etag string
+	for _, tc := range contractCases {
+		if tc.v1Only && prefix != apiV1Prefix {
+			continue
+		}
+
+		headers := tc.headers
+		if tc.ifNoneMatch {
+			headers = append(append([]string{}, headers...), "If-None-Match", etag)
+		}
+		rec := e.do(tc.method, prefix+tc.path, tc.body, headers...)
+		if rec.Code != tc.status {
+			t.Errorf("%s %s%s: status = %d, want %d: %s", tc.method, prefix, tc.path, rec.Code, tc.status, rec.Body.String())
+		}
+		etag = rec.Header().Get("ETag")
+
+		path, _, _ := strings.Cut(prefix+tc.path, "?")
+		for _, route := range routes {
+			if route.method == tc.method && route.pattern.MatchString(path) {
+				covered[route.method+" "+route.path] = true
+			}
+		}
+		results[tc.name] = e.normalize(rec)
+	}
+
+	for _, route := range routes {
+		if !strings.HasPrefix(route.path, prefix+"/") || (prefix == "/api" && strings.HasPrefix(route.path, apiV1Prefix+"/")) {
+			continue
+		}
+		if !covered[route.method+" "+route.path] {
+			t.Errorf("route %s %s is not covered by contractCases", route.method, route.path)
+		}
+	}
+	return results
+}
+
+// registeredRoute は setupRoutes が登録したルートと、そのパスに一致する正規表現
+type registeredRoute struct {
+	method  string
+	path    string
+	pattern *regexp.Regexp
+}
+
+// routes は setupRoutes が登録するルートを返す（router が追加する /metrics などは含まない）
+func (e *testEnv) routes() []registeredRoute {
+	r := gin.New()
+	e.server.setupRoutes(r)
+
+	var routes []registeredRoute
+	for _, info := range r.Routes() {
+		pattern := ginParam.ReplaceAllString(regexp.QuoteMeta(info.Path), `[^/]+`)
+		routes = append(routes, registeredRoute{
+			method:  info.Method,
+			path:    info.Path,
+			pattern: regexp.MustCompile("^" + pattern + "$"),
+		})
+	}
+	return routes
+}
+
+// volatileFields は実行ごとに変わる値（時刻・乱数・所要時間）を持つキー
+var volatileFields = map[string]bool{
+	"request_id":   true,
+	"exported_at":  true,
+	"started_at":   true,
+	"completed_at": true,
+	"digest":       true,
+	"pseudonym":    true,
+	"timestamp":    true,
+	"first_played": true,
+	"last_played":  true,
+	"claimed_at":   true,
+	"last_used":    true,
+	"expires_at":   true,
+	"latency_ms":   true,
+	"created_at":   true,
+	"share_code":   true,
+}
+
+// wordListID はカスタム単語リストの ID（作成するたびに共有コードが変わる）
+var wordListID = regexp.MustCompile(`list_[2-9A-HJKMNP-Z]{8}`)
+
+// periodView は期間別ビューの名前（weekly#2025-W01 など）
+var periodView = regexp.MustCompile(`^(weekly|monthly)#.+$`)
+
+// normalize はレスポンスから実行ごとに変わる値を取り除く。テーブル名の接頭辞は testTablePrefix にそろえる
+func (e *testEnv) normalize(rec *httptest.ResponseRecorder) contractResult {
+	result := contractResult{Status: rec.Code, Headers: make(map[string]string)}
+	for _, name := range goldenHeaders {
+		if v := rec.Header().Get(name); v != "" {
+			result.Headers[name] = v
+		}
+	}
+
+	if rec.Body.Len() == 0 {
+		return result
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &result.Body); err != nil {
+		// JSON以外（OpenAPI の YAML）はそのまま比較する
+		result.Body = rec.Body.String()
+		return result
+	}
+	result.Body = e.normalizeValue("", result.Body)
+	return result
+}
+
+func (e *testEnv) normalizeValue(key string, v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		normalized := make(map[string]interface{}, len(v))
+		for k, child := range v {
+			// 単語リストの翻訳は単語の ID がキーになる
+			normalized[wordListID.ReplaceAllString(k, "list_<share_code>")] = e.normalizeValue(k, child)
+		}
+		return normalized
+	case []interface{}:
+		for i, child := range v {
+			v[i] = e.normalizeValue(key, child)
+		}
+		return v
+	case string:
+		if volatileFields[key] && v != "" {
+			return "<" + key + ">"
+		}
+		if periodView.MatchString(v) {
+			return periodView.ReplaceAllString(v, "$1#<period>")
+		}
+		v = wordListID.ReplaceAllString(v, "list_<share_code>")
+		return strings.ReplaceAll(v, e.prefix, testTablePrefix)
+	case float64:
+		if volatileFields[key] && v != 0 {
+			return "<" + key + ">"
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// assertGolden はレスポンスを testdata/golden/<name>.json と比較する（-update の場合は書き込む）
+func assertGolden(t *testing.T, name string, got contractResult) {
+	t.Helper()
+	path := filepath.Join("testdata", "golden", name+".json")
+	data := append(mustJSON(got), '\n')
+
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("%s: %v (run go test -run TestContract -update to create it)", name, err)
+	}
+	if !bytes.Equal(want, data) {
+		t.Errorf("%s: response differs from %s (run go test -run TestContract -update if the change is intended)\ngot:\n%s\nwant:\n%s", name, path, data, want)
+	}
+}
+
+func mustJSON(v interface{}) []byte {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		panic(err)
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
+}
+
+func jsonEqual(a, b interface{}) bool {
+	return bytes.Equal(mustJSON(a), mustJSON(b))
+}
@@ -0,0 +1,161 @@
+package deckimport
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MaxCollectionBytes は .apkg の中のコレクション（SQLite）の展開後の大きさの上限。
+// アップロードされた小さな zip が大きなファイルに展開される場合に備える
+const MaxCollectionBytes = 64 << 20
+
+// fieldSeparator は notes テーブルの flds 列のフィールドの区切り
+const fieldSeparator = "\x1f"
+
+// ReadAPKG は Anki のデッキ（.apkg）・コレクション（.colpkg）の zip から maxNotes 個までのノートを読む。
+// コレクションは collection.anki21（なければ collection.anki2）で、フィールド名はノートタイプ（col テーブルの models）から求める。
+// Anki 2.1.50 以降の形式（zstd で圧縮した collection.anki21b）だけのファイルは読めない
+func ReadAPKG(r io.ReaderAt, size int64, maxNotes int) ([]Note, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open Anki package: %w", err)
+	}
+	files := make(map[string]*zip.File)
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	var collection *zip.File
+	switch {
+	case files["collection.anki21"] != nil:
+		collection = files["collection.anki21"]
+	case files["collection.anki21b"] != nil:
+		// この形式では collection.anki2 は新しい Anki への更新を促すノートだけになる
+		return nil, errors.New(`collection.anki21b (Anki 2.1.50+) is not supported; export the deck with "Support older Anki versions" checked`)
+	case files["collection.anki2"] != nil:
+		collection = files["collection.anki2"]
+	default:
+		return nil, errors.New("no Anki collection (collection.anki2 or collection.anki21) in the package")
+	}
+
+	data, err := readZipFile(collection)
+	if err != nil {
+		return nil, err
+	}
+	db, err := openSQLite(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", collection.Name, err)
+	}
+	notes, err := readNotes(db, maxNotes)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", collection.Name, err)
+	}
+	return notes, nil
+}
+
+func readZipFile(f *zip.File) ([]byte, error) {
+	if f.UncompressedSize64 > MaxCollectionBytes {
+		return nil, fmt.Errorf("%s is larger than %d bytes", f.Name, MaxCollectionBytes)
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", f.Name, err)
+	}
+	defer rc.Close()
+
+	// zip のヘッダーの大きさは書き換えられるため、読んだ大きさでも確かめる
+	data, err := io.ReadAll(io.LimitReader(rc, MaxCollectionBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", f.Name, err)
+	}
+	if len(data) > MaxCollectionBytes {
+		return nil, fmt.Errorf("%s is larger than %d bytes", f.Name, MaxCollectionBytes)
+	}
+	return data, nil
+}
+
+// readNotes は notes テーブルのノートを作成順（id の順）に返す
+func readNotes(db *sqliteDB, maxNotes int) ([]Note, error) {
+	names, err := readFieldNames(db)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := db.table("notes", maxNotes)
+	if errors.Is(err, errTooManyRows) {
+		return nil, fmt.Errorf("%w (more than %d)", ErrTooManyNotes, maxNotes)
+	}
+	if err != nil {
+		return nil, err
+	}
+	mid, flds := t.column("mid"), t.column("flds")
+	if mid < 0 || flds < 0 {
+		return nil, errors.New("notes table has no mid or flds column")
+	}
+
+	notes := make([]Note, 0, len(t.rows))
+	for i, row := range t.rows {
+		fields, ok := row[flds].(string)
+		if !ok {
+			return nil, fmt.Errorf("note %d has no fields", i+1)
+		}
+		model, _ := row[mid].(int64)
+		notes = append(notes, Note{
+			Ref:    fmt.Sprintf("note %d", i+1),
+			Names:  names[model],
+			Fields: strings.Split(fields, fieldSeparator),
+			HTML:   true,
+		})
+	}
+	if len(notes) == 0 {
+		return nil, errors.New("no notes in the collection")
+	}
+	return notes, nil
+}
+
+// readFieldNames はノートタイプの ID ごとのフィールド名（順番の順）を返す。
+// フィールド名が分からない場合（models 列がないなど）は空の map を返し、フィールドは番号で割り当てる
+func readFieldNames(db *sqliteDB) (map[int64][]string, error) {
+	names := make(map[int64][]string)
+	// col テーブルはコレクションの設定の1行だけ
+	t, err := db.table("col", 1)
+	if err != nil {
+		return names, nil
+	}
+	models := t.column("models")
+	if models < 0 || len(t.rows) == 0 {
+		return names, nil
+	}
+	raw, _ := t.rows[0][models].(string)
+	if raw == "" {
+		return names, nil
+	}
+
+	var noteTypes map[string]struct {
+		Fields []struct {
+			Name string `json:"name"`
+			Ord  int    `json:"ord"`
+		} `json:"flds"`
+	}
+	if err := json.Unmarshal([]byte(raw), &noteTypes); err != nil {
+		return nil, fmt.Errorf("failed to parse note types: %w", err)
+	}
+	for id, nt := range noteTypes {
+		model, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			continue
+		}
+		fields := nt.Fields
+		sort.Slice(fields, func(i, j int) bool { return fields[i].Ord < fields[j].Ord })
+		for _, f := range fields {
+			names[model] = append(names[model], f.Name)
+		}
+	}
+	return names, nil
+}
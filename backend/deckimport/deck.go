@@ -0,0 +1,351 @@
+// Package deckimport は Anki のデッキ（.apkg）とタブ区切りのテキストの単語帳を、単語と翻訳に変換する。
+// ノートの表面を単語、裏面を翻訳にし、言語を文字の種類から推定して、打鍵数の少ない（易しい）順にラウンドに分ける。
+// 外部のサービス・ライブラリを使わず（SQLite も自前で読む）、オフラインで動く
+package deckimport
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"typing-game-backend/balance"
+	"typing-game-backend/furigana"
+	"typing-game-backend/game"
+	"typing-game-backend/kanadict"
+	"typing-game-backend/wordimport"
+)
+
+// Note はデッキの1ノート
+type Note struct {
+	// Ref はエラーに表示するノートの位置（note 3・line 5）
+	Ref string
+	// Names はフィールド名（分からない場合は nil）
+	Names  []string
+	Fields []string
+	// HTML はフィールドが HTML か（Anki のノートのフィールドは HTML）
+	HTML bool
+}
+
+// Fields はノートのフィールドの割り当て。フィールド名（大文字・小文字は区別しない）か1から始まる番号で指定する。
+// 省略した場合は Front・Back・Reading という名前のフィールドを使い、ない場合は表面に1番目、裏面に2番目のフィールドを使う
+// （読みは使わない）
+type Fields struct {
+	Front string
+	Back  string
+	// Reading は日本語の読みのフィールド。かなの読みか、Anki のふりがなの形式（日本語[にほんご]）の表示形
+	Reading string
+}
+
+// Options は単語にする条件
+type Options struct {
+	// Category は単語の ID に使うカテゴリー（カスタム単語リストの場合は空で、ID を付けない）
+	Category string
+	// Language・TranslationLanguage は表面・裏面の言語（空の場合はテキストから推定する）
+	Language            string
+	TranslationLanguage string
+	WordsPerRound       int
+	Fields              Fields
+	// Dictionary は読みのない漢字に読みを付ける辞書（wordimport.Options と同じ）
+	Dictionary *kanadict.Dictionary
+	// MaxWordLength は表面・読みのフィールドの文字数の上限（0 は上限なし）。超えるノートは読みを割り当てずに取り込まない
+	MaxWordLength int
+	// MaxTranslationLength は翻訳の文字数の上限（0 は上限なし）
+	MaxTranslationLength int
+}
+
+// Entry は単語にしたノート。Word のラウンドは易しい順に WordsPerRound 語ずつ付ける
+type Entry struct {
+	// Note はデッキのノートの番号（0から）
+	Note        int
+	Ref         string
+	Word        wordimport.Word
+	Translation string
+}
+
+// Issue は取り込まないノートと理由
+type Issue struct {
+	Note   int    `json:"note"`
+	Ref    string `json:"ref"`
+	Reason string `json:"reason"`
+}
+
+// Deck は単語にしたデッキ
+type Deck struct {
+	Language            string
+	TranslationLanguage string
+	Entries             []Entry
+	// Skipped は表面が空（画像だけなど）・表面が前のノートと同じため取り込まないノート
+	Skipped []Issue
+	// Invalid は単語にできないノート（読みのない漢字・長すぎる翻訳など）
+	Invalid []Issue
+}
+
+// ErrTooManyNotes はデッキのノートが読む上限を超えたことを示す
+var ErrTooManyNotes = errors.New("too many notes")
+
+// Read はファイルの内容から形式（zip の場合は .apkg、それ以外は UTF-8 のテキスト）を判断して maxNotes 個までのノートを読む。
+// ノートが maxNotes を超える場合は読むのをやめて ErrTooManyNotes を返す
+func Read(data []byte, maxNotes int) ([]Note, error) {
+	if bytes.HasPrefix(data, []byte("PK\x03\x04")) {
+		return ReadAPKG(bytes.NewReader(data), int64(len(data)), maxNotes)
+	}
+	if !utf8.Valid(data) {
+		return nil, errors.New("file is neither an Anki package (.apkg) nor UTF-8 text")
+	}
+	return ReadTSV(bytes.NewReader(data), maxNotes)
+}
+
+var (
+	soundTag = regexp.MustCompile(`\[sound:[^\]]*\]`)
+	// lineTag は改行になるタグ（空白にする）
+	lineTag = regexp.MustCompile(`(?i)<\s*(br|/?div|/?p|/?li)\b[^>]*>`)
+	htmlTag = regexp.MustCompile(`<[^>]*>`)
+	// ankiFurigana は Anki のふりがなの形式（前の空白から [ までが漢字）
+	ankiFurigana = regexp.MustCompile(` ?([^ \[\]]+?)\[([^\]]+)\]`)
+)
+
+// Build はノートを単語にする。表面が空・重複のノートは Skipped に、単語にできないノートは Invalid に入れ、
+// 残りを wordimport の規則で検証して打鍵数の少ない順に並べる（同じ打鍵数はデッキの順）
+func Build(notes []Note, opts Options) (*Deck, error) {
+	if opts.WordsPerRound < 1 {
+		return nil, errors.New("words per round must be at least 1")
+	}
+	deck := &Deck{Language: opts.Language, TranslationLanguage: opts.TranslationLanguage}
+
+	type card struct {
+		note                 int
+		ref                  string
+		front, back, reading string
+	}
+	var cards []card
+	var fronts, backs []string
+	seen := make(map[string]bool)
+	for i, n := range notes {
+		front, frontErr := n.value(opts.Fields.Front, "Front", 0)
+		back, backErr := n.value(opts.Fields.Back, "Back", 1)
+		reading, readingErr := n.value(opts.Fields.Reading, "Reading", -1)
+		if err := errors.Join(frontErr, backErr, readingErr); err != nil {
+			deck.Invalid = append(deck.Invalid, Issue{Note: i, Ref: n.Ref, Reason: err.Error()})
+			continue
+		}
+
+		c := card{note: i, ref: n.Ref, front: cleanField(front, n.HTML), back: cleanField(back, n.HTML), reading: cleanField(reading, n.HTML)}
+		switch {
+		case c.front == "":
+			deck.Skipped = append(deck.Skipped, Issue{Note: i, Ref: n.Ref, Reason: "front is empty"})
+		case seen[c.front]:
+			deck.Skipped = append(deck.Skipped, Issue{Note: i, Ref: n.Ref, Reason: fmt.Sprintf("duplicate of %q", c.front)})
+		default:
+			seen[c.front] = true
+			cards = append(cards, c)
+			fronts = append(fronts, c.front)
+			if c.back != "" {
+				backs = append(backs, c.back)
+			}
+		}
+	}
+	if len(cards) == 0 {
+		return deck, nil
+	}
+	if deck.Language == "" {
+		if deck.Language = InferLanguage(fronts, opts.Dictionary); deck.Language == "" {
+			return nil, errors.New("could not infer the language of the fronts; specify the language")
+		}
+	}
+	if deck.TranslationLanguage == "" && len(backs) > 0 {
+		deck.TranslationLanguage = InferLanguage(backs, opts.Dictionary)
+	}
+
+	wopts := wordimport.Options{Category: opts.Category, Language: deck.Language, Dictionary: opts.Dictionary}
+	for _, c := range cards {
+		if limit := opts.MaxWordLength; limit > 0 && (utf8.RuneCountInString(c.front) > limit || utf8.RuneCountInString(c.reading) > limit) {
+			deck.Invalid = append(deck.Invalid, Issue{Note: c.note, Ref: c.ref, Reason: fmt.Sprintf("front or reading is longer than %d characters", limit)})
+			continue
+		}
+		display, reading := c.front, ""
+		if deck.Language == "jp" {
+			display, reading = japanese(c.front, c.reading)
+		}
+		w, err := wordimport.NewWord(1, string(game.Normal), display, reading, wopts)
+		if err == nil && opts.MaxTranslationLength > 0 && utf8.RuneCountInString(c.back) > opts.MaxTranslationLength {
+			err = fmt.Errorf("back is longer than %d characters", opts.MaxTranslationLength)
+		}
+		if err != nil {
+			deck.Invalid = append(deck.Invalid, Issue{Note: c.note, Ref: c.ref, Reason: err.Error()})
+			continue
+		}
+		deck.Entries = append(deck.Entries, Entry{Note: c.note, Ref: c.ref, Word: w, Translation: c.back})
+	}
+
+	sort.SliceStable(deck.Entries, func(i, j int) bool {
+		return balance.Keystrokes(deck.Entries[i].Word.Word) < balance.Keystrokes(deck.Entries[j].Word.Word)
+	})
+	for i := range deck.Entries {
+		w := &deck.Entries[i].Word
+		w.Round = i/opts.WordsPerRound + 1
+		w.LookupKey = fmt.Sprintf("%s#%s#%d", w.Category, w.Language, w.Round)
+		if opts.Category != "" {
+			w.WordID = wordimport.WordID(opts.Category, w.Language, w.Round, w.Type, i%opts.WordsPerRound+1)
+		}
+	}
+	return deck, nil
+}
+
+// Words は単語を易しい順に返す
+func (d *Deck) Words() []wordimport.Word {
+	words := make([]wordimport.Word, len(d.Entries))
+	for i, e := range d.Entries {
+		words[i] = e.Word
+	}
+	return words
+}
+
+// Translations は単語と同じ順の翻訳を返す（空文字は翻訳なし）
+func (d *Deck) Translations() []string {
+	translations := make([]string, len(d.Entries))
+	for i, e := range d.Entries {
+		translations[i] = e.Translation
+	}
+	return translations
+}
+
+// Review は辞書で付けた読みのうち、確認が必要な読みのある単語を返す
+func (d *Deck) Review() []Entry {
+	var review []Entry
+	for _, e := range d.Entries {
+		if len(e.Word.Review) > 0 {
+			review = append(review, e)
+		}
+	}
+	return review
+}
+
+// value はフィールドの値を返す。spec が空の場合は名前が name のフィールド、ない場合は fallback 番目（0から、-1 は使わない）の
+// フィールドを使う。フィールドの数より大きい番号は空のフィールドとみなす
+func (n Note) value(spec, name string, fallback int) (string, error) {
+	if spec == "" {
+		if i := indexFold(n.Names, name); i >= 0 {
+			fallback = i
+		}
+		if fallback < 0 || fallback >= len(n.Fields) {
+			return "", nil
+		}
+		return n.Fields[fallback], nil
+	}
+
+	i := indexFold(n.Names, spec)
+	if i < 0 {
+		number, err := strconv.Atoi(spec)
+		if err != nil || number < 1 {
+			return "", fmt.Errorf("no field %q", spec)
+		}
+		i = number - 1
+	}
+	if i >= len(n.Fields) {
+		return "", nil
+	}
+	return n.Fields[i], nil
+}
+
+func indexFold(names []string, name string) int {
+	for i, n := range names {
+		if strings.EqualFold(strings.TrimSpace(n), name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// cleanField はフィールドから音声（[sound:...]）を除き、HTML の場合はタグを除いて文字参照を戻し、空白を1つにまとめる
+func cleanField(s string, isHTML bool) string {
+	s = soundTag.ReplaceAllString(s, "")
+	if isHTML {
+		s = lineTag.ReplaceAllString(s, " ")
+		s = htmlTag.ReplaceAllString(s, "")
+		s = html.UnescapeString(s)
+	}
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// japanese は日本語の表面と読みのフィールドから、wordimport の表示形（ルビ付き）と読みを返す。
+// 読みのフィールドが Anki のふりがなの形式の場合は表示形に使い、かなの場合は読みに使う
+func japanese(front, reading string) (string, string) {
+	switch {
+	case strings.Contains(reading, "["):
+		return ruby(reading), ""
+	case reading != "" && furigana.IsKana(reading):
+		return ruby(front), reading
+	default:
+		return ruby(front), ""
+	}
+}
+
+// ruby は Anki のふりがなの形式（日本語[にほんご]、漢字の前の空白は区切り）を青空文庫形式のルビ（｜日本語《にほんご》）にする
+func ruby(s string) string {
+	if !strings.Contains(s, "[") {
+		return s
+	}
+	return strings.ReplaceAll(ankiFurigana.ReplaceAllString(s, "｜$1《$2》"), " ", "")
+}
+
+// InferLanguage はテキストの文字の種類から言語を推定し、最も多い言語を返す。かなを含むテキストは jp、ハングルは ko、
+// ラテン文字は en（ラテン文字の言語は区別できないため、en 以外は指定する）で、漢字だけのテキストは dict（nil の場合は使わない）で
+// すべて読める場合は jp、読めない場合は zh とする（かなを含むテキストがある場合は jp）。推定できない場合は空文字を返す
+func InferLanguage(texts []string, dict *kanadict.Dictionary) string {
+	counts := make(map[string]int)
+	kana := false
+	for _, t := range texts {
+		s := script(t)
+		kana = kana || s == "jp"
+		if s == "han" && dict != nil && len(dict.Read(t).Unknown()) == 0 {
+			s = "jp"
+		}
+		if s != "" {
+			counts[s]++
+		}
+	}
+	best := ""
+	for _, s := range []string{"jp", "han", "ko", "en"} {
+		if counts[s] > counts[best] {
+			best = s
+		}
+	}
+	if best == "han" {
+		if kana {
+			return "jp"
+		}
+		return "zh"
+	}
+	return best
+}
+
+func script(text string) string {
+	han, latin := false, false
+	for _, r := range text {
+		switch {
+		case unicode.In(r, unicode.Hiragana, unicode.Katakana):
+			return "jp"
+		case unicode.Is(unicode.Hangul, r):
+			return "ko"
+		case furigana.IsKanji(r):
+			han = true
+		case unicode.Is(unicode.Latin, r):
+			latin = true
+		}
+	}
+	switch {
+	case han:
+		return "han"
+	case latin:
+		return "en"
+	default:
+		return ""
+	}
+}
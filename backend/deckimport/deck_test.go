@@ -0,0 +1,334 @@
+package deckimport
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"typing-game-backend/kanadict"
+)
+
+// testdata/japanese.apkg は testdata/gen_apkg.py で作った Anki のデッキ（ページの大きさ 512 バイトの SQLite）。
+// Basic（Front・Back）のノートと、Japanese（Expression・Meaning・Reading）のノートが1つある
+func TestBuildAPKG(t *testing.T) {
+	data, err := os.ReadFile("testdata/japanese.apkg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	notes, err := Read(data, 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(notes) != 33 {
+		t.Fatalf("got %d notes, want 33", len(notes))
+	}
+	// 裏面が長いノートはオーバーフローページに続く
+	if got := notes[7].Fields[1]; len(got) != 3606 || !strings.HasSuffix(got, "and more ") {
+		t.Errorf("note 8 back has %d bytes, want 3606", len(got))
+	}
+
+	deck, err := Build(notes, Options{Category: "anki_words", WordsPerRound: 5, Dictionary: kanadict.Default(), MaxTranslationLength: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deck.Language != "jp" || deck.TranslationLanguage != "en" {
+		t.Errorf("languages = %s, %s, want jp, en", deck.Language, deck.TranslationLanguage)
+	}
+	if len(deck.Entries) != 29 {
+		t.Fatalf("got %d words, want 29", len(deck.Entries))
+	}
+
+	byRef := make(map[string]Entry)
+	for _, e := range deck.Entries {
+		byRef[e.Ref] = e
+	}
+	tests := []struct {
+		ref, id, display, word, translation string
+	}{
+		{"note 1", "anki_words_jp_1_001", "犬", "いぬ", "dog"},
+		{"note 2", "anki_words_jp_2_004", "猫", "ねこ", "cat kitty"},
+		{"note 3", "anki_words_jp_5_002", "食べる", "たべる", "to eat"},
+		// Japanese のノートは名前のないフィールドを番号で割り当て、Reading を読みに使う
+		{"note 9", "anki_words_jp_6_003", "学生", "がくせい", "student"},
+		{"note 6", "anki_words_jp_6_004", "ありがとう", "ありがとう", "thank you"},
+	}
+	for _, tt := range tests {
+		e, ok := byRef[tt.ref]
+		if !ok {
+			t.Errorf("%s was not imported", tt.ref)
+			continue
+		}
+		if e.Word.WordID != tt.id || e.Word.Display != tt.display || e.Word.Word != tt.word || e.Translation != tt.translation {
+			t.Errorf("%s = %s %s %s %q, want %s %s %s %q", tt.ref, e.Word.WordID, e.Word.Display, e.Word.Word, e.Translation,
+				tt.id, tt.display, tt.word, tt.translation)
+		}
+	}
+
+	// 打鍵数の少ない順に並ぶ
+	last := deck.Entries[len(deck.Entries)-1].Word
+	if last.Round != 6 || last.LookupKey != "anki_words#jp#6" {
+		t.Errorf("last word is in %s round %d, want anki_words#jp#6", last.LookupKey, last.Round)
+	}
+
+	wantSkipped := []string{"note 4", "note 5"}
+	wantInvalid := []string{"note 7", "note 8"}
+	if got := refs(deck.Skipped); !equal(got, wantSkipped) {
+		t.Errorf("skipped = %v, want %v", got, wantSkipped)
+	}
+	if got := refs(deck.Invalid); !equal(got, wantInvalid) {
+		t.Errorf("invalid = %v, want %v (%v)", got, wantInvalid, deck.Invalid)
+	}
+}
+
+func TestReadAPKGNewFormat(t *testing.T) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, name := range []string{"collection.anki2", "collection.anki21b"} {
+		if _, err := zw.Create(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Read(buf.Bytes(), 1000); err == nil || !strings.Contains(err.Error(), "Support older Anki versions") {
+		t.Errorf("Read(anki21b) error = %v, want an unsupported format error", err)
+	}
+}
+
+func TestReadTSV(t *testing.T) {
+	// Anki の「プレーンテキストのノート」の書き出し（GUID・ノートタイプの列付き）
+	input := "#separator:tab\n#html:true\n#guid column:1\n#notetype column:2\n" +
+		"a1\tBasic\tapple\tりんご\n" +
+		"a2\tBasic\t\"big<br>dog\"\t\"おおきい\nいぬ\"\n" +
+		"\n" +
+		"a3\tBasic\t[sound:x.mp3]\tおと\n" +
+		"a4\tBasic\tcat &amp; mouse\tねこ\n"
+	notes, err := ReadTSV(strings.NewReader(input), 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(notes) != 4 || notes[1].Ref != "line 6" || notes[3].Ref != "line 10" {
+		t.Fatalf("notes = %+v", notes)
+	}
+
+	deck, err := Build(notes, Options{Category: "anki", WordsPerRound: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deck.Language != "en" || deck.TranslationLanguage != "jp" {
+		t.Errorf("languages = %s, %s, want en, jp", deck.Language, deck.TranslationLanguage)
+	}
+	var got []string
+	for _, e := range deck.Entries {
+		got = append(got, e.Word.WordID+"="+e.Word.Word+"/"+e.Translation)
+	}
+	want := []string{"anki_en_1_001=apple/りんご", "anki_en_1_002=big dog/おおきい いぬ", "anki_en_2_001=cat & mouse/ねこ"}
+	if !equal(got, want) {
+		t.Errorf("words = %v, want %v", got, want)
+	}
+	if got := refs(deck.Skipped); !equal(got, []string{"line 9"}) {
+		t.Errorf("skipped = %v, want [line 9]", got)
+	}
+}
+
+func TestBuildFields(t *testing.T) {
+	notes := []Note{
+		{Ref: "line 1", Fields: []string{"いぬ", "dog", "日本[にほん]"}},
+		{Ref: "line 2", Fields: []string{"ねこ", "cat", "食[た]べ 物[もの]"}},
+	}
+	// 裏面を表面にし、3番目のフィールドを日本語の読み（ふりがな）にする
+	deck, err := Build(notes, Options{WordsPerRound: 10, Fields: Fields{Front: "2", Back: "1"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deck.Language != "en" || len(deck.Entries) != 2 || deck.Entries[0].Word.WordID != "" {
+		t.Fatalf("deck = %+v", deck)
+	}
+
+	deck, err = Build(notes, Options{WordsPerRound: 10, Fields: Fields{Reading: "3"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, e := range deck.Entries {
+		got = append(got, e.Word.Display+"="+e.Word.Word)
+	}
+	if want := []string{"日本=にほん", "食べ物=たべもの"}; !equal(got, want) {
+		t.Errorf("words = %v, want %v", got, want)
+	}
+
+	deck, err = Build(notes, Options{WordsPerRound: 10, Fields: Fields{Front: "Expression"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deck.Entries) != 0 || len(deck.Invalid) != 2 {
+		t.Errorf("unknown field: got %d words and %d invalid notes, want 0 and 2", len(deck.Entries), len(deck.Invalid))
+	}
+
+	// 長すぎる表面は読みを割り当てずに取り込まない
+	long := append(notes, Note{Ref: "line 3", Fields: []string{strings.Repeat("漢あ", 26), "long"}})
+	deck, err = Build(long, Options{WordsPerRound: 10, Language: "jp", MaxWordLength: 50})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(deck.Invalid) != 1 || deck.Invalid[0].Ref != "line 3" {
+		t.Errorf("long front: invalid = %+v, want line 3", deck.Invalid)
+	}
+}
+
+func TestInferLanguage(t *testing.T) {
+	tests := []struct {
+		texts []string
+		dict  *kanadict.Dictionary
+		want  string
+	}{
+		{[]string{"日本", "学生", "たべる"}, nil, "jp"},
+		{[]string{"你好", "学生"}, nil, "zh"},
+		// 辞書で読める漢字だけの単語は日本語
+		{[]string{"犬", "猫", "学生"}, kanadict.Default(), "jp"},
+		{[]string{"你好", "谢谢", "学生"}, kanadict.Default(), "zh"},
+		{[]string{"사과", "apple", "바나나"}, nil, "ko"},
+		{[]string{"apple", "dog", "犬"}, nil, "en"},
+		{[]string{"123", "!?"}, nil, ""},
+	}
+	for _, tt := range tests {
+		if got := InferLanguage(tt.texts, tt.dict); got != tt.want {
+			t.Errorf("InferLanguage(%v) = %q, want %q", tt.texts, got, tt.want)
+		}
+	}
+}
+
+func TestReadInvalid(t *testing.T) {
+	for name, data := range map[string][]byte{
+		"binary":       {0xff, 0xfe, 0x00},
+		"zip":          []byte("PK\x03\x04broken"),
+		"empty":        []byte("#separator:tab\n\n"),
+		"no sqlite db": zipWith(t, "collection.anki2", "not a database"),
+	} {
+		if _, err := Read(data, 1000); err == nil {
+			t.Errorf("Read(%s) succeeded, want error", name)
+		}
+	}
+}
+
+func TestReadTooManyNotes(t *testing.T) {
+	apkg, err := os.ReadFile("testdata/japanese.apkg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, data := range map[string][]byte{
+		"apkg": apkg,
+		"tsv":  []byte(strings.Repeat("apple\tりんご\n", 11)),
+	} {
+		if _, err := Read(data, 10); !errors.Is(err, ErrTooManyNotes) {
+			t.Errorf("Read(%s) error = %v, want ErrTooManyNotes", name, err)
+		}
+	}
+}
+
+// TestReadBrokenTree は内部ページのセルが同じページ・ファイルにないページを指す（細工した）コレクションを、
+// たどり続けずにエラーにすることを確認する
+func TestReadBrokenTree(t *testing.T) {
+	const pageSize = 512
+	schema := sqliteRecord("table", "notes", "notes", int64(2), "CREATE TABLE notes (id integer primary key, mid integer, flds text)")
+	master := make([]byte, pageSize)
+	copy(master, "SQLite format 3\x00")
+	binary.BigEndian.PutUint16(master[16:], pageSize)
+	binary.BigEndian.PutUint32(master[56:], 1)
+	// 1ページ目（sqlite_master の葉ページ）にスキーマの1行を置く
+	cell := append(append(sqliteVarint(len(schema)), 1), schema...)
+	at := pageSize - len(cell)
+	copy(master[at:], cell)
+	master[100] = 0x0d
+	binary.BigEndian.PutUint16(master[103:], 1)
+	binary.BigEndian.PutUint16(master[105:], uint16(at))
+	binary.BigEndian.PutUint16(master[108:], uint16(at))
+
+	// 2ページ目（notes の内部ページ）の 100 個のセルと右端の子ページがすべて child を指す
+	interior := func(child uint32) []byte {
+		p := make([]byte, pageSize)
+		p[0] = 0x05
+		binary.BigEndian.PutUint16(p[3:], 100)
+		binary.BigEndian.PutUint32(p[8:], child)
+		at := pageSize - 5
+		binary.BigEndian.PutUint32(p[at:], child)
+		p[at+4] = 1
+		for i := 0; i < 100; i++ {
+			binary.BigEndian.PutUint16(p[12+2*i:], uint16(at))
+		}
+		return p
+	}
+
+	tests := []struct {
+		name  string
+		child uint32
+		want  string
+	}{
+		{"self-referencing", 2, "page 2 is referenced more than once"},
+		{"out of range", 99, "page 99 is out of range"},
+	}
+	for _, tt := range tests {
+		db := append(append([]byte(nil), master...), interior(tt.child)...)
+		apkg := zipWith(t, "collection.anki2", string(db))
+		_, err := ReadAPKG(bytes.NewReader(apkg), int64(len(apkg)), 1000)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: ReadAPKG error = %v, want %q", tt.name, err, tt.want)
+		}
+	}
+}
+
+// sqliteRecord は SQLite のレコード（テキストと1バイトの整数だけ）を作る
+func sqliteRecord(values ...any) []byte {
+	var header, body []byte
+	for _, v := range values {
+		switch v := v.(type) {
+		case string:
+			header = append(header, sqliteVarint(13+2*len(v))...)
+			body = append(body, v...)
+		case int64:
+			header = append(header, 1)
+			body = append(body, byte(v))
+		}
+	}
+	return append(append(sqliteVarint(len(header)+1), header...), body...)
+}
+
+// sqliteVarint は 16383 以下の値の SQLite の可変長整数
+func sqliteVarint(v int) []byte {
+	if v < 0x80 {
+		return []byte{byte(v)}
+	}
+	return []byte{byte(v>>7) | 0x80, byte(v & 0x7f)}
+}
+
+func zipWith(t *testing.T, name, content string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Write([]byte(content))
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func refs(issues []Issue) []string {
+	var r []string
+	for _, i := range issues {
+		r = append(r, i.Ref)
+	}
+	return r
+}
+
+func equal(a, b []string) bool {
+	return strings.Join(a, "\n") == strings.Join(b, "\n")
+}
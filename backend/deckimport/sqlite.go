@@ -0,0 +1,391 @@
+package deckimport
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// sqliteDB は SQLite のデータベースファイル（https://www.sqlite.org/fileformat.html）を読む最小限の実装。
+// Anki のコレクションのテーブルを読むためのもので、テーブルの B-tree をたどってレコードを復号するだけを行う
+// （インデックス・WAL・UTF-8 以外のテキストは扱わない）。アップロードされたファイルを読むため、壊れた値は panic せずにエラーにする
+type sqliteDB struct {
+	data     []byte
+	pageSize int
+	// usable は各ページの末尾の予約領域を除いた大きさ
+	usable int
+}
+
+// sqliteTable はテーブルの列名と行。行の値は nil・int64・float64・string・[]byte のいずれか
+type sqliteTable struct {
+	columns []string
+	rows    [][]any
+}
+
+const sqliteMagic = "SQLite format 3\x00"
+
+// maxTreeDepth は B-tree の深さの上限
+const maxTreeDepth = 32
+
+// errTooManyRows はテーブルの行が上限を超えたことを示す
+var errTooManyRows = errors.New("too many rows")
+
+func openSQLite(data []byte) (*sqliteDB, error) {
+	if len(data) < 100 || string(data[:16]) != sqliteMagic {
+		return nil, errors.New("not a SQLite database")
+	}
+	pageSize := int(binary.BigEndian.Uint16(data[16:18]))
+	if pageSize == 1 {
+		pageSize = 65536
+	}
+	if pageSize < 512 || pageSize&(pageSize-1) != 0 {
+		return nil, fmt.Errorf("invalid SQLite page size %d", pageSize)
+	}
+	if encoding := binary.BigEndian.Uint32(data[56:60]); encoding > 1 {
+		return nil, fmt.Errorf("unsupported SQLite text encoding %d (only UTF-8)", encoding)
+	}
+	usable := pageSize - int(data[20])
+	if usable < 480 {
+		return nil, fmt.Errorf("invalid SQLite reserved space %d", data[20])
+	}
+	return &sqliteDB{data: data, pageSize: pageSize, usable: usable}, nil
+}
+
+// pages はファイルのページ数
+func (db *sqliteDB) pages() uint32 {
+	return uint32(min(int64(len(db.data)/db.pageSize), math.MaxUint32))
+}
+
+func (db *sqliteDB) page(n uint32) ([]byte, error) {
+	if n < 1 || n > db.pages() {
+		return nil, fmt.Errorf("SQLite page %d is out of range (%d pages)", n, db.pages())
+	}
+	start := (int64(n) - 1) * int64(db.pageSize)
+	return db.data[start : start+int64(db.pageSize)], nil
+}
+
+// table はテーブルのすべての行を rowid の順に返す。列名はスキーマ（sqlite_master）の CREATE TABLE 文から求め、
+// INTEGER PRIMARY KEY の列（rowid の別名で、レコードには NULL が入る）には rowid を入れる。
+// 行が maxRows を超える場合は読むのをやめてエラーを返す
+func (db *sqliteDB) table(name string, maxRows int) (*sqliteTable, error) {
+	var root uint32
+	var sql string
+	// sqlite_master は1ページ目を root とするテーブル（type, name, tbl_name, rootpage, sql）
+	err := db.walk(1, func(_ int64, values []any) error {
+		if len(values) < 5 || values[0] != "table" || !strings.EqualFold(fmt.Sprint(values[1]), name) {
+			return nil
+		}
+		page, ok := values[3].(int64)
+		if !ok || page < 1 || page > math.MaxUint32 {
+			return fmt.Errorf("invalid root page for table %s", name)
+		}
+		root = uint32(page)
+		sql, _ = values[4].(string)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if root == 0 {
+		return nil, fmt.Errorf("no table %s", name)
+	}
+
+	columns, rowidColumn := parseColumns(sql)
+	t := &sqliteTable{columns: columns}
+	err = db.walk(root, func(rowid int64, values []any) error {
+		if len(t.rows) >= maxRows {
+			return fmt.Errorf("%w (more than %d)", errTooManyRows, maxRows)
+		}
+		// ALTER TABLE で後から加えた列は、古い行のレコードにない（既定値は NULL とみなす）
+		row := make([]any, max(len(columns), len(values)))
+		copy(row, values)
+		if rowidColumn >= 0 && row[rowidColumn] == nil {
+			row[rowidColumn] = rowid
+		}
+		t.rows = append(t.rows, row)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("table %s: %w", name, err)
+	}
+	return t, nil
+}
+
+// column は列の番号を返す（列がない場合は -1）
+func (t *sqliteTable) column(name string) int {
+	for i, c := range t.columns {
+		if strings.EqualFold(c, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// parseColumns は CREATE TABLE 文から列名と、rowid の別名の列（INTEGER PRIMARY KEY）の番号を返す
+func parseColumns(sql string) ([]string, int) {
+	start, end := strings.Index(sql, "("), strings.LastIndex(sql, ")")
+	if start < 0 || end < start {
+		return nil, -1
+	}
+
+	var defs []string
+	depth, from := 0, start+1
+	for i := start + 1; i < end; i++ {
+		switch sql[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				defs = append(defs, sql[from:i])
+				from = i + 1
+			}
+		}
+	}
+	defs = append(defs, sql[from:end])
+
+	var columns []string
+	rowidColumn := -1
+	for _, def := range defs {
+		fields := strings.Fields(def)
+		if len(fields) == 0 {
+			continue
+		}
+		switch strings.ToUpper(fields[0]) {
+		case "PRIMARY", "UNIQUE", "CHECK", "FOREIGN", "CONSTRAINT":
+			// テーブルの制約（列ではない）
+			continue
+		}
+		if strings.Contains(strings.ToUpper(strings.Join(fields, " ")), "INTEGER PRIMARY KEY") {
+			rowidColumn = len(columns)
+		}
+		columns = append(columns, strings.Trim(fields[0], "\"`[]'"))
+	}
+	return columns, rowidColumn
+}
+
+// treeWalk は1つの B-tree をたどる間の状態
+type treeWalk struct {
+	db *sqliteDB
+	// visited はたどったページ
+	visited map[uint32]bool
+	// read は読んだレコードの合計の大きさ
+	read int
+	fn   func(rowid int64, values []any) error
+}
+
+// walk はテーブルの B-tree（root のページ以下）の行を rowid の順に fn に渡す。
+// 壊れた・細工したファイルでは内部ページのセル・オーバーフローページが同じページを何度も指せるため（たどる量が指数的に増える）、
+// 同じページを2回たどる場合と、レコードの合計がファイルより大きくなる場合はエラーにする
+func (db *sqliteDB) walk(root uint32, fn func(rowid int64, values []any) error) error {
+	w := &treeWalk{db: db, visited: make(map[uint32]bool), fn: fn}
+	return w.page(root, 0)
+}
+
+func (w *treeWalk) page(n uint32, depth int) error {
+	db := w.db
+	if depth > maxTreeDepth {
+		return errors.New("SQLite b-tree is too deep")
+	}
+	if n < 1 || n > db.pages() {
+		return fmt.Errorf("SQLite page %d is out of range (%d pages)", n, db.pages())
+	}
+	if w.visited[n] {
+		return fmt.Errorf("SQLite page %d is referenced more than once", n)
+	}
+	w.visited[n] = true
+	p, err := db.page(n)
+	if err != nil {
+		return err
+	}
+	header := 0
+	if n == 1 {
+		// 1ページ目の先頭はデータベースのヘッダー
+		header = 100
+	}
+	if len(p) < header+12 {
+		return fmt.Errorf("SQLite page %d is too small", n)
+	}
+	cells := int(binary.BigEndian.Uint16(p[header+3:]))
+
+	switch p[header] {
+	case 0x0d: // テーブルの葉ページ
+		for i := 0; i < cells; i++ {
+			offset, err := cellOffset(p, header+8, i)
+			if err != nil {
+				return fmt.Errorf("SQLite page %d: %w", n, err)
+			}
+			rowid, payload, err := db.leafCell(p, offset)
+			if err != nil {
+				return fmt.Errorf("SQLite page %d: %w", n, err)
+			}
+			if w.read += len(payload); w.read > len(db.data) {
+				return fmt.Errorf("SQLite page %d: records are larger than the database", n)
+			}
+			values, err := decodeRecord(payload)
+			if err != nil {
+				return fmt.Errorf("SQLite page %d: row %d: %w", n, rowid, err)
+			}
+			if err := w.fn(rowid, values); err != nil {
+				return err
+			}
+		}
+		return nil
+	case 0x05: // テーブルの内部ページ（セルは左の子ページ、ヘッダーに右端の子ページ）
+		for i := 0; i < cells; i++ {
+			offset, err := cellOffset(p, header+12, i)
+			if err != nil || offset+4 > len(p) {
+				return fmt.Errorf("SQLite page %d: invalid cell %d", n, i)
+			}
+			if err := w.page(binary.BigEndian.Uint32(p[offset:]), depth+1); err != nil {
+				return err
+			}
+		}
+		return w.page(binary.BigEndian.Uint32(p[header+8:]), depth+1)
+	default:
+		return fmt.Errorf("SQLite page %d is not a table b-tree page (type %d)", n, p[header])
+	}
+}
+
+// cellOffset はセルのポインタ配列（ページの先頭から start バイト目）の i 番目のセルの位置を返す
+func cellOffset(p []byte, start, i int) (int, error) {
+	at := start + 2*i
+	if at+2 > len(p) {
+		return 0, fmt.Errorf("invalid cell pointer %d", i)
+	}
+	offset := int(binary.BigEndian.Uint16(p[at:]))
+	if offset >= len(p) {
+		return 0, fmt.Errorf("invalid cell offset %d", offset)
+	}
+	return offset, nil
+}
+
+// leafCell は葉ページのセルの rowid とレコードを返す。ページに収まらないレコードは続きをオーバーフローページから読む
+func (db *sqliteDB) leafCell(p []byte, offset int) (int64, []byte, error) {
+	size, n := varint(p[offset:])
+	if n == 0 || size < 0 || size > int64(len(db.data)) {
+		return 0, nil, errors.New("invalid record size")
+	}
+	offset += n
+	rowid, n := varint(p[offset:])
+	if n == 0 {
+		return 0, nil, errors.New("invalid rowid")
+	}
+	offset += n
+
+	// ページに置く大きさはファイル形式の仕様の計算式で決まる
+	total := int(size)
+	local := total
+	if maxLocal := db.usable - 35; total > maxLocal {
+		minLocal := (db.usable-12)*32/255 - 23
+		local = minLocal + (total-minLocal)%(db.usable-4)
+		if local > maxLocal {
+			local = minLocal
+		}
+	}
+	if offset+local > len(p) {
+		return 0, nil, fmt.Errorf("row %d: record exceeds the page", rowid)
+	}
+	payload := append([]byte(nil), p[offset:offset+local]...)
+	if local == total {
+		return rowid, payload, nil
+	}
+
+	if offset+local+4 > len(p) {
+		return 0, nil, fmt.Errorf("row %d: missing overflow page", rowid)
+	}
+	next := binary.BigEndian.Uint32(p[offset+local:])
+	for pages := 0; len(payload) < total; pages++ {
+		if next == 0 || pages > len(db.data)/db.pageSize {
+			return 0, nil, fmt.Errorf("row %d: broken overflow chain", rowid)
+		}
+		overflow, err := db.page(next)
+		if err != nil {
+			return 0, nil, fmt.Errorf("row %d: %w", rowid, err)
+		}
+		next = binary.BigEndian.Uint32(overflow)
+		payload = append(payload, overflow[4:min(db.usable, 4+total-len(payload))]...)
+	}
+	return rowid, payload, nil
+}
+
+// decodeRecord はレコード（ヘッダーの列の型と値）を復号する
+func decodeRecord(payload []byte) ([]any, error) {
+	headerSize, n := varint(payload)
+	if n == 0 || headerSize < int64(n) || headerSize > int64(len(payload)) {
+		return nil, errors.New("invalid record header")
+	}
+	var types []int64
+	for at := n; at < int(headerSize); {
+		t, n := varint(payload[at:int(headerSize)])
+		if n == 0 {
+			return nil, errors.New("invalid record header")
+		}
+		types = append(types, t)
+		at += n
+	}
+
+	values := make([]any, len(types))
+	body := payload[headerSize:]
+	for i, t := range types {
+		size := 0
+		switch {
+		case t >= 12:
+			size = int((t - 12) / 2)
+		case t >= 1 && t <= 4:
+			size = int(t)
+		case t == 5:
+			size = 6
+		case t == 6, t == 7:
+			size = 8
+		case t == 10, t == 11:
+			return nil, fmt.Errorf("invalid serial type %d", t)
+		}
+		if size > len(body) {
+			return nil, errors.New("record is truncated")
+		}
+		v := body[:size]
+		body = body[size:]
+
+		switch {
+		case t == 0:
+			values[i] = nil
+		case t >= 1 && t <= 6:
+			// 符号付きのビッグエンディアンの整数
+			x := int64(int8(v[0]))
+			for _, b := range v[1:] {
+				x = x<<8 | int64(b)
+			}
+			values[i] = x
+		case t == 7:
+			values[i] = math.Float64frombits(binary.BigEndian.Uint64(v))
+		case t == 8:
+			values[i] = int64(0)
+		case t == 9:
+			values[i] = int64(1)
+		case t%2 == 0:
+			values[i] = append([]byte(nil), v...)
+		default:
+			values[i] = string(v)
+		}
+	}
+	return values, nil
+}
+
+// varint は SQLite の可変長整数（1〜9バイトのビッグエンディアン）を読み、値と読んだバイト数を返す（読めない場合は 0 バイト）
+func varint(b []byte) (int64, int) {
+	var v uint64
+	for i := 0; i < 9 && i < len(b); i++ {
+		if i == 8 {
+			return int64(v<<8 | uint64(b[i])), 9
+		}
+		v = v<<7 | uint64(b[i]&0x7f)
+		if b[i] < 0x80 {
+			return int64(v), i + 1
+		}
+	}
+	return 0, 0
+}
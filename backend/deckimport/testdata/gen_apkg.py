@@ -0,0 +1,67 @@
+# deckimport のテスト用の Anki デッキ（japanese.apkg）を作る。
+# B-tree の内部ページとオーバーフローページを読むように、ページの大きさを最小の 512 バイトにする。
+#
+#   python3 testdata/gen_apkg.py
+import json
+import os
+import sqlite3
+import tempfile
+import zipfile
+
+HERE = os.path.dirname(os.path.abspath(__file__))
+
+NOTES = [
+    (1, ["犬", "dog"]),
+    (1, ["<b>猫</b>", "cat&nbsp;<br>kitty"]),
+    (1, ["食[た]べる", "to eat"]),
+    (1, ["[sound:inu.mp3]", "sound only"]),
+    (1, ["犬", "dog again"]),
+    (1, ["ありがとう", "thank you"]),
+    (1, ["鬱", "depression"]),
+    (1, ["一日", "a day " + "and more " * 400]),
+    (2, ["学生", "student", "がくせい"]),
+] + [(1, [w, "filler"]) for w in [
+    "あめ", "いす", "うみ", "えき", "おか", "かさ", "きつね", "くも", "けむり", "こえ", "さくら", "しお",
+    "すし", "せみ", "そら", "たこ", "ちず", "つき", "てがみ", "とり", "なつ", "にじ", "ぬの", "ねずみ",
+]]
+
+MODELS = {
+    "1": {"name": "Basic", "flds": [{"name": "Back", "ord": 1}, {"name": "Front", "ord": 0}]},
+    "2": {"name": "Japanese", "flds": [{"name": "Expression", "ord": 0}, {"name": "Meaning", "ord": 1}, {"name": "Reading", "ord": 2}]},
+}
+
+
+def main():
+    with tempfile.TemporaryDirectory() as tmp:
+        path = os.path.join(tmp, "collection.anki2")
+        db = sqlite3.connect(path)
+        db.execute("PRAGMA page_size = 512")
+        db.execute(
+            "CREATE TABLE col (id integer primary key, crt integer not null, mod integer not null, scm integer not null, "
+            "ver integer not null, dty integer not null, usn integer not null, ls integer not null, conf text not null, "
+            "models text not null, decks text not null, dconf text not null, tags text not null)"
+        )
+        db.execute(
+            "CREATE TABLE notes (id integer primary key, guid text not null, mid integer not null, mod integer not null, "
+            "usn integer not null, tags text not null, flds text not null, sfld integer not null, csum integer not null, "
+            "flags integer not null, data text not null)"
+        )
+        db.execute(
+            "INSERT INTO col VALUES (1, 0, 0, 0, 11, 0, 0, 0, '{}', ?, '{}', '{}', '{}')",
+            (json.dumps(MODELS, ensure_ascii=False),),
+        )
+        for i, (mid, fields) in enumerate(NOTES):
+            db.execute(
+                "INSERT INTO notes VALUES (?, ?, ?, 0, 0, '', ?, ?, 0, 0, '')",
+                (1700000000000 + i, "guid%d" % i, mid, "\x1f".join(fields), fields[0]),
+            )
+        db.commit()
+        db.close()
+
+        with zipfile.ZipFile(os.path.join(HERE, "japanese.apkg"), "w", zipfile.ZIP_DEFLATED) as z:
+            z.write(path, "collection.anki2")
+            z.writestr("media", "{}")
+
+
+if __name__ == "__main__":
+    main()
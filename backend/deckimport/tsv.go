@@ -0,0 +1,126 @@
+package deckimport
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// separators は Anki のヘッダーの #separator の名前と区切り文字
+var separators = map[string]rune{
+	"tab":       '\t',
+	"comma":     ',',
+	"semicolon": ';',
+	"pipe":      '|',
+	"colon":     ':',
+	"space":     ' ',
+}
+
+// metadataColumns はノートのフィールドではない列を示す Anki のヘッダー
+var metadataColumns = []string{"guid column", "notetype column", "deck column", "tags column"}
+
+// ReadTSV は Anki の「プレーンテキストのノート」の書き出し、またはタブ区切りのテキスト（1行1ノート）から maxNotes 個までのノートを読む。
+// 先頭の # で始まる行は Anki のヘッダーとして読み、#separator（区切り文字）・#html（フィールドが HTML か）・
+// #columns（フィールド名）と、GUID・ノートタイプ・デッキ・タグの列を使う。値の中の区切り文字・改行は " で囲む
+func ReadTSV(r io.Reader, maxNotes int) ([]Note, error) {
+	br := bufio.NewReader(r)
+	if bom, _ := br.Peek(3); string(bom) == "\ufeff" {
+		br.Discard(3)
+	}
+	separator := '\t'
+	html := false
+	var columns []string
+	skip := make(map[int]bool)
+
+	lines := 0
+	for {
+		peek, err := br.Peek(1)
+		if err != nil || peek[0] != '#' {
+			break
+		}
+		line, err := br.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return nil, fmt.Errorf("failed to read header: %w", err)
+		}
+		lines++
+		key, value, ok := strings.Cut(strings.TrimRight(strings.TrimPrefix(line, "#"), "\r\n"), ":")
+		if !ok {
+			continue
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		switch {
+		case key == "separator":
+			if sep, ok := separators[strings.ToLower(strings.TrimSpace(value))]; ok {
+				separator = sep
+			} else if utf8.RuneCountInString(value) == 1 {
+				separator, _ = utf8.DecodeRuneInString(value)
+			} else {
+				return nil, fmt.Errorf("line %d: unsupported separator %q", lines, value)
+			}
+		case key == "html":
+			html = strings.EqualFold(strings.TrimSpace(value), "true")
+		case key == "columns":
+			columns = strings.Split(value, string(separator))
+		case slices.Contains(metadataColumns, key):
+			n, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("line %d: invalid %s %q", lines, key, value)
+			}
+			skip[n-1] = true
+		}
+	}
+
+	reader := csv.NewReader(br)
+	reader.Comma = separator
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	var names []string
+	for i, c := range columns {
+		if !skip[i] {
+			names = append(names, strings.TrimSpace(c))
+		}
+	}
+
+	var notes []Note
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read notes: %w", err)
+		}
+		line, _ := reader.FieldPos(0)
+
+		var fields []string
+		for i, value := range record {
+			if !skip[i] {
+				fields = append(fields, value)
+			}
+		}
+		if strings.TrimSpace(strings.Join(fields, "")) == "" {
+			continue
+		}
+		if len(notes) >= maxNotes {
+			return nil, fmt.Errorf("%w (more than %d)", ErrTooManyNotes, maxNotes)
+		}
+		notes = append(notes, Note{
+			Ref:    fmt.Sprintf("line %d", lines+line),
+			Names:  names,
+			Fields: fields,
+			HTML:   html,
+		})
+	}
+	if len(notes) == 0 {
+		return nil, errors.New("no notes in the file")
+	}
+	return notes, nil
+}
@@ -0,0 +1,1054 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"typing-game-backend/config"
+	"typing-game-backend/furigana"
+	"typing-game-backend/game"
+	"typing-game-backend/leaderboard"
+	"typing-game-backend/names"
+	"typing-game-backend/passage"
+	"typing-game-backend/wordlist"
+)
+
+type ScoreItem struct {
+	PlayerName string `dynamodbav:"player_name"`
+	Score      int    `dynamodbav:"score"`
+	Round      int    `dynamodbav:"round"`
+	Time       int    `dynamodbav:"time"`
+	Category   string `dynamodbav:"category"`
+	Timestamp  int64  `dynamodbav:"timestamp"`
+	ScoreType  string `dynamodbav:"score_type"`
+	ExpiresAt  int64  `dynamodbav:"expires_at,omitempty"` // TTL属性（保持期間が無期限の場合は付与しない）
+	// StatsCounted はプレイヤー累計に加算済みのスコア。backfill で二重に加算しないための目印
+	StatsCounted bool `dynamodbav:"stats_counted,omitempty"`
+	// RulesetVersion はプレイしたルールセットのバージョン（記録を始める前のスコアにはない）
+	RulesetVersion string `dynamodbav:"ruleset_version,omitempty"`
+	// Mode はプレイしたモード（記録を始める前のスコアにはなく、通常のモードとして扱う）
+	Mode game.Mode `dynamodbav:"mode,omitempty"`
+	// 以下はタイピングテスト（タイムアタック・練習）のスコアのみ。
+	// ElapsedMs・WPM・Accuracy は Typing から計算した値で、リーダーボードの再構築に使う
+	Duration  int               `dynamodbav:"duration,omitempty"`
+	ElapsedMs int               `dynamodbav:"elapsed_ms,omitempty"`
+	WPM       float64           `dynamodbav:"wpm,omitempty"`
+	Accuracy  float64           `dynamodbav:"accuracy,omitempty"`
+	Typing    *game.TypingStats `dynamodbav:"typing,omitempty"`
+	// Progress は文章モードの文ごとの記録
+	Progress *game.PassageProgress `dynamodbav:"progress,omitempty"`
+}
+
+// PlayerStatsItem はプレイヤーごとの累計。スコア登録と同じトランザクションで加算するため、
+// scores テーブルの記録がTTLで削除された後も残る
+type PlayerStatsItem struct {
+	PlayerName  string `dynamodbav:"player_name" json:"player_name"`
+	GamesPlayed int    `dynamodbav:"games_played" json:"games_played"`
+	TotalScore  int64  `dynamodbav:"total_score" json:"total_score"`
+	TotalTime   int64  `dynamodbav:"total_time" json:"total_time"`
+	FirstPlayed int64  `dynamodbav:"first_played" json:"first_played"`
+	LastPlayed  int64  `dynamodbav:"last_played" json:"last_played"`
+}
+
+type LeaderboardItem struct {
+	PlayerName string `dynamodbav:"player_name" json:"player_name"`
+	Score      int    `dynamodbav:"score" json:"score"`
+	Round      int    `dynamodbav:"round" json:"round"`
+	Category   string `dynamodbav:"category" json:"category"`
+	Rank       int    `dynamodbav:"rank" json:"rank"`
+	Timestamp  int64  `dynamodbav:"timestamp,omitempty" json:"-"` // 自己ベストを達成した時刻
+	// 以下はタイピングテスト（タイムアタック・練習）のビューのみ
+	WPM       float64 `dynamodbav:"wpm,omitempty" json:"wpm,omitempty"`
+	Accuracy  float64 `dynamodbav:"accuracy,omitempty" json:"accuracy,omitempty"`
+	ElapsedMs int     `dynamodbav:"elapsed_ms,omitempty" json:"elapsed_ms,omitempty"`
+}
+
+// LeaderboardViewItem はカテゴリー別・期間別・モード別ビュー（leaderboard views テーブル）の項目
+type LeaderboardViewItem struct {
+	View       string `dynamodbav:"view"`
+	PlayerName string `dynamodbav:"player_name"`
+	Score      int    `dynamodbav:"score"`
+	Round      int    `dynamodbav:"round"`
+	Category   string `dynamodbav:"category"`
+	Timestamp  int64  `dynamodbav:"timestamp"`
+	// 以下はタイピングテスト（タイムアタック・練習）のビューのみ
+	WPM       float64 `dynamodbav:"wpm,omitempty"`
+	Accuracy  float64 `dynamodbav:"accuracy,omitempty"`
+	ElapsedMs int     `dynamodbav:"elapsed_ms,omitempty"`
+}
+
+func (v LeaderboardViewItem) entry() leaderboard.Entry {
+	return leaderboard.Entry{
+		PlayerName: v.PlayerName,
+		Score:      v.Score,
+		Round:      v.Round,
+		Category:   v.Category,
+		Timestamp:  v.Timestamp,
+		ElapsedMs:  v.ElapsedMs,
+		WPM:        v.WPM,
+		Accuracy:   v.Accuracy,
+	}
+}
+
+type WordItem struct {
+	Category string `dynamodbav:"category" json:"category"`
+	WordID   string `dynamodbav:"word_id" json:"word_id"`
+	Word     string `dynamodbav:"word" json:"word"` // 入力する読み（日本語はひらがな）
+	// Display は表示形（漢字かな混じり・カタカナ）、Furigana は表示形の区間ごとの読み（日本語のみ）
+	Display   string             `dynamodbav:"display,omitempty" json:"display"`
+	Furigana  []furigana.Segment `dynamodbav:"furigana,omitempty" json:"furigana,omitempty"`
+	Round     int                `dynamodbav:"round" json:"round"`
+	Type      string             `dynamodbav:"type" json:"type"` // "normal", "bonus", "debuff"
+	Language  string             `dynamodbav:"language" json:"language"`
+	LookupKey string             `dynamodbav:"lookup_key,omitempty" json:"-"` // category#language#round (LookupIndex用)
+}
+
+// fillDisplay は表示形のない単語（かなだけで登録された単語）に表示形とふりがなを補う
+func (w *WordItem) fillDisplay() {
+	if w.Display == "" {
+		w.Display = w.Word
+	}
+	if w.Language == "jp" && len(w.Furigana) == 0 {
+		if segments, err := furigana.Align(w.Display, w.Word); err == nil {
+			w.Furigana = segments
+		}
+	}
+}
+
+type TranslationItem struct {
+	WordID      string `dynamodbav:"word_id" json:"word_id"`
+	Language    string `dynamodbav:"language" json:"language"`
+	Translation string `dynamodbav:"translation" json:"translation"`
+	Category    string `dynamodbav:"category" json:"category"`
+	CreatedAt   string `dynamodbav:"created_at" json:"created_at"`
+	UpdatedAt   string `dynamodbav:"updated_at" json:"updated_at"`
+}
+
+// dynamoStore はDynamoDBの各テーブルへのアクセスをまとめたもの
+type dynamoStore struct {
+	client    *dynamodb.Client
+	tables    config.TablesConfig
+	timeout   config.StorageConfig
+	retention config.RetentionConfig
+	calls     *resilientCaller
+}
+
+func newDynamoStore(client *dynamodb.Client, tables config.TablesConfig, storage config.StorageConfig, retention config.RetentionConfig) *dynamoStore {
+	return &dynamoStore{
+		client:    client,
+		tables:    tables,
+		timeout:   storage,
+		retention: retention,
+		calls:     newResilientCaller(storage),
+	}
+}
+
+// read は読み込み用のタイムアウトでDynamoDBを呼び出す
+func (s *dynamoStore) read(ctx context.Context, table, op string, fn func(ctx context.Context) error) error {
+	return s.calls.call(ctx, table, op, s.timeout.ReadTimeout.Duration, fn)
+}
+
+// write は書き込み用のタイムアウトでDynamoDBを呼び出す
+func (s *dynamoStore) write(ctx context.Context, table, op string, fn func(ctx context.Context) error) error {
+	return s.calls.call(ctx, table, op, s.timeout.WriteTimeout.Duration, fn)
+}
+
+// resilientClient は dynamoStore と同じタイムアウト・リトライ・サーキットブレーカーを通してDynamoDBを呼び出す privacy.Client。
+// SDK側のリトライは無効にしているため、他のパッケージにはクライアントをそのまま渡さない
+type resilientClient struct {
+	store *dynamoStore
+}
+
+func (c resilientClient) Query(ctx context.Context, params *dynamodb.QueryInput, optFns ...func(*dynamodb.Options)) (*dynamodb.QueryOutput, error) {
+	var out *dynamodb.QueryOutput
+	err := c.store.read(ctx, aws.ToString(params.TableName), "Query", func(ctx context.Context) error {
+		var err error
+		out, err = c.store.client.Query(ctx, params, optFns...)
+		return err
+	})
+	return out, err
+}
+
+func (c resilientClient) GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error) {
+	var out *dynamodb.GetItemOutput
+	err := c.store.read(ctx, aws.ToString(params.TableName), "GetItem", func(ctx context.Context) error {
+		var err error
+		out, err = c.store.client.GetItem(ctx, params, optFns...)
+		return err
+	})
+	return out, err
+}
+
+func (c resilientClient) DeleteItem(ctx context.Context, params *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error) {
+	var out *dynamodb.DeleteItemOutput
+	err := c.store.write(ctx, aws.ToString(params.TableName), "DeleteItem", func(ctx context.Context) error {
+		var err error
+		out, err = c.store.client.DeleteItem(ctx, params, optFns...)
+		return err
+	})
+	return out, err
+}
+
+// TransactWriteItems は最初の項目のテーブルのサーキットブレーカーを使う
+func (c resilientClient) TransactWriteItems(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error) {
+	var table string
+	if len(params.TransactItems) > 0 {
+		switch item := params.TransactItems[0]; {
+		case item.Put != nil:
+			table = aws.ToString(item.Put.TableName)
+		case item.Delete != nil:
+			table = aws.ToString(item.Delete.TableName)
+		case item.Update != nil:
+			table = aws.ToString(item.Update.TableName)
+		case item.ConditionCheck != nil:
+			table = aws.ToString(item.ConditionCheck.TableName)
+		}
+	}
+
+	var out *dynamodb.TransactWriteItemsOutput
+	err := c.store.write(ctx, table, "TransactWriteItems", func(ctx context.Context) error {
+		var err error
+		out, err = c.store.client.TransactWriteItems(ctx, params, optFns...)
+		return err
+	})
+	return out, err
+}
+
+// namedTable は論理名とテーブル名の組
+type namedTable struct {
+	name  string
+	table string
+}
+
+// errTableNotActive はテーブルが存在するが利用可能な状態でないことを表す
+var errTableNotActive = errors.New("table is not active")
+
+// errTranslationNotFound は翻訳が登録されていないことを表す（ストレージの障害と区別する）
+var errTranslationNotFound = errors.New("translation not found")
+
+// errPassageNotFound は文章が登録されていないことを表す（ストレージの障害と区別する）
+var errPassageNotFound = errors.New("passage not found")
+
+// errWordListNotFound は単語リストが作成されていないことを表す（ストレージの障害と区別する）
+var errWordListNotFound = errors.New("word list not found")
+
+// tableNames は設定済み（未設定を含む）のテーブルを返す
+func (s *dynamoStore) tableNames() []namedTable {
+	return []namedTable{
+		{name: "scores", table: s.tables.Scores},
+		{name: "leaderboard", table: s.tables.Leaderboard},
+		{name: "leaderboard_views", table: s.tables.LeaderboardViews},
+		{name: "player_stats", table: s.tables.PlayerStats},
+		{name: "player_names", table: s.tables.PlayerNames},
+		{name: "words", table: s.tables.Words},
+		{name: "translations", table: s.tables.Translations},
+		{name: "passages", table: s.tables.Passages},
+		{name: "word_lists", table: s.tables.WordLists},
+	}
+}
+
+// pingTable はテーブルに到達でき、利用可能な状態であることを確認する
+func (s *dynamoStore) pingTable(ctx context.Context, table string) error {
+	out, err := s.client.DescribeTable(ctx, &dynamodb.DescribeTableInput{
+		TableName: aws.String(table),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to describe table %s: %w", table, err)
+	}
+
+	switch out.Table.TableStatus {
+	case types.TableStatusActive, types.TableStatusUpdating:
+		return nil
+	default:
+		return fmt.Errorf("%w: status %s", errTableNotActive, out.Table.TableStatus)
+	}
+}
+
+// DynamoDB operations
+
+// scoreResult はスコア登録の結果
+type scoreResult struct {
+	PersonalBest bool // 自己ベストを更新したか（初回登録を含む）
+	PreviousBest int  // 更新前の自己ベスト（初回は0）
+}
+
+// leaderboardCondition は既存の記録より高いスコアのときだけリーダーボードを書き換える条件
+const leaderboardCondition = "attribute_not_exists(player_name) OR score < :score"
+
+// roundLeaderboardCondition はラウンド数で順位を決めるビュー（エンドレスモード）で、
+// 既存の記録より多いラウンド数か、同じラウンド数で高いスコアのときだけ書き換える条件
+const roundLeaderboardCondition = "attribute_not_exists(player_name) OR #round < :round OR (#round = :round AND score < :score)"
+
+// timeLeaderboardCondition は入力時間で順位を決めるビュー（練習モード）で、
+// 既存の記録より短い時間か、同じ時間で高いスコアのときだけ書き換える条件
+const timeLeaderboardCondition = "attribute_not_exists(player_name) OR elapsed_ms > :elapsed_ms OR (elapsed_ms = :elapsed_ms AND score < :score)"
+
+// nameClaimCondition は名前の Skeleton が未登録か、同じプレイヤーが登録している場合だけ書き込む条件
+const nameClaimCondition = "attribute_not_exists(name_key) OR player_name = :name"
+
+// scoreWrite はスコア登録のトランザクションに含める書き込みの種類
+type scoreWrite int
+
+const (
+	writeScore scoreWrite = iota
+	writeLeaderboard
+	writePlayerStats
+	writeNameClaim
+)
+
+// recordScore はスコアを保存し、自己ベストであればリーダーボードも更新する。
+// 通常のモードは全体（leaderboard テーブル）、それ以外のモードはモード別ビュー（leaderboard views テーブル）で自己ベストを判定する。
+// スコア・リーダーボード・プレイヤー累計・名前の登録は1つのトランザクションで行い、一部だけが反映されることはない。
+// リーダーボードの条件が満たされない（自己ベストでない）場合はリーダーボード以外を書き込む。
+// 同じプレイヤーの同じ秒（スコアのキー）のスコアがある場合は、次の秒のスコアとして保存する。
+// 名前が別のプレイヤーの名前と紛らわしい場合は names.CodeTaken の *names.Violation を返す
+func (s *dynamoStore) recordScore(ctx context.Context, sub ScoreSubmission) (scoreResult, error) {
+	if s.tables.Scores == "" {
+		return scoreResult{}, fmt.Errorf("scores table is not configured (SCORES_TABLE_NAME)")
+	}
+	if s.tables.Leaderboard == "" {
+		return scoreResult{}, fmt.Errorf("leaderboard table is not configured (LEADERBOARD_TABLE_NAME)")
+	}
+
+	slog.Debug("Saving score", "table", s.tables.Scores, "player_name", sub.PlayerName, "score", sub.Score)
+
+	now := time.Now()
+	scoreItem := ScoreItem{
+		PlayerName:     sub.PlayerName,
+		Score:          sub.Score,
+		Round:          sub.Round,
+		Time:           sub.Time,
+		Category:       sub.Category,
+		Timestamp:      now.Unix(),
+		ScoreType:      "game", // GSI用の固定値
+		RulesetVersion: sub.RulesetVersion,
+		Mode:           sub.Mode,
+		Duration:       sub.Duration,
+		WPM:            sub.WPM,
+		Accuracy:       sub.Accuracy,
+		Typing:         sub.Typing,
+		Progress:       sub.Progress,
+	}
+	switch {
+	case sub.Typing != nil:
+		scoreItem.ElapsedMs = sub.Typing.ElapsedMs
+	case sub.Progress != nil:
+		scoreItem.ElapsedMs = sub.Progress.Stats().ElapsedMs
+	}
+	if s.retention.ScoreDays > 0 {
+		scoreItem.ExpiresAt = now.AddDate(0, 0, s.retention.ScoreDays).Unix()
+	}
+	scoreItem.StatsCounted = s.tables.PlayerStats != ""
+	scoreAV, err := attributevalue.MarshalMap(scoreItem)
+	if err != nil {
+		return scoreResult{}, fmt.Errorf("failed to marshal score item: %w", err)
+	}
+
+	best, err := s.personalBestWrite(scoreItem)
+	if err != nil {
+		return scoreResult{}, err
+	}
+
+	writes := map[scoreWrite]types.TransactWriteItem{
+		writeScore:       s.scorePut(scoreAV),
+		writeLeaderboard: best,
+	}
+	if stats, ok := s.playerStatsWrite(scoreItem); ok {
+		writes[writePlayerStats] = stats
+	}
+	if claim, ok := s.nameClaimWrite(sub.PlayerName, scoreItem.Timestamp); ok {
+		writes[writeNameClaim] = claim
+	}
+
+	result := scoreResult{PersonalBest: true}
+	collisions := 0
+	for {
+		kinds, items := orderedWrites(writes)
+		err := s.transact(ctx, items)
+		if err == nil {
+			break
+		}
+
+		failed, ok := conditionsFailed(err, kinds)
+		if !ok {
+			return scoreResult{}, fmt.Errorf("failed to save score: %w", err)
+		}
+
+		existing, notBest := failed[writeLeaderboard]
+		if notBest {
+			// 自己ベストではないため、リーダーボード以外を書き込む
+			var previous LeaderboardItem
+			if existing != nil {
+				if err := attributevalue.UnmarshalMap(existing, &previous); err != nil {
+					return scoreResult{}, fmt.Errorf("failed to unmarshal leaderboard item: %w", err)
+				}
+			}
+			result = scoreResult{PersonalBest: false, PreviousBest: previous.Score}
+			delete(writes, writeLeaderboard)
+		}
+
+		if _, taken := failed[writeNameClaim]; taken {
+			// 名前の登録を始める前からリーダーボードにいるプレイヤーは、紛らわしい名前の登録があっても引き続き投稿できる
+			registered := notBest
+			if !registered {
+				if registered, err = s.hasLeaderboardEntry(ctx, sub.PlayerName); err != nil {
+					return scoreResult{}, err
+				}
+			}
+			if !registered {
+				return scoreResult{}, &names.Violation{Code: names.CodeTaken}
+			}
+			loggerFrom(ctx).Info("Player name predates name claims; skipping claim", "player_name", sub.PlayerName)
+			delete(writes, writeNameClaim)
+		}
+
+		if _, taken := failed[writeScore]; taken {
+			// 同じプレイヤーの同じ秒のスコアがある（キーが同じ）。上書きすると累計と合わなくなるため、次の秒のスコアとして保存し直す
+			if collisions++; collisions >= scoreKeyAttempts {
+				return scoreResult{}, fmt.Errorf("failed to save score: %d scores of the player already exist around %d", collisions, scoreItem.Timestamp)
+			}
+			scoreItem.Timestamp++
+			if err := s.rekeyScoreWrites(writes, scoreItem); err != nil {
+				return scoreResult{}, err
+			}
+		}
+	}
+
+	s.updateLeaderboardViews(ctx, scoreItem)
+	return result, nil
+}
+
+// personalBestWrite は自己ベストのときだけ PrimaryView（全体・モード別・単語リスト別ビュー）を書き換える書き込みを返す
+func (s *dynamoStore) personalBestWrite(item ScoreItem) (types.TransactWriteItem, error) {
+	view := leaderboard.PrimaryView(item.Category, item.Mode, item.Duration)
+	if view == leaderboard.Global {
+		av, err := attributevalue.MarshalMap(LeaderboardItem{
+			PlayerName: item.PlayerName,
+			Score:      item.Score,
+			Round:      item.Round,
+			Category:   item.Category,
+			Rank:       0, // Will be calculated when fetching
+			Timestamp:  item.Timestamp,
+		})
+		if err != nil {
+			return types.TransactWriteItem{}, fmt.Errorf("failed to marshal leaderboard item: %w", err)
+		}
+		return types.TransactWriteItem{Put: &types.Put{
+			TableName:           aws.String(s.tables.Leaderboard),
+			Item:                av,
+			ConditionExpression: aws.String(leaderboardCondition),
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":score": &types.AttributeValueMemberN{Value: strconv.Itoa(item.Score)},
+			},
+			ReturnValuesOnConditionCheckFailure: types.ReturnValuesOnConditionCheckFailureAllOld,
+		}}, nil
+	}
+
+	if s.tables.LeaderboardViews == "" {
+		return types.TransactWriteItem{}, fmt.Errorf("leaderboard views table is not configured (LEADERBOARD_VIEWS_TABLE_NAME)")
+	}
+	av, err := attributevalue.MarshalMap(LeaderboardViewItem{
+		View:       view,
+		PlayerName: item.PlayerName,
+		Score:      item.Score,
+		Round:      item.Round,
+		Category:   item.Category,
+		Timestamp:  item.Timestamp,
+		WPM:        item.WPM,
+		Accuracy:   item.Accuracy,
+		ElapsedMs:  item.ElapsedMs,
+	})
+	if err != nil {
+		return types.TransactWriteItem{}, fmt.Errorf("failed to marshal leaderboard view item: %w", err)
+	}
+	put := &types.Put{
+		TableName:           aws.String(s.tables.LeaderboardViews),
+		Item:                av,
+		ConditionExpression: aws.String(leaderboardCondition),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":score": &types.AttributeValueMemberN{Value: strconv.Itoa(item.Score)},
+		},
+		ReturnValuesOnConditionCheckFailure: types.ReturnValuesOnConditionCheckFailureAllOld,
+	}
+	switch leaderboard.OrderOf(view) {
+	case leaderboard.ByRound:
+		put.ConditionExpression = aws.String(roundLeaderboardCondition)
+		put.ExpressionAttributeNames = map[string]string{"#round": "round"}
+		put.ExpressionAttributeValues[":round"] = &types.AttributeValueMemberN{Value: strconv.Itoa(item.Round)}
+	case leaderboard.ByTime:
+		put.ConditionExpression = aws.String(timeLeaderboardCondition)
+		put.ExpressionAttributeValues[":elapsed_ms"] = &types.AttributeValueMemberN{Value: strconv.Itoa(item.ElapsedMs)}
+	}
+	return types.TransactWriteItem{Put: put}, nil
+}
+
+// scoreKeyAttempts は同じプレイヤーの同じ秒のスコアがある場合に、時刻をずらして保存し直す回数の上限
+const scoreKeyAttempts = 30
+
+// scorePut はスコアを保存する書き込み。同じキー（プレイヤー名・時刻）のスコアがある場合は条件を満たさない
+func (s *dynamoStore) scorePut(item map[string]types.AttributeValue) types.TransactWriteItem {
+	return types.TransactWriteItem{Put: &types.Put{
+		TableName:                aws.String(s.tables.Scores),
+		Item:                     item,
+		ConditionExpression:      aws.String("attribute_not_exists(#timestamp)"),
+		ExpressionAttributeNames: map[string]string{"#timestamp": "timestamp"},
+	}}
+}
+
+// rekeyScoreWrites は時刻を変えたスコアで、残っている書き込み（時刻を含む）を作り直す
+func (s *dynamoStore) rekeyScoreWrites(writes map[scoreWrite]types.TransactWriteItem, item ScoreItem) error {
+	av, err := attributevalue.MarshalMap(item)
+	if err != nil {
+		return fmt.Errorf("failed to marshal score item: %w", err)
+	}
+	writes[writeScore] = s.scorePut(av)
+	if _, ok := writes[writeLeaderboard]; ok {
+		best, err := s.personalBestWrite(item)
+		if err != nil {
+			return err
+		}
+		writes[writeLeaderboard] = best
+	}
+	if _, ok := writes[writePlayerStats]; ok {
+		writes[writePlayerStats], _ = s.playerStatsWrite(item)
+	}
+	if _, ok := writes[writeNameClaim]; ok {
+		writes[writeNameClaim], _ = s.nameClaimWrite(item.PlayerName, item.Timestamp)
+	}
+	return nil
+}
+
+// orderedWrites はトランザクションに渡す順序に並べた書き込みと、その種類を返す
+func orderedWrites(writes map[scoreWrite]types.TransactWriteItem) ([]scoreWrite, []types.TransactWriteItem) {
+	var kinds []scoreWrite
+	var items []types.TransactWriteItem
+	for _, kind := range []scoreWrite{writeScore, writeLeaderboard, writePlayerStats, writeNameClaim} {
+		if item, ok := writes[kind]; ok {
+			kinds = append(kinds, kind)
+			items = append(items, item)
+		}
+	}
+	return kinds, items
+}
+
+func (s *dynamoStore) transact(ctx context.Context, writes []types.TransactWriteItem) error {
+	return s.write(ctx, s.tables.Scores, "TransactWriteItems", func(ctx context.Context) error {
+		_, err := s.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+			TransactItems: writes,
+		})
+		return err
+	})
+}
+
+// playerStatsWrite はプレイヤー累計にスコアを加算する書き込みを返す（テーブル未設定の場合は false）
+func (s *dynamoStore) playerStatsWrite(item ScoreItem) (types.TransactWriteItem, bool) {
+	if s.tables.PlayerStats == "" {
+		return types.TransactWriteItem{}, false
+	}
+
+	return types.TransactWriteItem{Update: &types.Update{
+		TableName: aws.String(s.tables.PlayerStats),
+		Key: map[string]types.AttributeValue{
+			"player_name": &types.AttributeValueMemberS{Value: item.PlayerName},
+		},
+		UpdateExpression: aws.String("ADD games_played :one, total_score :score, total_time :time " +
+			"SET first_played = if_not_exists(first_played, :ts), last_played = :ts"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":one":   &types.AttributeValueMemberN{Value: "1"},
+			":score": &types.AttributeValueMemberN{Value: strconv.Itoa(item.Score)},
+			":time":  &types.AttributeValueMemberN{Value: strconv.Itoa(item.Time)},
+			":ts":    &types.AttributeValueMemberN{Value: strconv.FormatInt(item.Timestamp, 10)},
+		},
+	}}, true
+}
+
+// nameClaimWrite は名前の Skeleton をプレイヤーの名前として登録する書き込みを返す（テーブル未設定の場合は false）
+func (s *dynamoStore) nameClaimWrite(playerName string, timestamp int64) (types.TransactWriteItem, bool) {
+	if s.tables.PlayerNames == "" {
+		return types.TransactWriteItem{}, false
+	}
+
+	return types.TransactWriteItem{Update: &types.Update{
+		TableName: aws.String(s.tables.PlayerNames),
+		Key: map[string]types.AttributeValue{
+			"name_key": &types.AttributeValueMemberS{Value: names.Skeleton(playerName)},
+		},
+		UpdateExpression:    aws.String("SET player_name = :name, claimed_at = if_not_exists(claimed_at, :ts), last_used = :ts"),
+		ConditionExpression: aws.String(nameClaimCondition),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":name": &types.AttributeValueMemberS{Value: playerName},
+			":ts":   &types.AttributeValueMemberN{Value: strconv.FormatInt(timestamp, 10)},
+		},
+	}}, true
+}
+
+// hasLeaderboardEntry はプレイヤーがリーダーボードに記録を持っているかを返す
+func (s *dynamoStore) hasLeaderboardEntry(ctx context.Context, playerName string) (bool, error) {
+	var result *dynamodb.GetItemOutput
+	err := s.read(ctx, s.tables.Leaderboard, "GetItem", func(ctx context.Context) error {
+		var err error
+		result, err = s.client.GetItem(ctx, &dynamodb.GetItemInput{
+			TableName: aws.String(s.tables.Leaderboard),
+			Key: map[string]types.AttributeValue{
+				"player_name": &types.AttributeValueMemberS{Value: playerName},
+			},
+			ConsistentRead:       aws.Bool(true),
+			ProjectionExpression: aws.String("player_name"),
+		})
+		return err
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to get leaderboard entry: %w", err)
+	}
+	return result.Item != nil, nil
+}
+
+// conditionsFailed はトランザクションが条件付きの書き込み（スコアのキー・リーダーボード・名前の登録）の条件だけでキャンセルされたかを判定し、
+// その場合は条件を満たさなかった書き込みの種類と既存の項目（取得できた場合）を返す
+func conditionsFailed(err error, kinds []scoreWrite) (map[scoreWrite]map[string]types.AttributeValue, bool) {
+	var canceled *types.TransactionCanceledException
+	if !errors.As(err, &canceled) || len(canceled.CancellationReasons) != len(kinds) {
+		return nil, false
+	}
+
+	failed := make(map[scoreWrite]map[string]types.AttributeValue)
+	for i, reason := range canceled.CancellationReasons {
+		switch code := aws.ToString(reason.Code); {
+		case code == "None":
+		case code == "ConditionalCheckFailed" && kinds[i] != writePlayerStats:
+			failed[kinds[i]] = reason.Item
+		default:
+			return nil, false
+		}
+	}
+	return failed, len(failed) > 0
+}
+
+// updateLeaderboardViews はカテゴリーの単語での通常のモードのスコアで、カテゴリー別・期間別のビューを既存の記録より高い場合だけ条件付きで更新する。
+// ビューはスコアとは別に書き込むため、失敗してもスコアの登録は成功として扱い、
+// ずれは cmd/rebuild-leaderboard で修復する（モード別ビューは recordScore のトランザクションで更新する）
+func (s *dynamoStore) updateLeaderboardViews(ctx context.Context, item ScoreItem) {
+	if s.tables.LeaderboardViews == "" || leaderboard.PrimaryView(item.Category, item.Mode, item.Duration) != leaderboard.Global {
+		return
+	}
+
+	for _, view := range leaderboard.ViewsFor(item.Category, time.Unix(item.Timestamp, 0)) {
+		av, err := attributevalue.MarshalMap(LeaderboardViewItem{
+			View:       view,
+			PlayerName: item.PlayerName,
+			Score:      item.Score,
+			Round:      item.Round,
+			Category:   item.Category,
+			Timestamp:  item.Timestamp,
+		})
+		if err != nil {
+			loggerFrom(ctx).Warn("Failed to marshal leaderboard view item", "view", view, "error", err)
+			continue
+		}
+
+		err = s.write(ctx, s.tables.LeaderboardViews, "PutItem", func(ctx context.Context) error {
+			_, err := s.client.PutItem(ctx, &dynamodb.PutItemInput{
+				TableName:           aws.String(s.tables.LeaderboardViews),
+				Item:                av,
+				ConditionExpression: aws.String(leaderboardCondition),
+				ExpressionAttributeValues: map[string]types.AttributeValue{
+					":score": &types.AttributeValueMemberN{Value: strconv.Itoa(item.Score)},
+				},
+			})
+			return err
+		})
+
+		var notBest *types.ConditionalCheckFailedException
+		if err != nil && !errors.As(err, &notBest) {
+			loggerFrom(ctx).Warn("Failed to update leaderboard view", "view", view, "player_name", item.PlayerName, "error", err)
+		}
+	}
+}
+
+func (s *dynamoStore) fetchLeaderboard(ctx context.Context, limit int) ([]LeaderboardItem, error) {
+	if s.tables.Leaderboard == "" {
+		return nil, fmt.Errorf("leaderboard table is not configured (LEADERBOARD_TABLE_NAME)")
+	}
+
+	// Scan は1MBごとにページが分かれるため、LastEvaluatedKey を辿って全件を取得する
+	var items []LeaderboardItem
+	paginator := dynamodb.NewScanPaginator(s.client, &dynamodb.ScanInput{
+		TableName: aws.String(s.tables.Leaderboard),
+	})
+	for paginator.HasMorePages() {
+		var page *dynamodb.ScanOutput
+		err := s.read(ctx, s.tables.Leaderboard, "Scan", func(ctx context.Context) error {
+			var err error
+			page, err = paginator.NextPage(ctx)
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan leaderboard table: %w", err)
+		}
+
+		var pageItems []LeaderboardItem
+		if err := attributevalue.UnmarshalListOfMaps(page.Items, &pageItems); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal leaderboard items: %w", err)
+		}
+		items = append(items, pageItems...)
+	}
+
+	// Sort by score (descending) and assign ranks
+	for i := 0; i < len(items); i++ {
+		for j := i + 1; j < len(items); j++ {
+			if items[j].Score > items[i].Score {
+				items[i], items[j] = items[j], items[i]
+			}
+		}
+	}
+
+	// Assign ranks
+	for i := range items {
+		items[i].Rank = i + 1
+	}
+
+	// Return top N
+	if len(items) > limit {
+		items = items[:limit]
+	}
+
+	return items, nil
+}
+
+// fetchLeaderboardView はカテゴリー別・期間別・モード別ビューの上位を ViewScoreIndex からスコア順に取得する。
+// ラウンド数で順位を決めるビュー（エンドレスモード）はビュー全体を読み込んで並べ替える
+func (s *dynamoStore) fetchLeaderboardView(ctx context.Context, view string, limit int) ([]LeaderboardItem, error) {
+	if s.tables.LeaderboardViews == "" {
+		return nil, fmt.Errorf("leaderboard views table is not configured (LEADERBOARD_VIEWS_TABLE_NAME)")
+	}
+
+	// 1ページが limit 件に満たない場合（1MBを超えた場合）は次のページを取得する
+	var viewItems []LeaderboardViewItem
+	input := &dynamodb.QueryInput{
+		TableName:              aws.String(s.tables.LeaderboardViews),
+		IndexName:              aws.String(leaderboard.ViewScoreIndex),
+		KeyConditionExpression: aws.String("#view = :view"),
+		ExpressionAttributeNames: map[string]string{
+			"#view": "view",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":view": &types.AttributeValueMemberS{Value: view},
+		},
+		ScanIndexForward: aws.Bool(false),
+		Limit:            aws.Int32(int32(limit)),
+	}
+	// スコア以外で順位を決めるビューは LSI の順序を使えないため、ビュー全体を取得して並べ替える
+	ranked := leaderboard.OrderOf(view) != leaderboard.ByScore
+	if ranked {
+		input.IndexName, input.ScanIndexForward, input.Limit = nil, nil, nil
+	}
+	paginator := dynamodb.NewQueryPaginator(s.client, input)
+	for paginator.HasMorePages() && (ranked || len(viewItems) < limit) {
+		var page *dynamodb.QueryOutput
+		err := s.read(ctx, s.tables.LeaderboardViews, "Query", func(ctx context.Context) error {
+			var err error
+			page, err = paginator.NextPage(ctx)
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to query leaderboard view %s: %w", view, err)
+		}
+
+		var pageItems []LeaderboardViewItem
+		if err := attributevalue.UnmarshalListOfMaps(page.Items, &pageItems); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal leaderboard view items: %w", err)
+		}
+		viewItems = append(viewItems, pageItems...)
+	}
+	if ranked {
+		sort.SliceStable(viewItems, func(i, j int) bool {
+			return leaderboard.Less(view, viewItems[i].entry(), viewItems[j].entry())
+		})
+	}
+	if len(viewItems) > limit {
+		viewItems = viewItems[:limit]
+	}
+
+	items := make([]LeaderboardItem, len(viewItems))
+	for i, v := range viewItems {
+		items[i] = LeaderboardItem{
+			PlayerName: v.PlayerName,
+			Score:      v.Score,
+			Round:      v.Round,
+			Category:   v.Category,
+			Rank:       i + 1,
+			WPM:        v.WPM,
+			Accuracy:   v.Accuracy,
+			ElapsedMs:  v.ElapsedMs,
+		}
+	}
+	return items, nil
+}
+
+func (s *dynamoStore) fetchWords(ctx context.Context, category string, round int, language string) ([]WordItem, error) {
+	if s.tables.Words == "" {
+		slog.Debug("WORDS_TABLE_NAME not set; using local fallback words", "category", category, "round", round, "language", language)
+		return fallbackWords(category, round, language), nil
+	}
+
+	// LookupIndex（category#language#round）で単語を取得
+	words, err := s.queryWords(ctx, &dynamodb.QueryInput{
+		TableName:              aws.String(s.tables.Words),
+		IndexName:              aws.String(wordsLookupIndex),
+		KeyConditionExpression: aws.String("lookup_key = :lookup_key"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":lookup_key": &types.AttributeValueMemberS{Value: wordLookupKey(category, language, round)},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(words) == 0 {
+		// lookup_key が未設定の旧スキーマのアイテムに対応するため、カテゴリーでのクエリにフォールバック
+		// （scripts/migrate-word-lookup-keys.go でバックフィル後は不要）
+		slog.Warn("No words found in lookup index; falling back to category query", "index", wordsLookupIndex, "category", category, "round", round, "language", language)
+		words, err = s.queryWords(ctx, &dynamodb.QueryInput{
+			TableName:              aws.String(s.tables.Words),
+			KeyConditionExpression: aws.String("category = :category"),
+			FilterExpression:       aws.String("#round = :round AND #language = :language"),
+			ExpressionAttributeNames: map[string]string{
+				"#round":    "round",
+				"#language": "language",
+			},
+			ExpressionAttributeValues: map[string]types.AttributeValue{
+				":category": &types.AttributeValueMemberS{Value: category},
+				":round":    &types.AttributeValueMemberN{Value: fmt.Sprintf("%d", round)},
+				":language": &types.AttributeValueMemberS{Value: language},
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	slog.Debug("Fetched words from DynamoDB", "count", len(words), "category", category, "round", round, "language", language)
+	return words, nil
+}
+
+// queryWords は LastEvaluatedKey を辿って全ページ分の単語を取得する
+func (s *dynamoStore) queryWords(ctx context.Context, input *dynamodb.QueryInput) ([]WordItem, error) {
+	var words []WordItem
+	paginator := dynamodb.NewQueryPaginator(s.client, input)
+	for paginator.HasMorePages() {
+		var page *dynamodb.QueryOutput
+		err := s.read(ctx, s.tables.Words, "Query", func(ctx context.Context) error {
+			var err error
+			page, err = paginator.NextPage(ctx)
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to query words table: %w", err)
+		}
+
+		var pageWords []WordItem
+		if err := attributevalue.UnmarshalListOfMaps(page.Items, &pageWords); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal words: %w", err)
+		}
+		for i := range pageWords {
+			pageWords[i].fillDisplay()
+		}
+		words = append(words, pageWords...)
+	}
+
+	return words, nil
+}
+
+func (s *dynamoStore) fetchTranslation(ctx context.Context, wordID, targetLanguage string) (string, error) {
+	if s.tables.Translations == "" {
+		return "", fmt.Errorf("%w for word_id: %s (TRANSLATIONS_TABLE_NAME is not set)", errTranslationNotFound, wordID)
+	}
+
+	// DynamoDBから翻訳を取得
+	var result *dynamodb.GetItemOutput
+	err := s.read(ctx, s.tables.Translations, "GetItem", func(ctx context.Context) error {
+		var err error
+		result, err = s.client.GetItem(ctx, &dynamodb.GetItemInput{
+			TableName: aws.String(s.tables.Translations),
+			Key: map[string]types.AttributeValue{
+				"word_id": &types.AttributeValueMemberS{
+					Value: wordID,
+				},
+				"language": &types.AttributeValueMemberS{
+					Value: targetLanguage,
+				},
+			},
+		})
+		return err
+	})
+
+	if err != nil {
+		return "", fmt.Errorf("failed to get translation from DynamoDB: %w", err)
+	}
+
+	if result.Item == nil {
+		return "", fmt.Errorf("%w for word_id: %s, language: %s", errTranslationNotFound, wordID, targetLanguage)
+	}
+
+	var translation TranslationItem
+	err = attributevalue.UnmarshalMap(result.Item, &translation)
+	if err != nil {
+		return "", fmt.Errorf("failed to unmarshal translation: %w", err)
+	}
+
+	return translation.Translation, nil
+}
+
+// fetchPassages は言語の文章をすべて返す（LanguageIndex）。テーブルが未設定の場合は組み込みの文章を返す
+func (s *dynamoStore) fetchPassages(ctx context.Context, language string) ([]passage.Passage, error) {
+	if s.tables.Passages == "" {
+		return fallbackPassages(language), nil
+	}
+
+	var passages []passage.Passage
+	paginator := dynamodb.NewQueryPaginator(s.client, &dynamodb.QueryInput{
+		TableName:              aws.String(s.tables.Passages),
+		IndexName:              aws.String(passage.LanguageIndex),
+		KeyConditionExpression: aws.String("#language = :language"),
+		ExpressionAttributeNames: map[string]string{
+			"#language": "language",
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":language": &types.AttributeValueMemberS{Value: language},
+		},
+	})
+	for paginator.HasMorePages() {
+		var page *dynamodb.QueryOutput
+		err := s.read(ctx, s.tables.Passages, "Query", func(ctx context.Context) error {
+			var err error
+			page, err = paginator.NextPage(ctx)
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to query passages: %w", err)
+		}
+
+		var pagePassages []passage.Passage
+		if err := attributevalue.UnmarshalListOfMaps(page.Items, &pagePassages); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal passages: %w", err)
+		}
+		passages = append(passages, pagePassages...)
+	}
+	return passages, nil
+}
+
+// fetchPassage は文章を ID で返す。登録されていない場合は errPassageNotFound を返す
+func (s *dynamoStore) fetchPassage(ctx context.Context, passageID string) (*passage.Passage, error) {
+	if s.tables.Passages == "" {
+		if p, ok := fallbackPassage(passageID); ok {
+			return p, nil
+		}
+		return nil, fmt.Errorf("%w: %s", errPassageNotFound, passageID)
+	}
+
+	var result *dynamodb.GetItemOutput
+	err := s.read(ctx, s.tables.Passages, "GetItem", func(ctx context.Context) error {
+		var err error
+		result, err = s.client.GetItem(ctx, &dynamodb.GetItemInput{
+			TableName: aws.String(s.tables.Passages),
+			Key: map[string]types.AttributeValue{
+				"passage_id": &types.AttributeValueMemberS{Value: passageID},
+			},
+		})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get passage: %w", err)
+	}
+	if result.Item == nil {
+		return nil, fmt.Errorf("%w: %s", errPassageNotFound, passageID)
+	}
+
+	var p passage.Passage
+	if err := attributevalue.UnmarshalMap(result.Item, &p); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal passage: %w", err)
+	}
+	return &p, nil
+}
+
+// shareCodeAttempts は共有コードが既存のリストと重複したときに作り直す回数の上限
+const shareCodeAttempts = 5
+
+// createWordList は新しい共有コードで単語リストを保存する。共有コードが既存のリストと重複した場合は作り直す
+func (s *dynamoStore) createWordList(ctx context.Context, spec wordlist.Spec) (*wordlist.List, error) {
+	if s.tables.WordLists == "" {
+		return nil, fmt.Errorf("word lists table is not configured (WORD_LISTS_TABLE_NAME)")
+	}
+
+	for attempt := 1; attempt <= shareCodeAttempts; attempt++ {
+		code, err := wordlist.NewShareCode()
+		if err != nil {
+			return nil, err
+		}
+		l := wordlist.New(code, spec, time.Now())
+		av, err := attributevalue.MarshalMap(l)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal word list: %w", err)
+		}
+
+		err = s.write(ctx, s.tables.WordLists, "PutItem", func(ctx context.Context) error {
+			_, err := s.client.PutItem(ctx, &dynamodb.PutItemInput{
+				TableName:           aws.String(s.tables.WordLists),
+				Item:                av,
+				ConditionExpression: aws.String("attribute_not_exists(list_id)"),
+			})
+			return err
+		})
+		var taken *types.ConditionalCheckFailedException
+		if errors.As(err, &taken) {
+			loggerFrom(ctx).Warn("Share code already in use; generating another", "attempt", attempt)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to put word list: %w", err)
+		}
+		return l, nil
+	}
+	return nil, fmt.Errorf("failed to generate an unused share code after %d attempts", shareCodeAttempts)
+}
+
+// fetchWordList は単語リストを ID で返す。作成されていない場合は errWordListNotFound を返す
+func (s *dynamoStore) fetchWordList(ctx context.Context, listID string) (*wordlist.List, error) {
+	if s.tables.WordLists == "" {
+		return nil, fmt.Errorf("word lists table is not configured (WORD_LISTS_TABLE_NAME)")
+	}
+
+	var result *dynamodb.GetItemOutput
+	err := s.read(ctx, s.tables.WordLists, "GetItem", func(ctx context.Context) error {
+		var err error
+		result, err = s.client.GetItem(ctx, &dynamodb.GetItemInput{
+			TableName: aws.String(s.tables.WordLists),
+			Key: map[string]types.AttributeValue{
+				"list_id": &types.AttributeValueMemberS{Value: listID},
+			},
+		})
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get word list: %w", err)
+	}
+	if result.Item == nil {
+		return nil, fmt.Errorf("%w: %s", errWordListNotFound, listID)
+	}
+
+	var l wordlist.List
+	if err := attributevalue.UnmarshalMap(result.Item, &l); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal word list: %w", err)
+	}
+	return &l, nil
+}
@@ -0,0 +1,856 @@
+package dynamotest
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// 式（ConditionExpression・KeyConditionExpression・FilterExpression・UpdateExpression・ProjectionExpression）の
+// 字句解析・構文解析と評価。バックエンドとコマンドが使う構文をひととおり扱う
+
+type lexeme struct {
+	kind string // ident, name (#x), value (:x), number, symbol, eof
+	text string
+}
+
+func lex(expr string) ([]lexeme, error) {
+	var lexemes []lexeme
+	rs := []rune(expr)
+	for i := 0; i < len(rs); {
+		r := rs[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '#' || r == ':' || isIdentRune(r):
+			start := i
+			i++
+			for i < len(rs) && isIdentRune(rs[i]) {
+				i++
+			}
+			kind := "ident"
+			switch {
+			case r == '#':
+				kind = "name"
+			case r == ':':
+				kind = "value"
+			case unicode.IsDigit(r):
+				kind = "number"
+			}
+			lexemes = append(lexemes, lexeme{kind: kind, text: string(rs[start:i])})
+		default:
+			if i+1 < len(rs) {
+				if two := string(rs[i : i+2]); two == "<>" || two == "<=" || two == ">=" {
+					lexemes = append(lexemes, lexeme{kind: "symbol", text: two})
+					i += 2
+					continue
+				}
+			}
+			if !strings.ContainsRune("()[],.=<>+-", r) {
+				return nil, fmt.Errorf("invalid character %q in expression", r)
+			}
+			lexemes = append(lexemes, lexeme{kind: "symbol", text: string(r)})
+			i++
+		}
+	}
+	return append(lexemes, lexeme{kind: "eof"}), nil
+}
+
+func isIdentRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// pathElem はドキュメントパス（a.b[0]）の要素
+type pathElem struct {
+	name  string
+	index int
+	isIdx bool
+}
+
+type path []pathElem
+
+func (p path) String() string {
+	var b strings.Builder
+	for i, e := range p {
+		switch {
+		case e.isIdx:
+			fmt.Fprintf(&b, "[%d]", e.index)
+		case i > 0:
+			b.WriteString("." + e.name)
+		default:
+			b.WriteString(e.name)
+		}
+	}
+	return b.String()
+}
+
+func (p path) get(it item) *value {
+	if len(p) == 0 || p[0].isIdx {
+		return nil
+	}
+	v := it[p[0].name]
+	for _, e := range p[1:] {
+		switch {
+		case v == nil:
+			return nil
+		case e.isIdx:
+			if v.kind != "L" || e.index >= len(v.l) {
+				return nil
+			}
+			v = v.l[e.index]
+		default:
+			if v.kind != "M" {
+				return nil
+			}
+			v = v.m[e.name]
+		}
+	}
+	return v
+}
+
+// parent は最後の要素を除いたパスの値（マップ・リスト）を返す
+func (p path) parent(it item) (*value, error) {
+	if len(p) == 1 {
+		return nil, nil
+	}
+	v := p[:len(p)-1].get(it)
+	if v == nil || (v.kind != "M" && v.kind != "L") {
+		return nil, fmt.Errorf("the document path provided in the update expression is invalid for update: %s", p)
+	}
+	return v, nil
+}
+
+func (p path) set(it item, v *value) error {
+	parent, err := p.parent(it)
+	if err != nil {
+		return err
+	}
+	last := p[len(p)-1]
+	switch {
+	case parent == nil:
+		it[last.name] = v
+	case last.isIdx && parent.kind == "L":
+		if last.index >= len(parent.l) {
+			parent.l = append(parent.l, v)
+		} else {
+			parent.l[last.index] = v
+		}
+	case !last.isIdx && parent.kind == "M":
+		if parent.m == nil {
+			parent.m = make(map[string]*value)
+		}
+		parent.m[last.name] = v
+	default:
+		return fmt.Errorf("the document path provided in the update expression is invalid for update: %s", p)
+	}
+	return nil
+}
+
+func (p path) remove(it item) error {
+	parent, err := p.parent(it)
+	if err != nil {
+		return nil
+	}
+	last := p[len(p)-1]
+	switch {
+	case parent == nil:
+		delete(it, last.name)
+	case last.isIdx && parent.kind == "L" && last.index < len(parent.l):
+		parent.l = slices.Delete(parent.l, last.index, last.index+1)
+	case !last.isIdx && parent.kind == "M":
+		delete(parent.m, last.name)
+	}
+	return nil
+}
+
+// operand は比較・代入の値
+type operand func(it item) (*value, error)
+
+// condition は条件式
+type condition func(it item) (bool, error)
+
+// parser は名前（#x）と値（:x）の置換を持つ構文解析器
+type parser struct {
+	lexemes []lexeme
+	pos     int
+	names   map[string]string
+	values  map[string]*value
+	used    map[string]bool // 使われた #x・:x（未使用のものは ValidationException）
+}
+
+func newParser(expr string, names map[string]string, values map[string]*value) (*parser, error) {
+	lexemes, err := lex(expr)
+	if err != nil {
+		return nil, err
+	}
+	return &parser{lexemes: lexemes, names: names, values: values, used: make(map[string]bool)}, nil
+}
+
+func (p *parser) peek() lexeme { return p.lexemes[p.pos] }
+
+func (p *parser) next() lexeme {
+	t := p.lexemes[p.pos]
+	if t.kind != "eof" {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) keyword(words ...string) bool {
+	t := p.peek()
+	return t.kind == "ident" && slices.Contains(words, strings.ToUpper(t.text))
+}
+
+func (p *parser) symbol(s string) bool {
+	t := p.peek()
+	return t.kind == "symbol" && t.text == s
+}
+
+func (p *parser) expect(s string) error {
+	if !p.symbol(s) {
+		return fmt.Errorf("syntax error: expected %q, got %q", s, p.peek().text)
+	}
+	p.next()
+	return nil
+}
+
+func (p *parser) done() error {
+	if t := p.peek(); t.kind != "eof" {
+		return fmt.Errorf("syntax error: unexpected token %q", t.text)
+	}
+	return nil
+}
+
+func (p *parser) parsePath() (path, error) {
+	var out path
+	name, err := p.pathName()
+	if err != nil {
+		return nil, err
+	}
+	out = append(out, pathElem{name: name})
+	for {
+		switch {
+		case p.symbol("."):
+			p.next()
+			name, err := p.pathName()
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, pathElem{name: name})
+		case p.symbol("["):
+			p.next()
+			t := p.next()
+			index, err := strconv.Atoi(t.text)
+			if t.kind != "number" || err != nil {
+				return nil, fmt.Errorf("syntax error: invalid list index %q", t.text)
+			}
+			if err := p.expect("]"); err != nil {
+				return nil, err
+			}
+			out = append(out, pathElem{index: index, isIdx: true})
+		default:
+			return out, nil
+		}
+	}
+}
+
+func (p *parser) pathName() (string, error) {
+	t := p.next()
+	switch t.kind {
+	case "ident":
+		return t.text, nil
+	case "name":
+		name, ok := p.names[t.text]
+		if !ok {
+			return "", fmt.Errorf("an expression attribute name used in the document path is not defined; attribute name: %s", t.text)
+		}
+		p.used[t.text] = true
+		return name, nil
+	default:
+		return "", fmt.Errorf("syntax error: expected an attribute name, got %q", t.text)
+	}
+}
+
+func (p *parser) valueRef() (*value, error) {
+	t := p.next()
+	if t.kind != "value" {
+		return nil, fmt.Errorf("syntax error: expected an expression attribute value, got %q", t.text)
+	}
+	v, ok := p.values[t.text]
+	if !ok {
+		return nil, fmt.Errorf("an expression attribute value used in expression is not defined; attribute value: %s", t.text)
+	}
+	p.used[t.text] = true
+	return v, nil
+}
+
+// parseOperand は値・パス・関数（size・if_not_exists・list_append）を読む。update は更新式の中かどうか
+func (p *parser) parseOperand(update bool) (operand, error) {
+	if p.peek().kind == "value" {
+		v, err := p.valueRef()
+		if err != nil {
+			return nil, err
+		}
+		return func(item) (*value, error) { return v, nil }, nil
+	}
+
+	if t := p.peek(); t.kind == "ident" && p.lexemes[p.pos+1].text == "(" {
+		name := t.text
+		p.next()
+		p.next()
+		switch {
+		case name == "size" && !update:
+			target, err := p.parsePath()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return func(it item) (*value, error) {
+				v := target.get(it)
+				if v == nil {
+					return nil, nil
+				}
+				var n int
+				switch v.kind {
+				case "S", "B":
+					n = len(v.s)
+				case "M":
+					n = len(v.m)
+				case "L":
+					n = len(v.l)
+				case "SS", "NS", "BS":
+					n = len(v.set)
+				default:
+					return nil, fmt.Errorf("invalid operand type for size: %s", v.kind)
+				}
+				return &value{kind: "N", s: strconv.Itoa(n)}, nil
+			}, nil
+		case name == "if_not_exists" && update:
+			target, err := p.parsePath()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(","); err != nil {
+				return nil, err
+			}
+			fallback, err := p.parseOperand(update)
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return func(it item) (*value, error) {
+				if v := target.get(it); v != nil {
+					return v, nil
+				}
+				return fallback(it)
+			}, nil
+		case name == "list_append" && update:
+			a, err := p.parseOperand(update)
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(","); err != nil {
+				return nil, err
+			}
+			b, err := p.parseOperand(update)
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return func(it item) (*value, error) {
+				x, err := a(it)
+				if err != nil {
+					return nil, err
+				}
+				y, err := b(it)
+				if err != nil {
+					return nil, err
+				}
+				if x == nil || y == nil || x.kind != "L" || y.kind != "L" {
+					return nil, fmt.Errorf("incorrect operand type for operator or function; operator or function: list_append")
+				}
+				return &value{kind: "L", l: append(slices.Clone(x.l), y.l...)}, nil
+			}, nil
+		default:
+			return nil, fmt.Errorf("invalid function name; function: %s", name)
+		}
+	}
+
+	target, err := p.parsePath()
+	if err != nil {
+		return nil, err
+	}
+	return func(it item) (*value, error) { return target.get(it), nil }, nil
+}
+
+// parseCondition は条件式を読む（OR < AND < NOT の順に結合が強い）
+func (p *parser) parseCondition() (condition, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("OR") {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(it item) (bool, error) {
+			ok, err := l(it)
+			if err != nil || ok {
+				return ok, err
+			}
+			return right(it)
+		}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (condition, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.keyword("AND") {
+		p.next()
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		l := left
+		left = func(it item) (bool, error) {
+			ok, err := l(it)
+			if err != nil || !ok {
+				return ok, err
+			}
+			return right(it)
+		}
+	}
+	return left, nil
+}
+
+func (p *parser) parseNot() (condition, error) {
+	if p.keyword("NOT") {
+		p.next()
+		inner, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return func(it item) (bool, error) {
+			ok, err := inner(it)
+			return !ok, err
+		}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (condition, error) {
+	if p.symbol("(") {
+		p.next()
+		inner, err := p.parseCondition()
+		if err != nil {
+			return nil, err
+		}
+		return inner, p.expect(")")
+	}
+
+	if t := p.peek(); t.kind == "ident" && p.lexemes[p.pos+1].text == "(" && t.text != "size" {
+		return p.parseFunction()
+	}
+
+	left, err := p.parseOperand(false)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case p.keyword("BETWEEN"):
+		p.next()
+		low, err := p.parseOperand(false)
+		if err != nil {
+			return nil, err
+		}
+		if !p.keyword("AND") {
+			return nil, fmt.Errorf("syntax error: expected AND in BETWEEN")
+		}
+		p.next()
+		high, err := p.parseOperand(false)
+		if err != nil {
+			return nil, err
+		}
+		return func(it item) (bool, error) {
+			vals, err := evalAll(it, left, low, high)
+			if err != nil {
+				return false, err
+			}
+			lo, ok1 := compare(vals[0], vals[1])
+			hi, ok2 := compare(vals[0], vals[2])
+			return ok1 && ok2 && lo >= 0 && hi <= 0, nil
+		}, nil
+
+	case p.keyword("IN"):
+		p.next()
+		if err := p.expect("("); err != nil {
+			return nil, err
+		}
+		var candidates []operand
+		for {
+			c, err := p.parseOperand(false)
+			if err != nil {
+				return nil, err
+			}
+			candidates = append(candidates, c)
+			if !p.symbol(",") {
+				break
+			}
+			p.next()
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+		return func(it item) (bool, error) {
+			v, err := left(it)
+			if err != nil {
+				return false, err
+			}
+			for _, c := range candidates {
+				cv, err := c(it)
+				if err != nil {
+					return false, err
+				}
+				if equal(v, cv) {
+					return true, nil
+				}
+			}
+			return false, nil
+		}, nil
+	}
+
+	op := p.next()
+	if op.kind != "symbol" || !slices.Contains([]string{"=", "<>", "<", "<=", ">", ">="}, op.text) {
+		return nil, fmt.Errorf("syntax error: expected a comparator, got %q", op.text)
+	}
+	right, err := p.parseOperand(false)
+	if err != nil {
+		return nil, err
+	}
+	return func(it item) (bool, error) {
+		vals, err := evalAll(it, left, right)
+		if err != nil {
+			return false, err
+		}
+		a, b := vals[0], vals[1]
+		switch op.text {
+		case "=":
+			return equal(a, b), nil
+		case "<>":
+			return !equal(a, b), nil
+		}
+		c, ok := compare(a, b)
+		if !ok {
+			return false, nil
+		}
+		switch op.text {
+		case "<":
+			return c < 0, nil
+		case "<=":
+			return c <= 0, nil
+		case ">":
+			return c > 0, nil
+		default:
+			return c >= 0, nil
+		}
+	}, nil
+}
+
+func (p *parser) parseFunction() (condition, error) {
+	name := p.next().text
+	p.next() // (
+	target, err := p.parsePath()
+	if err != nil {
+		return nil, err
+	}
+
+	var arg operand
+	switch name {
+	case "attribute_exists", "attribute_not_exists":
+	case "begins_with", "contains", "attribute_type":
+		if err := p.expect(","); err != nil {
+			return nil, err
+		}
+		if arg, err = p.parseOperand(false); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("invalid function name; function: %s", name)
+	}
+	if err := p.expect(")"); err != nil {
+		return nil, err
+	}
+
+	return func(it item) (bool, error) {
+		v := target.get(it)
+		var a *value
+		if arg != nil {
+			var err error
+			if a, err = arg(it); err != nil {
+				return false, err
+			}
+		}
+		switch name {
+		case "attribute_exists":
+			return v != nil, nil
+		case "attribute_not_exists":
+			return v == nil, nil
+		case "attribute_type":
+			return v != nil && a != nil && v.kind == a.s, nil
+		case "begins_with":
+			return v != nil && a != nil && v.kind == a.kind && (v.kind == "S" || v.kind == "B") && strings.HasPrefix(v.s, a.s), nil
+		default: // contains
+			if v == nil || a == nil {
+				return false, nil
+			}
+			switch v.kind {
+			case "S":
+				return a.kind == "S" && strings.Contains(v.s, a.s), nil
+			case "SS", "NS", "BS":
+				return slices.Contains(v.set, a.s), nil
+			case "L":
+				for _, e := range v.l {
+					if equal(e, a) {
+						return true, nil
+					}
+				}
+			}
+			return false, nil
+		}
+	}, nil
+}
+
+func evalAll(it item, ops ...operand) ([]*value, error) {
+	vals := make([]*value, len(ops))
+	for i, op := range ops {
+		v, err := op(it)
+		if err != nil {
+			return nil, err
+		}
+		vals[i] = v
+	}
+	return vals, nil
+}
+
+// compileCondition は条件式を解析する。空の式は常に真
+func compileCondition(expr string, names map[string]string, values map[string]*value) (condition, map[string]bool, error) {
+	if strings.TrimSpace(expr) == "" {
+		return func(item) (bool, error) { return true, nil }, nil, nil
+	}
+	p, err := newParser(expr, names, values)
+	if err != nil {
+		return nil, nil, err
+	}
+	cond, err := p.parseCondition()
+	if err != nil {
+		return nil, nil, err
+	}
+	return cond, p.used, p.done()
+}
+
+// updateAction は更新式の1つの操作
+type updateAction func(it item) error
+
+// compileUpdate は更新式（SET・REMOVE・ADD・DELETE）を解析する
+func compileUpdate(expr string, names map[string]string, values map[string]*value) ([]updateAction, map[string]bool, error) {
+	p, err := newParser(expr, names, values)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var actions []updateAction
+	for p.peek().kind != "eof" {
+		if !p.keyword("SET", "REMOVE", "ADD", "DELETE") {
+			return nil, nil, fmt.Errorf("syntax error: unexpected token %q in update expression", p.peek().text)
+		}
+		clause := strings.ToUpper(p.next().text)
+		for {
+			action, err := p.parseUpdateAction(clause)
+			if err != nil {
+				return nil, nil, err
+			}
+			actions = append(actions, action)
+			if !p.symbol(",") {
+				break
+			}
+			p.next()
+		}
+	}
+	if len(actions) == 0 {
+		return nil, nil, fmt.Errorf("update expression must not be empty")
+	}
+	return actions, p.used, nil
+}
+
+func (p *parser) parseUpdateAction(clause string) (updateAction, error) {
+	target, err := p.parsePath()
+	if err != nil {
+		return nil, err
+	}
+
+	switch clause {
+	case "REMOVE":
+		return func(it item) error { return target.remove(it) }, nil
+
+	case "SET":
+		if err := p.expect("="); err != nil {
+			return nil, err
+		}
+		left, err := p.parseOperand(true)
+		if err != nil {
+			return nil, err
+		}
+		var op string
+		var right operand
+		if p.symbol("+") || p.symbol("-") {
+			op = p.next().text
+			if right, err = p.parseOperand(true); err != nil {
+				return nil, err
+			}
+		}
+		return func(it item) error {
+			v, err := left(it)
+			if err != nil {
+				return err
+			}
+			if v == nil {
+				return fmt.Errorf("the provided expression refers to an attribute that does not exist in the item")
+			}
+			if right != nil {
+				w, err := right(it)
+				if err != nil {
+					return err
+				}
+				if v, err = arithmetic(v, w, op); err != nil {
+					return err
+				}
+			}
+			return target.set(it, v.clone())
+		}, nil
+
+	default: // ADD・DELETE
+		arg, err := p.valueRef()
+		if err != nil {
+			return nil, err
+		}
+		return func(it item) error {
+			current := target.get(it)
+			if clause == "ADD" {
+				return addTo(it, target, current, arg)
+			}
+			return deleteFrom(it, target, current, arg)
+		}, nil
+	}
+}
+
+func arithmetic(a, b *value, op string) (*value, error) {
+	if b == nil || a.kind != "N" || b.kind != "N" {
+		return nil, fmt.Errorf("an operand in the update expression has an incorrect data type")
+	}
+	x, err := number(a.s)
+	if err != nil {
+		return nil, err
+	}
+	y, err := number(b.s)
+	if err != nil {
+		return nil, err
+	}
+	if op == "+" {
+		x.Add(x, y)
+	} else {
+		x.Sub(x, y)
+	}
+	return &value{kind: "N", s: formatNumber(x)}, nil
+}
+
+func addTo(it item, target path, current, arg *value) error {
+	switch {
+	case current == nil:
+		return target.set(it, arg.clone())
+	case current.kind == "N" && arg.kind == "N":
+		sum, err := arithmetic(current, arg, "+")
+		if err != nil {
+			return err
+		}
+		return target.set(it, sum)
+	case current.kind == arg.kind && (arg.kind == "SS" || arg.kind == "NS" || arg.kind == "BS"):
+		merged := current.clone()
+		for _, e := range arg.set {
+			if !slices.Contains(merged.set, e) {
+				merged.set = append(merged.set, e)
+			}
+		}
+		return target.set(it, merged)
+	default:
+		return fmt.Errorf("an operand in the update expression has an incorrect data type")
+	}
+}
+
+func deleteFrom(it item, target path, current, arg *value) error {
+	if current == nil {
+		return nil
+	}
+	if current.kind != arg.kind {
+		return fmt.Errorf("an operand in the update expression has an incorrect data type")
+	}
+	remaining := current.clone()
+	remaining.set = slices.DeleteFunc(remaining.set, func(e string) bool { return slices.Contains(arg.set, e) })
+	if len(remaining.set) == 0 {
+		return target.remove(it)
+	}
+	return target.set(it, remaining)
+}
+
+// compileProjection は射影式を解析する。空の式は nil（すべての属性）
+func compileProjection(expr string, names map[string]string) ([]path, map[string]bool, error) {
+	if strings.TrimSpace(expr) == "" {
+		return nil, nil, nil
+	}
+	p, err := newParser(expr, names, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	var paths []path
+	for {
+		target, err := p.parsePath()
+		if err != nil {
+			return nil, nil, err
+		}
+		paths = append(paths, target)
+		if !p.symbol(",") {
+			break
+		}
+		p.next()
+	}
+	return paths, p.used, p.done()
+}
+
+// project は射影式の属性だけを残した項目を返す（入れ子のパスは最上位の属性ごと残す）
+func project(it item, paths []path) item {
+	if paths == nil || it == nil {
+		return it
+	}
+	out := make(item)
+	for _, p := range paths {
+		if v, ok := it[p[0].name]; ok {
+			out[p[0].name] = v
+		}
+	}
+	return out
+}
@@ -0,0 +1,880 @@
+// Package dynamotest はテスト用のDynamoDB互換サーバー。
+// DynamoDBのJSON API（DynamoDB_20120810）のうち、バックエンドとコマンドが使う操作
+// （テーブルの作成・項目の読み書き・条件付き書き込み・トランザクション・Query / Scan のページング）をメモリ上で実装する。
+// 結果整合性・容量・TTLによる削除は再現しない。ページの大きさ（SetPageSize）と障害（Fail）はテストから指定できる
+package dynamotest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+)
+
+// Server はメモリ上のDynamoDB互換サーバー
+type Server struct {
+	// URL はサーバーのエンドポイント（NewClient に渡す）
+	URL string
+
+	srv      *httptest.Server
+	mu       sync.Mutex
+	tables   map[string]*table
+	pageSize int
+	failures map[string]*failure
+}
+
+type failure struct {
+	errorType string
+	remaining int // 負の場合は解除するまで失敗させる
+}
+
+// NewServer はサーバーを起動する。使い終わったら Close で停止する
+func NewServer() *Server {
+	s := &Server{
+		tables:   make(map[string]*table),
+		failures: make(map[string]*failure),
+	}
+	s.srv = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.srv.URL
+	return s
+}
+
+// Close はサーバーを停止する
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// Client はこのサーバーに接続するクライアントを返す
+func (s *Server) Client() *dynamodb.Client {
+	return NewClient(s.URL)
+}
+
+// NewClient は endpoint（このパッケージのサーバーや DynamoDB Local）に接続するクライアントを返す。
+// SDKのリトライは無効にする（バックエンドと同じく、リトライは呼び出し側で行う）
+func NewClient(endpoint string) *dynamodb.Client {
+	return dynamodb.New(dynamodb.Options{
+		Region:           "ap-northeast-1",
+		BaseEndpoint:     aws.String(endpoint),
+		Credentials:      credentials.NewStaticCredentialsProvider("test", "test", ""),
+		RetryMaxAttempts: 1,
+	})
+}
+
+// SetPageSize は Query / Scan が1回に評価する項目数の上限を設定する（0 は無制限）。
+// 本物のDynamoDBが1MBごとにページを分けるのと同じく、Limit がなくても LastEvaluatedKey を返す
+func (s *Server) SetPageSize(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pageSize = n
+}
+
+// Fail は次の times 回の操作 op（"Query" など）を errorType（"ProvisionedThroughputExceededException" など）のエラーにする。
+// times が負の場合は Fail(op, "", 0) で解除するまで失敗させる
+func (s *Server) Fail(op, errorType string, times int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if times == 0 {
+		delete(s.failures, op)
+		return
+	}
+	s.failures[op] = &failure{errorType: errorType, remaining: times}
+}
+
+// apiError はDynamoDBのエラーレスポンス
+type apiError struct {
+	errorType string
+	message   string
+	extra     map[string]interface{}
+}
+
+func (e *apiError) Error() string { return e.errorType + ": " + e.message }
+
+func validationError(format string, args ...interface{}) *apiError {
+	return &apiError{errorType: "ValidationException", message: fmt.Sprintf(format, args...)}
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	op, ok := strings.CutPrefix(r.Header.Get("X-Amz-Target"), "DynamoDB_20120810.")
+	body, err := io.ReadAll(r.Body)
+	if !ok || err != nil {
+		writeError(w, &apiError{errorType: "UnknownOperationException", message: "unknown operation"})
+		return
+	}
+
+	s.mu.Lock()
+	out, opErr := s.dispatch(op, body)
+	s.mu.Unlock()
+
+	if opErr != nil {
+		writeError(w, opErr)
+		return
+	}
+	w.Header().Set("Content-Type", "application/x-amz-json-1.0")
+	_ = json.NewEncoder(w).Encode(out)
+}
+
+func writeError(w http.ResponseWriter, err *apiError) {
+	resp := map[string]interface{}{
+		"__type":  "com.amazonaws.dynamodb.v20120810#" + err.errorType,
+		"message": err.message,
+	}
+	for k, v := range err.extra {
+		resp[k] = v
+	}
+	status := http.StatusBadRequest
+	if err.errorType == "InternalServerError" || err.errorType == "ServiceUnavailable" {
+		status = http.StatusInternalServerError
+	}
+	w.Header().Set("Content-Type", "application/x-amz-json-1.0")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (s *Server) dispatch(op string, body []byte) (interface{}, *apiError) {
+	if f, ok := s.failures[op]; ok {
+		if f.remaining > 0 {
+			f.remaining--
+			if f.remaining == 0 {
+				delete(s.failures, op)
+			}
+		}
+		return nil, &apiError{errorType: f.errorType, message: "injected failure"}
+	}
+
+	handlers := map[string]func([]byte) (interface{}, *apiError){
+		"CreateTable":        s.createTable,
+		"DeleteTable":        s.deleteTable,
+		"DescribeTable":      s.describeTable,
+		"ListTables":         s.listTables,
+		"UpdateTimeToLive":   s.updateTimeToLive,
+		"GetItem":            s.getItem,
+		"PutItem":            s.putItem,
+		"UpdateItem":         s.updateItem,
+		"DeleteItem":         s.deleteItem,
+		"BatchWriteItem":     s.batchWriteItem,
+		"TransactWriteItems": s.transactWriteItems,
+		"Query":              s.query,
+		"Scan":               s.scan,
+	}
+	handler, ok := handlers[op]
+	if !ok {
+		return nil, &apiError{errorType: "UnknownOperationException", message: "unsupported operation " + op}
+	}
+	return handler(body)
+}
+
+func decode(body []byte, v interface{}) *apiError {
+	if err := json.Unmarshal(body, v); err != nil {
+		return &apiError{errorType: "SerializationException", message: err.Error()}
+	}
+	return nil
+}
+
+// テーブル
+
+type keyElement struct {
+	AttributeName string
+	KeyType       string // HASH, RANGE
+}
+
+type indexDefinition struct {
+	IndexName  string
+	KeySchema  []keyElement
+	Projection struct {
+		ProjectionType string
+	}
+}
+
+type table struct {
+	name      string
+	keySchema []keyElement
+	indexDefs []indexDefinition
+	hashKey   string
+	rangeKey  string
+	indexes   map[string]*index
+	items     map[string]item
+	createdAt time.Time
+}
+
+type index struct {
+	hashKey  string
+	rangeKey string
+	keysOnly bool
+}
+
+func schemaKeys(schema []keyElement) (hash, rng string) {
+	for _, k := range schema {
+		switch k.KeyType {
+		case "HASH":
+			hash = k.AttributeName
+		case "RANGE":
+			rng = k.AttributeName
+		}
+	}
+	return hash, rng
+}
+
+func (s *Server) table(name string) (*table, *apiError) {
+	t, ok := s.tables[name]
+	if !ok {
+		return nil, &apiError{errorType: "ResourceNotFoundException", message: "Requested resource not found: Table: " + name + " not found"}
+	}
+	return t, nil
+}
+
+func (t *table) description() map[string]interface{} {
+	desc := map[string]interface{}{
+		"TableName":        t.name,
+		"TableStatus":      "ACTIVE",
+		"KeySchema":        t.keySchema,
+		"ItemCount":        len(t.items),
+		"CreationDateTime": float64(t.createdAt.UnixNano()) / 1e9,
+	}
+	var gsis []map[string]interface{}
+	for _, def := range t.indexDefs {
+		gsis = append(gsis, map[string]interface{}{
+			"IndexName":   def.IndexName,
+			"KeySchema":   def.KeySchema,
+			"Projection":  def.Projection,
+			"IndexStatus": "ACTIVE",
+		})
+	}
+	if gsis != nil {
+		desc["GlobalSecondaryIndexes"] = gsis
+	}
+	return desc
+}
+
+// primaryKey は項目の主キーを取り出し、項目を一意に表す文字列を返す
+func (t *table) primaryKey(it item) (item, string, *apiError) {
+	key := make(item)
+	var id strings.Builder
+	for _, name := range []string{t.hashKey, t.rangeKey} {
+		if name == "" {
+			continue
+		}
+		v, ok := it[name]
+		if !ok || (v.kind != "S" && v.kind != "N" && v.kind != "B") {
+			return nil, "", validationError("One of the required keys was not given a value")
+		}
+		key[name] = v
+		fmt.Fprintf(&id, "%s:%s\x00", v.kind, v.s)
+	}
+	return key, id.String(), nil
+}
+
+// keyOnly は Key に主キー以外の属性が含まれていないことを確認する
+func (t *table) keyOnly(key item) (string, *apiError) {
+	_, id, err := t.primaryKey(key)
+	if err != nil {
+		return "", err
+	}
+	want := 1
+	if t.rangeKey != "" {
+		want = 2
+	}
+	if len(key) != want {
+		return "", validationError("The provided key element does not match the schema")
+	}
+	return id, nil
+}
+
+// sorted はキー（hashKey, rangeKey）を持つ項目をキーの順に返す
+func (t *table) sorted(hashKey, rangeKey string) []item {
+	var items []item
+	for _, it := range t.items {
+		if it[hashKey] == nil || (rangeKey != "" && it[rangeKey] == nil) {
+			continue
+		}
+		items = append(items, it)
+	}
+	less := func(a, b *value) int {
+		if c, ok := compare(a, b); ok {
+			return c
+		}
+		return strings.Compare(a.kind, b.kind)
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		if c := less(items[i][hashKey], items[j][hashKey]); c != 0 {
+			return c < 0
+		}
+		if rangeKey != "" {
+			if c := less(items[i][rangeKey], items[j][rangeKey]); c != 0 {
+				return c < 0
+			}
+		}
+		// 同じインデックスキーの項目はテーブルの主キー順にする
+		_, a, _ := t.primaryKey(items[i])
+		_, b, _ := t.primaryKey(items[j])
+		return a < b
+	})
+	return items
+}
+
+func (s *Server) createTable(body []byte) (interface{}, *apiError) {
+	var in struct {
+		TableName              string
+		KeySchema              []keyElement
+		GlobalSecondaryIndexes []indexDefinition
+		LocalSecondaryIndexes  []indexDefinition
+	}
+	if err := decode(body, &in); err != nil {
+		return nil, err
+	}
+	if _, exists := s.tables[in.TableName]; exists {
+		return nil, &apiError{errorType: "ResourceInUseException", message: "Table already exists: " + in.TableName}
+	}
+
+	t := &table{
+		name:      in.TableName,
+		keySchema: in.KeySchema,
+		indexDefs: append(in.GlobalSecondaryIndexes, in.LocalSecondaryIndexes...),
+		indexes:   make(map[string]*index),
+		items:     make(map[string]item),
+		createdAt: time.Now(),
+	}
+	t.hashKey, t.rangeKey = schemaKeys(in.KeySchema)
+	if t.hashKey == "" {
+		return nil, validationError("KeySchema must contain a HASH key")
+	}
+	for _, def := range t.indexDefs {
+		hash, rng := schemaKeys(def.KeySchema)
+		t.indexes[def.IndexName] = &index{hashKey: hash, rangeKey: rng, keysOnly: def.Projection.ProjectionType == "KEYS_ONLY"}
+	}
+	s.tables[in.TableName] = t
+	return map[string]interface{}{"TableDescription": t.description()}, nil
+}
+
+func (s *Server) deleteTable(body []byte) (interface{}, *apiError) {
+	var in struct{ TableName string }
+	if err := decode(body, &in); err != nil {
+		return nil, err
+	}
+	t, err := s.table(in.TableName)
+	if err != nil {
+		return nil, err
+	}
+	delete(s.tables, in.TableName)
+	return map[string]interface{}{"TableDescription": t.description()}, nil
+}
+
+func (s *Server) describeTable(body []byte) (interface{}, *apiError) {
+	var in struct{ TableName string }
+	if err := decode(body, &in); err != nil {
+		return nil, err
+	}
+	t, err := s.table(in.TableName)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"Table": t.description()}, nil
+}
+
+func (s *Server) listTables([]byte) (interface{}, *apiError) {
+	names := make([]string, 0, len(s.tables))
+	for name := range s.tables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return map[string]interface{}{"TableNames": names}, nil
+}
+
+func (s *Server) updateTimeToLive(body []byte) (interface{}, *apiError) {
+	var in struct {
+		TableName               string
+		TimeToLiveSpecification json.RawMessage
+	}
+	if err := decode(body, &in); err != nil {
+		return nil, err
+	}
+	if _, err := s.table(in.TableName); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"TimeToLiveSpecification": in.TimeToLiveSpecification}, nil
+}
+
+// 書き込み
+
+// writeRequest は PutItem・UpdateItem・DeleteItem と TransactWriteItems の各操作の入力
+type writeRequest struct {
+	TableName                           string
+	Item                                item
+	Key                                 item
+	UpdateExpression                    string
+	ConditionExpression                 string
+	ExpressionAttributeNames            map[string]string
+	ExpressionAttributeValues           map[string]*value
+	ReturnValues                        string
+	ReturnValuesOnConditionCheckFailure string
+}
+
+// preparedWrite は条件を評価した書き込み。commit で反映する
+type preparedWrite struct {
+	t       *table
+	id      string
+	old     item
+	new     item // nil の場合は削除
+	check   bool // ConditionCheck（書き込みなし）
+	failed  bool // 条件を満たさなかった
+	request *writeRequest
+}
+
+// prepare は書き込み（kind: Put・Update・Delete・ConditionCheck）の条件を評価し、書き込み後の項目を作る
+func (s *Server) prepare(kind string, req *writeRequest) (*preparedWrite, *apiError) {
+	t, apiErr := s.table(req.TableName)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	var id string
+	var keyItem item
+	if kind == "Put" {
+		if keyItem, id, apiErr = t.primaryKey(req.Item); apiErr != nil {
+			return nil, apiErr
+		}
+	} else {
+		if id, apiErr = t.keyOnly(req.Key); apiErr != nil {
+			return nil, apiErr
+		}
+		keyItem = req.Key
+	}
+
+	used := make(map[string]bool)
+	cond, condUsed, err := compileCondition(req.ConditionExpression, req.ExpressionAttributeNames, req.ExpressionAttributeValues)
+	if err != nil {
+		return nil, validationError("Invalid ConditionExpression: %v", err)
+	}
+	mergeUsed(used, condUsed)
+
+	var actions []updateAction
+	if kind == "Update" {
+		var updateUsed map[string]bool
+		if actions, updateUsed, err = compileUpdate(req.UpdateExpression, req.ExpressionAttributeNames, req.ExpressionAttributeValues); err != nil {
+			return nil, validationError("Invalid UpdateExpression: %v", err)
+		}
+		mergeUsed(used, updateUsed)
+	}
+	if apiErr := checkUnused(used, req.ExpressionAttributeNames, req.ExpressionAttributeValues); apiErr != nil {
+		return nil, apiErr
+	}
+
+	w := &preparedWrite{t: t, id: id, old: t.items[id], request: req}
+	ok, err := cond(w.old)
+	if err != nil {
+		return nil, validationError("Invalid ConditionExpression: %v", err)
+	}
+	if !ok {
+		w.failed = true
+		return w, nil
+	}
+
+	switch kind {
+	case "Put":
+		w.new = req.Item.clone()
+	case "Update":
+		base := w.old.clone()
+		if base == nil {
+			base = keyItem.clone()
+		}
+		for _, action := range actions {
+			if err := action(base); err != nil {
+				return nil, validationError("Invalid UpdateExpression: %v", err)
+			}
+		}
+		if _, newID, apiErr := t.primaryKey(base); apiErr != nil || newID != id {
+			return nil, validationError("Cannot update attribute %s. This attribute is part of the key", t.hashKey)
+		}
+		w.new = base
+	case "ConditionCheck":
+		w.check = true
+	}
+	return w, nil
+}
+
+func (w *preparedWrite) commit() {
+	switch {
+	case w.check:
+	case w.new == nil:
+		delete(w.t.items, w.id)
+	default:
+		w.t.items[w.id] = w.new
+	}
+}
+
+// returnValues は ReturnValues（ALL_OLD・ALL_NEW）に応じた項目を返す
+func (w *preparedWrite) returnValues() map[string]interface{} {
+	out := map[string]interface{}{}
+	switch w.request.ReturnValues {
+	case "ALL_OLD":
+		if w.old != nil {
+			out["Attributes"] = w.old.clone()
+		}
+	case "ALL_NEW":
+		if w.new != nil {
+			out["Attributes"] = w.new.clone()
+		}
+	}
+	return out
+}
+
+func (w *preparedWrite) conditionFailed() *apiError {
+	err := &apiError{errorType: "ConditionalCheckFailedException", message: "The conditional request failed"}
+	if w.request.ReturnValuesOnConditionCheckFailure == "ALL_OLD" && w.old != nil {
+		err.extra = map[string]interface{}{"Item": w.old.clone()}
+	}
+	return err
+}
+
+func mergeUsed(dst, src map[string]bool) {
+	for k := range src {
+		dst[k] = true
+	}
+}
+
+// checkUnused は式で使われていない ExpressionAttributeNames / Values を ValidationException にする（本物のDynamoDBと同じ）
+func checkUnused(used map[string]bool, names map[string]string, values map[string]*value) *apiError {
+	for name := range names {
+		if !used[name] {
+			return validationError("Value provided in ExpressionAttributeNames unused in expressions: keys: {%s}", name)
+		}
+	}
+	for name := range values {
+		if !used[name] {
+			return validationError("Value provided in ExpressionAttributeValues unused in expressions: keys: {%s}", name)
+		}
+	}
+	return nil
+}
+
+func (s *Server) write(kind string, body []byte) (interface{}, *apiError) {
+	var req writeRequest
+	if err := decode(body, &req); err != nil {
+		return nil, err
+	}
+	w, err := s.prepare(kind, &req)
+	if err != nil {
+		return nil, err
+	}
+	if w.failed {
+		return nil, w.conditionFailed()
+	}
+	w.commit()
+	return w.returnValues(), nil
+}
+
+func (s *Server) putItem(body []byte) (interface{}, *apiError)    { return s.write("Put", body) }
+func (s *Server) updateItem(body []byte) (interface{}, *apiError) { return s.write("Update", body) }
+func (s *Server) deleteItem(body []byte) (interface{}, *apiError) { return s.write("Delete", body) }
+
+func (s *Server) batchWriteItem(body []byte) (interface{}, *apiError) {
+	var in struct {
+		RequestItems map[string][]struct {
+			PutRequest    *struct{ Item item }
+			DeleteRequest *struct{ Key item }
+		}
+	}
+	if err := decode(body, &in); err != nil {
+		return nil, err
+	}
+
+	var writes []*preparedWrite
+	for tableName, requests := range in.RequestItems {
+		for _, r := range requests {
+			var w *preparedWrite
+			var err *apiError
+			switch {
+			case r.PutRequest != nil:
+				w, err = s.prepare("Put", &writeRequest{TableName: tableName, Item: r.PutRequest.Item})
+			case r.DeleteRequest != nil:
+				w, err = s.prepare("Delete", &writeRequest{TableName: tableName, Key: r.DeleteRequest.Key})
+			default:
+				err = validationError("a write request must contain PutRequest or DeleteRequest")
+			}
+			if err != nil {
+				return nil, err
+			}
+			writes = append(writes, w)
+		}
+	}
+	for _, w := range writes {
+		w.commit()
+	}
+	return map[string]interface{}{"UnprocessedItems": map[string]interface{}{}}, nil
+}
+
+func (s *Server) transactWriteItems(body []byte) (interface{}, *apiError) {
+	var in struct {
+		TransactItems []struct {
+			Put            *writeRequest
+			Update         *writeRequest
+			Delete         *writeRequest
+			ConditionCheck *writeRequest
+		}
+	}
+	if err := decode(body, &in); err != nil {
+		return nil, err
+	}
+
+	// すべての条件を書き込み前の状態で評価し、1つでも満たさなければ何も書き込まない
+	writes := make([]*preparedWrite, 0, len(in.TransactItems))
+	targets := make(map[string]bool)
+	failed := false
+	for _, ti := range in.TransactItems {
+		kind, req := "Put", ti.Put
+		switch {
+		case ti.Update != nil:
+			kind, req = "Update", ti.Update
+		case ti.Delete != nil:
+			kind, req = "Delete", ti.Delete
+		case ti.ConditionCheck != nil:
+			kind, req = "ConditionCheck", ti.ConditionCheck
+		}
+		if req == nil {
+			return nil, validationError("a transact item must contain exactly one operation")
+		}
+		w, err := s.prepare(kind, req)
+		if err != nil {
+			return nil, err
+		}
+		target := w.t.name + "\x00" + w.id
+		if targets[target] {
+			return nil, validationError("Transaction request cannot include multiple operations on one item")
+		}
+		targets[target] = true
+		failed = failed || w.failed
+		writes = append(writes, w)
+	}
+
+	if failed {
+		reasons := make([]map[string]interface{}, len(writes))
+		var codes []string
+		for i, w := range writes {
+			reason := map[string]interface{}{"Code": "None"}
+			if w.failed {
+				reason = map[string]interface{}{"Code": "ConditionalCheckFailed", "Message": "The conditional request failed"}
+				if w.request.ReturnValuesOnConditionCheckFailure == "ALL_OLD" && w.old != nil {
+					reason["Item"] = w.old.clone()
+				}
+			}
+			reasons[i] = reason
+			codes = append(codes, reason["Code"].(string))
+		}
+		return nil, &apiError{
+			errorType: "TransactionCanceledException",
+			message:   "Transaction cancelled, please refer cancellation reasons for specific reasons [" + strings.Join(codes, ", ") + "]",
+			extra:     map[string]interface{}{"CancellationReasons": reasons},
+		}
+	}
+
+	for _, w := range writes {
+		w.commit()
+	}
+	return map[string]interface{}{}, nil
+}
+
+// 読み込み
+
+func (s *Server) getItem(body []byte) (interface{}, *apiError) {
+	var in struct {
+		TableName                string
+		Key                      item
+		ProjectionExpression     string
+		ExpressionAttributeNames map[string]string
+	}
+	if err := decode(body, &in); err != nil {
+		return nil, err
+	}
+	t, apiErr := s.table(in.TableName)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	id, apiErr := t.keyOnly(in.Key)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+	paths, used, err := compileProjection(in.ProjectionExpression, in.ExpressionAttributeNames)
+	if err != nil {
+		return nil, validationError("Invalid ProjectionExpression: %v", err)
+	}
+	if apiErr := checkUnused(used, in.ExpressionAttributeNames, nil); apiErr != nil {
+		return nil, apiErr
+	}
+
+	out := map[string]interface{}{}
+	if it, ok := t.items[id]; ok {
+		out["Item"] = project(it, paths).clone()
+	}
+	return out, nil
+}
+
+// readRequest は Query・Scan の入力
+type readRequest struct {
+	TableName                 string
+	IndexName                 string
+	KeyConditionExpression    string
+	FilterExpression          string
+	ProjectionExpression      string
+	ExpressionAttributeNames  map[string]string
+	ExpressionAttributeValues map[string]*value
+	ScanIndexForward          *bool
+	Limit                     int
+	ExclusiveStartKey         item
+	Select                    string
+}
+
+func (s *Server) query(body []byte) (interface{}, *apiError) {
+	var in readRequest
+	if err := decode(body, &in); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(in.KeyConditionExpression) == "" {
+		return nil, validationError("Either the KeyConditions or KeyConditionExpression parameter must be specified in the request")
+	}
+	return s.read(&in)
+}
+
+func (s *Server) scan(body []byte) (interface{}, *apiError) {
+	var in readRequest
+	if err := decode(body, &in); err != nil {
+		return nil, err
+	}
+	return s.read(&in)
+}
+
+// read は Query（KeyConditionExpression あり）と Scan を処理する
+func (s *Server) read(in *readRequest) (interface{}, *apiError) {
+	t, apiErr := s.table(in.TableName)
+	if apiErr != nil {
+		return nil, apiErr
+	}
+
+	hashKey, rangeKey, keysOnly := t.hashKey, t.rangeKey, false
+	if in.IndexName != "" {
+		idx, ok := t.indexes[in.IndexName]
+		if !ok {
+			return nil, validationError("The table does not have the specified index: %s", in.IndexName)
+		}
+		hashKey, rangeKey, keysOnly = idx.hashKey, idx.rangeKey, idx.keysOnly
+	}
+
+	used := make(map[string]bool)
+	keyCond, keyUsed, err := compileCondition(in.KeyConditionExpression, in.ExpressionAttributeNames, in.ExpressionAttributeValues)
+	if err != nil {
+		return nil, validationError("Invalid KeyConditionExpression: %v", err)
+	}
+	filter, filterUsed, err := compileCondition(in.FilterExpression, in.ExpressionAttributeNames, in.ExpressionAttributeValues)
+	if err != nil {
+		return nil, validationError("Invalid FilterExpression: %v", err)
+	}
+	paths, projectionUsed, err := compileProjection(in.ProjectionExpression, in.ExpressionAttributeNames)
+	if err != nil {
+		return nil, validationError("Invalid ProjectionExpression: %v", err)
+	}
+	mergeUsed(used, keyUsed)
+	mergeUsed(used, filterUsed)
+	mergeUsed(used, projectionUsed)
+	if apiErr := checkUnused(used, in.ExpressionAttributeNames, in.ExpressionAttributeValues); apiErr != nil {
+		return nil, apiErr
+	}
+
+	var candidates []item
+	for _, it := range t.sorted(hashKey, rangeKey) {
+		ok, err := keyCond(it)
+		if err != nil {
+			return nil, validationError("Invalid KeyConditionExpression: %v", err)
+		}
+		if ok {
+			candidates = append(candidates, it)
+		}
+	}
+	if in.ScanIndexForward != nil && !*in.ScanIndexForward {
+		for i, j := 0, len(candidates)-1; i < j; i, j = i+1, j-1 {
+			candidates[i], candidates[j] = candidates[j], candidates[i]
+		}
+	}
+
+	// ExclusiveStartKey の次の項目から評価する
+	start := 0
+	if in.ExclusiveStartKey != nil {
+		_, startID, apiErr := t.primaryKey(in.ExclusiveStartKey)
+		if apiErr != nil {
+			return nil, validationError("The provided starting key is invalid")
+		}
+		for i, it := range candidates {
+			if _, id, _ := t.primaryKey(it); id == startID {
+				start = i + 1
+				break
+			}
+		}
+	}
+
+	limit := in.Limit
+	if s.pageSize > 0 && (limit == 0 || s.pageSize < limit) {
+		limit = s.pageSize
+	}
+	end := len(candidates)
+	var lastKey item
+	if limit > 0 && start+limit < len(candidates) {
+		end = start + limit
+		lastKey = t.lastEvaluatedKey(candidates[end-1], hashKey, rangeKey)
+	}
+	if start > end {
+		start = end
+	}
+
+	items := []item{}
+	for _, it := range candidates[start:end] {
+		ok, err := filter(it)
+		if err != nil {
+			return nil, validationError("Invalid FilterExpression: %v", err)
+		}
+		if !ok {
+			continue
+		}
+		if keysOnly {
+			it = t.lastEvaluatedKey(it, hashKey, rangeKey)
+		}
+		items = append(items, project(it, paths).clone())
+	}
+
+	out := map[string]interface{}{
+		"Count":        len(items),
+		"ScannedCount": end - start,
+	}
+	if in.Select != "COUNT" {
+		out["Items"] = items
+	}
+	if lastKey != nil {
+		out["LastEvaluatedKey"] = lastKey
+	}
+	return out, nil
+}
+
+// lastEvaluatedKey はテーブルの主キーとインデックスのキーだけを残した項目を返す
+func (t *table) lastEvaluatedKey(it item, hashKey, rangeKey string) item {
+	key := make(item)
+	for _, name := range []string{t.hashKey, t.rangeKey, hashKey, rangeKey} {
+		if v, ok := it[name]; ok && name != "" {
+			key[name] = v.clone()
+		}
+	}
+	return key
+}
+
+// ResetTables はすべてのテーブルを削除する（サーバーを使い回すテスト用）
+func (s *Server) ResetTables(context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tables = make(map[string]*table)
+	s.failures = make(map[string]*failure)
+}
@@ -0,0 +1,187 @@
+package dynamotest
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// value はDynamoDBのJSON形式のAttributeValue（{"S": "..."} など）
+type value struct {
+	kind string // S, N, B, BOOL, NULL, M, L, SS, NS, BS
+	s    string // S・N（数値は文字列のまま）・B（base64）
+	b    bool   // BOOL
+	m    map[string]*value
+	l    []*value
+	set  []string // SS・NS・BS
+}
+
+// item は1項目（属性名 → 値）
+type item map[string]*value
+
+func stringValue(s string) *value { return &value{kind: "S", s: s} }
+
+func (v *value) UnmarshalJSON(data []byte) error {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if len(raw) != 1 {
+		return fmt.Errorf("attribute value must have exactly one type, got %d", len(raw))
+	}
+	for kind, body := range raw {
+		v.kind = kind
+		switch kind {
+		case "S", "N", "B":
+			return json.Unmarshal(body, &v.s)
+		case "BOOL", "NULL":
+			return json.Unmarshal(body, &v.b)
+		case "M":
+			return json.Unmarshal(body, &v.m)
+		case "L":
+			return json.Unmarshal(body, &v.l)
+		case "SS", "NS", "BS":
+			return json.Unmarshal(body, &v.set)
+		default:
+			return fmt.Errorf("unsupported attribute value type %q", kind)
+		}
+	}
+	return nil
+}
+
+func (v *value) MarshalJSON() ([]byte, error) {
+	var body interface{}
+	switch v.kind {
+	case "S", "N", "B":
+		body = v.s
+	case "BOOL", "NULL":
+		body = v.b
+	case "M":
+		m := v.m
+		if m == nil {
+			m = map[string]*value{}
+		}
+		body = m
+	case "L":
+		l := v.l
+		if l == nil {
+			l = []*value{}
+		}
+		body = l
+	default:
+		body = v.set
+	}
+	return json.Marshal(map[string]interface{}{v.kind: body})
+}
+
+// clone は値を複製する（保存している項目がリクエスト・レスポンス経由で書き換わらないようにする）
+func (v *value) clone() *value {
+	if v == nil {
+		return nil
+	}
+	c := &value{kind: v.kind, s: v.s, b: v.b, set: slices.Clone(v.set)}
+	if v.m != nil {
+		c.m = make(map[string]*value, len(v.m))
+		for k, e := range v.m {
+			c.m[k] = e.clone()
+		}
+	}
+	for _, e := range v.l {
+		c.l = append(c.l, e.clone())
+	}
+	return c
+}
+
+func (it item) clone() item {
+	if it == nil {
+		return nil
+	}
+	c := make(item, len(it))
+	for k, v := range it {
+		c[k] = v.clone()
+	}
+	return c
+}
+
+// number は N の値を任意精度の数値にする
+func number(s string) (*big.Float, error) {
+	f, _, err := big.ParseFloat(strings.TrimSpace(s), 10, 200, big.ToNearestEven)
+	if err != nil {
+		return nil, fmt.Errorf("invalid number %q", s)
+	}
+	return f, nil
+}
+
+func formatNumber(f *big.Float) string {
+	return f.Text('f', -1)
+}
+
+// compare は同じ型（S・N・B）の値の大小を比較する。比較できない組み合わせは ok が false
+func compare(a, b *value) (int, bool) {
+	if a == nil || b == nil || a.kind != b.kind {
+		return 0, false
+	}
+	switch a.kind {
+	case "S":
+		return strings.Compare(a.s, b.s), true
+	case "N":
+		x, err1 := number(a.s)
+		y, err2 := number(b.s)
+		if err1 != nil || err2 != nil {
+			return 0, false
+		}
+		return x.Cmp(y), true
+	case "B":
+		x, err1 := base64.StdEncoding.DecodeString(a.s)
+		y, err2 := base64.StdEncoding.DecodeString(b.s)
+		if err1 != nil || err2 != nil {
+			return 0, false
+		}
+		return bytes.Compare(x, y), true
+	}
+	return 0, false
+}
+
+// equal は値が等しいかを返す（数値は値で、集合は順序を無視して比較する）
+func equal(a, b *value) bool {
+	if a == nil || b == nil || a.kind != b.kind {
+		return false
+	}
+	switch a.kind {
+	case "S", "N", "B":
+		c, ok := compare(a, b)
+		return ok && c == 0
+	case "BOOL", "NULL":
+		return a.b == b.b
+	case "M":
+		if len(a.m) != len(b.m) {
+			return false
+		}
+		for k, e := range a.m {
+			if !equal(e, b.m[k]) {
+				return false
+			}
+		}
+		return true
+	case "L":
+		if len(a.l) != len(b.l) {
+			return false
+		}
+		for i := range a.l {
+			if !equal(a.l[i], b.l[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		x, y := slices.Clone(a.set), slices.Clone(b.set)
+		sort.Strings(x)
+		sort.Strings(y)
+		return slices.Equal(x, y)
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+
+	"typing-game-backend/apierror"
+	"typing-game-backend/names"
+)
+
+// respondError はエラーを apierror のレスポンスとして返す。
+// *apierror.Error 以外のエラーは internal_error として扱い、内容（err.Error()）はレスポンスに含めない
+func respondError(c *gin.Context, err error) {
+	apiErr := apierror.As(err)
+	resp := apiErr.Response(apierror.Language(c.Request))
+	if requestID, ok := c.Request.Context().Value(requestIDKey{}).(string); ok {
+		resp.RequestID = requestID
+	}
+	c.AbortWithStatusJSON(apiErr.Status(), resp)
+}
+
+// bindError は ShouldBindJSON のエラーを項目ごとの検証エラーに変換する
+func bindError(err error) *apierror.Error {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return apierror.Wrap(apierror.CodeRequestTooLarge, err)
+	}
+
+	var invalid validator.ValidationErrors
+	if errors.As(err, &invalid) {
+		fields := make([]apierror.FieldError, 0, len(invalid))
+		for _, fe := range invalid {
+			code := apierror.FieldInvalid
+			if fe.Tag() == "required" {
+				code = apierror.FieldRequired
+			}
+			fields = append(fields, apierror.Field(fe.Field(), code))
+		}
+		return apierror.Validation(fields...)
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return apierror.Validation(apierror.Field(typeErr.Field, apierror.FieldInvalid))
+	}
+
+	return apierror.Wrap(apierror.CodeInvalidRequest, err)
+}
+
+// storageError はストレージのエラーを、時間をおけば解消しうる障害（storage_unavailable）とそれ以外（internal_error）に分ける
+func storageError(err error) *apierror.Error {
+	if errors.Is(err, errCircuitOpen) || errors.Is(err, context.DeadlineExceeded) || isRetryable(err) {
+		return apierror.Wrap(apierror.CodeStorageUnavailable, err)
+	}
+	return apierror.Wrap(apierror.CodeInternal, err)
+}
+
+// playerNameError はプレイヤー名のポリシー違反を apierror に変換する（コードは同じ値）
+func playerNameError(v *names.Violation) *apierror.Error {
+	if v.Code == names.CodeTooLong {
+		return apierror.New(apierror.Code(v.Code), v.MaxLength)
+	}
+	return apierror.New(apierror.Code(v.Code))
+}
+
+// recoveryHandler は panic を internal_error として返す
+func recoveryHandler(c *gin.Context, recovered interface{}) {
+	loggerFrom(c.Request.Context()).Error("Panic recovered", "panic", fmt.Sprint(recovered))
+	respondError(c, apierror.New(apierror.CodeInternal))
+}
+
+// notFound は存在しないルートへのリクエストに not_found を返す
+func notFound(c *gin.Context) {
+	respondError(c, apierror.New(apierror.CodeNotFound))
+}
+
+// useJSONFieldNames は検証エラーの項目名を構造体のフィールド名ではなくJSONのキーにする
+func useJSONFieldNames() {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+	v.RegisterTagNameFunc(func(f reflect.StructField) string {
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			return f.Name
+		}
+		return name
+	})
+}
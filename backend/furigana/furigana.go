@@ -0,0 +1,194 @@
+// Package furigana は日本語の表示テキスト（漢字かな混じり）と入力する読みの対応（ふりがな）を扱う。
+// 表示テキストは区間（Segment）に分け、漢字を含む区間だけが読みを持つ
+package furigana
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Segment は表示テキストの区間。Reading は漢字を含む区間の読み（かな）で、それ以外の区間では空
+type Segment struct {
+	Text    string `dynamodbav:"text" json:"text"`
+	Reading string `dynamodbav:"reading,omitempty" json:"reading,omitempty"`
+}
+
+// Text は区間をつなげた表示テキストを返す
+func Text(segments []Segment) string {
+	var b strings.Builder
+	for _, s := range segments {
+		b.WriteString(s.Text)
+	}
+	return b.String()
+}
+
+// Reading は区間をつなげた読みを返す（読みのない区間は表示テキストのまま）
+func Reading(segments []Segment) string {
+	var b strings.Builder
+	for _, s := range segments {
+		if s.Reading != "" {
+			b.WriteString(s.Reading)
+		} else {
+			b.WriteString(s.Text)
+		}
+	}
+	return b.String()
+}
+
+// Plain は読みのない1つの区間を返す（かなだけの単語など）
+func Plain(text string) []Segment {
+	return []Segment{{Text: text}}
+}
+
+// Validate は区間が表示テキストと読みに一致するか検証する。
+// 漢字を含む区間には読みが必要で、読みはかなでなければならない。カタカナとひらがなは同じ読みとみなす
+func Validate(display, reading string, segments []Segment) error {
+	if len(segments) == 0 {
+		return errors.New("furigana has no segments")
+	}
+	if text := Text(segments); text != display {
+		return fmt.Errorf("furigana text %q does not match display %q", text, display)
+	}
+	for _, s := range segments {
+		switch {
+		case s.Text == "":
+			return errors.New("furigana segment has no text")
+		case s.Reading == "":
+			if k := FirstKanji(s.Text); k != "" {
+				return fmt.Errorf("kanji %q has no reading", k)
+			}
+		case !IsKana(s.Reading):
+			return fmt.Errorf("reading %q of %q must be kana", s.Reading, s.Text)
+		}
+	}
+	if got := ToHiragana(Reading(segments)); got != ToHiragana(reading) {
+		return fmt.Errorf("furigana reading %q does not match reading %q", got, reading)
+	}
+	return nil
+}
+
+// Align は表示テキストを読みに合わせて区間に分ける。漢字以外（送りがな・カタカナなど）を手がかりに、
+// 漢字の並びごとに読みを割り当てる。一致しない場合と、割り当て方が複数ある場合（「いい一日」など）はエラーを返す
+func Align(display, reading string) ([]Segment, error) {
+	runs := splitRuns(display)
+	if len(runs) == 0 {
+		return nil, errors.New("display is empty")
+	}
+	target := []rune(ToHiragana(reading))
+
+	// ways[i][pos] は runs[i:] を読みの pos 以降に割り当てる方法の数（2以上は2とする）。
+	// 後ろの区間から順に求めるため、一致しない場合も区間数 × 読みの長さに比例する時間で終わる
+	ways := make([][]uint8, len(runs)+1)
+	for i := range ways {
+		ways[i] = make([]uint8, len(target)+1)
+	}
+	ways[len(runs)][len(target)] = 1
+	for i := len(runs) - 1; i >= 0; i-- {
+		if !runs[i].kanji {
+			for pos := 0; pos <= len(target); pos++ {
+				if end, ok := matchLiteral(runs[i].text, target, pos); ok {
+					ways[i][pos] = ways[i+1][end]
+				}
+			}
+			continue
+		}
+		// 漢字の並びは1文字以上の読みを持つ（later は ways[i+1][pos+1:] の合計）
+		var later uint8
+		for pos := len(target) - 1; pos >= 0; pos-- {
+			later = min(2, later+ways[i+1][pos+1])
+			ways[i][pos] = later
+		}
+	}
+
+	switch ways[0][0] {
+	case 0:
+		return nil, fmt.Errorf("reading %q does not match display %q", reading, display)
+	case 1:
+	default:
+		return nil, fmt.Errorf("reading %q can be aligned to display %q in more than one way (write it with ruby like 漢字《かんじ》)", reading, display)
+	}
+
+	// 割り当て方は1つだけなので、続きの割り当て方がある位置をたどる
+	segments := make([]Segment, 0, len(runs))
+	pos := 0
+	for i, r := range runs {
+		if !r.kanji {
+			segments = append(segments, Segment{Text: r.text})
+			pos, _ = matchLiteral(r.text, target, pos)
+			continue
+		}
+		end := pos + 1
+		for ways[i+1][end] == 0 {
+			end++
+		}
+		segments = append(segments, Segment{Text: r.text, Reading: string(target[pos:end])})
+		pos = end
+	}
+	return segments, nil
+}
+
+// matchLiteral は漢字以外の並び text が読みの pos から一致するかを返し、一致した場合は続きの位置を返す
+func matchLiteral(text string, target []rune, pos int) (int, bool) {
+	literal := []rune(ToHiragana(text))
+	end := pos + len(literal)
+	if end > len(target) || string(target[pos:end]) != string(literal) {
+		return 0, false
+	}
+	return end, true
+}
+
+type run struct {
+	text  string
+	kanji bool
+}
+
+// splitRuns は表示テキストを漢字の並びとそれ以外の並びに分ける
+func splitRuns(s string) []run {
+	var runs []run
+	for _, r := range s {
+		kanji := IsKanji(r)
+		if len(runs) > 0 && runs[len(runs)-1].kanji == kanji {
+			runs[len(runs)-1].text += string(r)
+			continue
+		}
+		runs = append(runs, run{text: string(r), kanji: kanji})
+	}
+	return runs
+}
+
+// ToHiragana はカタカナをひらがなに変換する（長音などそれ以外の文字はそのまま）
+func ToHiragana(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'ァ' && r <= 'ヶ' {
+			return r - 'ァ' + 'ぁ'
+		}
+		return r
+	}, s)
+}
+
+// IsKanji は r が漢字（々・〆 を含む）かを返す
+func IsKanji(r rune) bool {
+	return unicode.Is(unicode.Han, r) || r == '々' || r == '〆'
+}
+
+// IsKana は s がかな（ひらがな・カタカナ・長音）だけからなるかを返す
+func IsKana(s string) bool {
+	for _, r := range s {
+		if !unicode.Is(unicode.Hiragana, r) && !unicode.Is(unicode.Katakana, r) && r != 'ー' {
+			return false
+		}
+	}
+	return true
+}
+
+// FirstKanji は s の最初の漢字を返す（ない場合は空文字）
+func FirstKanji(s string) string {
+	for _, r := range s {
+		if IsKanji(r) {
+			return string(r)
+		}
+	}
+	return ""
+}
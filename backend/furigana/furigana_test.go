@@ -0,0 +1,101 @@
+package furigana
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseRuby(t *testing.T) {
+	tests := []struct {
+		in   string
+		want []Segment
+	}{
+		{"吾輩《わがはい》は猫《ねこ》である。", []Segment{{"吾輩", "わがはい"}, {"は", ""}, {"猫", "ねこ"}, {"である。", ""}}},
+		{"｜東京タワー《とうきょうたわー》にいく。", []Segment{{"東京タワー", "とうきょうたわー"}, {"にいく。", ""}}},
+		{"ひらがなだけ", []Segment{{"ひらがなだけ", ""}}},
+	}
+	for _, tt := range tests {
+		got, err := ParseRuby(tt.in)
+		if err != nil || !slices.Equal(got, tt.want) {
+			t.Errorf("ParseRuby(%q) = %v, %v; want %v", tt.in, got, err, tt.want)
+		}
+		if ruby := Ruby(got); ruby != tt.in {
+			t.Errorf("Ruby(%v) = %q, want %q", got, ruby, tt.in)
+		}
+	}
+	for _, invalid := range []string{"ねこ《ねこ", "猫《neko》", "《ねこ》", "｜ねこ"} {
+		if _, err := ParseRuby(invalid); err == nil {
+			t.Errorf("ParseRuby(%q): want error", invalid)
+		}
+	}
+}
+
+func TestAlign(t *testing.T) {
+	tests := []struct {
+		display, reading string
+		want             []Segment
+	}{
+		{"漢字", "かんじ", []Segment{{"漢字", "かんじ"}}},
+		{"食べ物", "たべもの", []Segment{{"食", "た"}, {"べ", ""}, {"物", "もの"}}},
+		{"温暖化", "おんだんか", []Segment{{"温暖化", "おんだんか"}}},
+		{"コーヒー", "こーひー", []Segment{{"コーヒー", ""}}},
+		{"お弁当", "おべんとう", []Segment{{"お", ""}, {"弁当", "べんとう"}}},
+		{"手伝いましょうか", "てつだいましょうか", []Segment{{"手伝", "てつだ"}, {"いましょうか", ""}}},
+	}
+	for _, tt := range tests {
+		got, err := Align(tt.display, tt.reading)
+		if err != nil || !slices.Equal(got, tt.want) {
+			t.Errorf("Align(%q, %q) = %v, %v; want %v", tt.display, tt.reading, got, err, tt.want)
+		}
+		if err := Validate(tt.display, tt.reading, got); err != nil {
+			t.Errorf("Validate(%q, %q, %v) = %v", tt.display, tt.reading, got, err)
+		}
+	}
+
+	for _, invalid := range []struct{ display, reading string }{
+		{"食べ物", "たぺもの"}, // 送りがなが一致しない
+		{"猫", ""},
+	} {
+		if _, err := Align(invalid.display, invalid.reading); err == nil {
+			t.Errorf("Align(%q, %q): want error", invalid.display, invalid.reading)
+		}
+	}
+	if _, err := Align("日の日", "ひのひのひ"); err == nil {
+		t.Errorf("Align with more than one alignment: want error")
+	}
+
+	// 一致しない場合も、分け方をすべて試さずに終わる
+	start := time.Now()
+	if _, err := Align(strings.Repeat("漢あ", 40), strings.Repeat("あ", 120)+"x"); err == nil {
+		t.Error("Align with a reading that does not match: want error")
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Align with a reading that does not match took %v", elapsed)
+	}
+	if got, err := Align(strings.Repeat("漢あ", 40), strings.Repeat("かあ", 40)); err != nil || len(got) != 80 {
+		t.Errorf("Align of 40 kanji runs = %d segments, %v; want 80", len(got), err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	segments := []Segment{{"食", "た"}, {"べ", ""}, {"物", "もの"}}
+	tests := []struct {
+		display, reading string
+		segments         []Segment
+		valid            bool
+	}{
+		{"食べ物", "たべもの", segments, true},
+		{"食べ物", "たべもん", segments, false},
+		{"食物", "たべもの", segments, false},
+		{"食べ物", "たべもの", []Segment{{"食べ", "たべ"}, {"物", ""}}, false},
+		{"食べ物", "たべもの", []Segment{{"食べ物", "tabemono"}}, false},
+		{"コーヒー", "こーひー", Plain("コーヒー"), true},
+	}
+	for _, tt := range tests {
+		if err := Validate(tt.display, tt.reading, tt.segments); (err == nil) != tt.valid {
+			t.Errorf("Validate(%q, %q, %v) = %v, want valid %v", tt.display, tt.reading, tt.segments, err, tt.valid)
+		}
+	}
+}
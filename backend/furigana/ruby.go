@@ -0,0 +1,98 @@
+package furigana
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// rubyBaseMarkers はルビを振る範囲の始まりを示す記号（青空文庫形式の ｜）
+const rubyBaseMarkers = "｜|"
+
+// ParseRuby は青空文庫形式のルビ（漢字《かんじ》・｜漢字かな《かんじかな》）付きのテキストを区間に分ける。
+// ルビは直前の漢字の並び（｜ がある場合は ｜ から《 まで）に振る。ルビの読みはかなでなければならない
+func ParseRuby(s string) ([]Segment, error) {
+	var segments []Segment
+	// plain はまだ区間にしていないルビのないテキスト、marker は plain の中の ｜ の位置
+	var plain []rune
+	marker := -1
+
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case strings.ContainsRune(rubyBaseMarkers, r):
+			marker = len(plain)
+		case r == '《':
+			end := i + 1
+			for end < len(runes) && runes[end] != '》' {
+				end++
+			}
+			if end == len(runes) {
+				return nil, errors.New("ruby is not closed (missing 》)")
+			}
+			ruby := string(runes[i+1 : end])
+			if ruby == "" || !IsKana(ruby) {
+				return nil, fmt.Errorf("ruby %q must be kana", ruby)
+			}
+
+			base := marker
+			if base < 0 {
+				base = len(plain)
+				for base > 0 && IsKanji(plain[base-1]) {
+					base--
+				}
+			}
+			if base == len(plain) {
+				return nil, fmt.Errorf("ruby %q has no base text", ruby)
+			}
+			if base > 0 {
+				segments = append(segments, Segment{Text: string(plain[:base])})
+			}
+			segments = append(segments, Segment{Text: string(plain[base:]), Reading: ruby})
+			plain, marker = nil, -1
+			i = end
+		default:
+			plain = append(plain, r)
+		}
+	}
+	if marker >= 0 {
+		return nil, errors.New("ruby base marker ｜ has no ruby")
+	}
+	if len(plain) > 0 {
+		segments = append(segments, Segment{Text: string(plain)})
+	}
+	return segments, nil
+}
+
+// Ruby は区間を青空文庫形式のルビ付きのテキストに戻す（ParseRuby の逆）
+func Ruby(segments []Segment) string {
+	var b strings.Builder
+	for i, s := range segments {
+		if s.Reading == "" {
+			b.WriteString(s.Text)
+			continue
+		}
+		// 漢字だけの区間の前が漢字で終わる場合と、漢字以外を含む区間には範囲の始まりを示す
+		if !onlyKanji(s.Text) || (i > 0 && endsWithKanji(segments[i-1].Text)) {
+			b.WriteRune('｜')
+		}
+		b.WriteString(s.Text)
+		b.WriteString("《" + s.Reading + "》")
+	}
+	return b.String()
+}
+
+func onlyKanji(s string) bool {
+	for _, r := range s {
+		if !IsKanji(r) {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func endsWithKanji(s string) bool {
+	runes := []rune(s)
+	return len(runes) > 0 && IsKanji(runes[len(runes)-1])
+}
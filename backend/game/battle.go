@@ -0,0 +1,269 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Phase はバトルの進行状態
+type Phase string
+
+const (
+	PhaseReady        Phase = "ready"         // ラウンド開始前
+	PhasePlaying      Phase = "playing"       // ラウンド中
+	PhaseRoundCleared Phase = "round_cleared" // 敵を倒し、次のラウンドの開始待ち
+	PhaseWon          Phase = "won"           // 最終ラウンドの敵を倒した
+	PhaseLost         Phase = "lost"          // HPが0になったか時間切れ
+)
+
+// EventKind はバトルに入力するイベントの種類
+type EventKind string
+
+const (
+	EventRoundStart    EventKind = "round_start"
+	EventWordCompleted EventKind = "word_completed"
+	EventMistake       EventKind = "mistake"
+	EventTick          EventKind = "tick" // 時間の経過（Seconds 秒）
+)
+
+// Event はバトルへの入力。JSON にしてイベント列として記録・再生できる
+type Event struct {
+	Kind     EventKind `json:"kind"`
+	WordType WordType  `json:"word_type,omitempty"` // EventWordCompleted のみ
+	Seconds  int       `json:"seconds,omitempty"`   // EventTick のみ
+}
+
+// RoundStart はラウンド開始のイベントを返す
+func RoundStart() Event { return Event{Kind: EventRoundStart} }
+
+// WordCompleted は単語の入力完了のイベントを返す
+func WordCompleted(t WordType) Event { return Event{Kind: EventWordCompleted, WordType: t} }
+
+// Mistake は入力ミスのイベントを返す
+func Mistake() Event { return Event{Kind: EventMistake} }
+
+// Tick は seconds 秒の経過のイベントを返す
+func Tick(seconds int) Event { return Event{Kind: EventTick, Seconds: seconds} }
+
+// Outcome はイベントによって起きた決着
+type Outcome string
+
+const (
+	OutcomeNone           Outcome = ""
+	OutcomeEnemyDefeated  Outcome = "enemy_defeated"
+	OutcomePlayerDefeated Outcome = "player_defeated"
+	OutcomeTimeout        Outcome = "timeout"
+)
+
+// ErrInvalidEvent は現在の状態では受け付けられないイベント
+var ErrInvalidEvent = errors.New("invalid event")
+
+// State はバトルの状態
+type State struct {
+	Phase          Phase          `json:"phase"`
+	Round          int            `json:"round"`
+	PlayerHP       int            `json:"player_hp"`
+	EnemyHP        int            `json:"enemy_hp"`
+	TimeLeft       int            `json:"time_left"` // 秒
+	Elapsed        int            `json:"elapsed"`   // ゲーム全体の経過秒数
+	Combo          int            `json:"combo"`
+	MaxCombo       int            `json:"max_combo"`
+	WordsCompleted int            `json:"words_completed"` // 現在のラウンドで入力した単語数
+	TotalWords     int            `json:"total_words"`     // ゲーム全体で入力した単語数
+	Mistakes       int            `json:"mistakes"`
+	Score          ScoreBreakdown `json:"score"`
+}
+
+// Step は1つのイベントを適用した結果
+type Step struct {
+	Damage       int            `json:"damage"`        // 敵に与えたダメージ
+	PlayerDamage int            `json:"player_damage"` // プレイヤーが受けたダメージ
+	Heal         int            `json:"heal"`          // プレイヤーが実際に回復したHP
+	TimeBonus    int            `json:"time_bonus"`
+	Score        ScoreBreakdown `json:"score"`
+	Outcome      Outcome        `json:"outcome,omitempty"`
+}
+
+// Battle はルールセットに従ってバトルを進める状態機械。並行して使うことはできない
+type Battle struct {
+	rules *Ruleset
+	mode  Mode
+	state State
+}
+
+// NewBattle は rules のバトルを開始前の状態で作る
+func NewBattle(rules *Ruleset) *Battle {
+	return &Battle{
+		rules: rules,
+		mode:  ModeStandard,
+		state: State{Phase: PhaseReady, PlayerHP: rules.PlayerMaxHP},
+	}
+}
+
+// NewEndlessBattle は rules のエンドレスモードのバトルを開始前の状態で作る。
+// ウェーブ（Round）は Ruleset.Wave で決まり、プレイヤーが負けるまで終わらない。
+// rules にエンドレスモードのルールがない場合はエラーを返す
+func NewEndlessBattle(rules *Ruleset) (*Battle, error) {
+	if !rules.SupportsEndless() {
+		return nil, fmt.Errorf("ruleset %s does not support endless mode", rules.Version)
+	}
+	b := NewBattle(rules)
+	b.mode = ModeEndless
+	return b, nil
+}
+
+// Rules はバトルのルールセットを返す
+func (b *Battle) Rules() *Ruleset { return b.rules }
+
+// Mode はバトルのモードを返す
+func (b *Battle) Mode() Mode { return b.mode }
+
+// State は現在の状態を返す
+func (b *Battle) State() State { return b.state }
+
+// Apply はイベントを適用する。受け付けられないイベントは ErrInvalidEvent を返し、状態は変わらない
+func (b *Battle) Apply(ev Event) (Step, error) {
+	switch ev.Kind {
+	case EventRoundStart:
+		return b.startRound()
+	case EventWordCompleted:
+		return b.completeWord(ev.WordType)
+	case EventMistake:
+		return b.mistake()
+	case EventTick:
+		return b.tick(ev.Seconds)
+	default:
+		return Step{}, fmt.Errorf("%w: unknown kind %q", ErrInvalidEvent, ev.Kind)
+	}
+}
+
+func (b *Battle) require(kind EventKind, phases ...Phase) error {
+	for _, p := range phases {
+		if b.state.Phase == p {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s in phase %s", ErrInvalidEvent, kind, b.state.Phase)
+}
+
+func (b *Battle) startRound() (Step, error) {
+	if err := b.require(EventRoundStart, PhaseReady, PhaseRoundCleared); err != nil {
+		return Step{}, err
+	}
+	s := &b.state
+	enemy, _ := b.rules.Enemy(s.Round + 1)
+	if b.mode == ModeEndless {
+		wave, _ := b.rules.Wave(s.Round + 1)
+		enemy = wave.Enemy
+	}
+	s.Round++
+	s.Phase = PhasePlaying
+	s.EnemyHP = enemy.MaxHP
+	s.TimeLeft = enemy.TimeLimitSeconds
+	s.Combo = 0
+	s.WordsCompleted = 0
+	return Step{}, nil
+}
+
+func (b *Battle) completeWord(t WordType) (Step, error) {
+	if err := b.require(EventWordCompleted, PhasePlaying); err != nil {
+		return Step{}, err
+	}
+	effect, ok := b.rules.Words[t]
+	if !ok {
+		return Step{}, fmt.Errorf("%w: unknown word type %q", ErrInvalidEvent, t)
+	}
+
+	s := &b.state
+	s.Combo++
+	s.MaxCombo = max(s.MaxCombo, s.Combo)
+	s.WordsCompleted++
+	s.TotalWords++
+
+	step := Step{
+		Damage:    b.rules.Damage(t, s.Combo),
+		Heal:      b.heal(effect.Heal),
+		TimeBonus: effect.TimeBonus,
+	}
+	step.Score = b.rules.Score(step.Damage, s.Combo, t, effect.TimeBonus)
+	s.Score = s.Score.Add(step.Score)
+	s.EnemyHP = max(0, s.EnemyHP-step.Damage)
+	s.TimeLeft += effect.TimeBonus
+
+	if s.EnemyHP == 0 {
+		step.Outcome = OutcomeEnemyDefeated
+		if b.mode == ModeStandard && s.Round >= b.rules.Rounds() {
+			s.Phase = PhaseWon
+		} else {
+			s.Phase = PhaseRoundCleared
+			step.Heal += b.heal(b.rules.RoundClearHeal)
+		}
+	}
+	return step, nil
+}
+
+func (b *Battle) mistake() (Step, error) {
+	if err := b.require(EventMistake, PhasePlaying); err != nil {
+		return Step{}, err
+	}
+	s := &b.state
+	step := Step{PlayerDamage: min(s.PlayerHP, b.rules.MistakeDamage)}
+	s.PlayerHP -= step.PlayerDamage
+	s.Combo = 0
+	s.Mistakes++
+	if s.PlayerHP == 0 {
+		s.Phase = PhaseLost
+		step.Outcome = OutcomePlayerDefeated
+	}
+	return step, nil
+}
+
+func (b *Battle) tick(seconds int) (Step, error) {
+	if err := b.require(EventTick, PhasePlaying); err != nil {
+		return Step{}, err
+	}
+	if seconds <= 0 {
+		return Step{}, fmt.Errorf("%w: tick of %d seconds", ErrInvalidEvent, seconds)
+	}
+	s := &b.state
+	elapsed := min(seconds, s.TimeLeft)
+	s.TimeLeft -= elapsed
+	s.Elapsed += elapsed
+	if s.TimeLeft == 0 {
+		s.Phase = PhaseLost
+		return Step{Outcome: OutcomeTimeout}, nil
+	}
+	return Step{}, nil
+}
+
+// heal はプレイヤーのHPを最大HPまで回復し、実際に回復した量を返す
+func (b *Battle) heal(amount int) int {
+	s := &b.state
+	healed := min(b.rules.PlayerMaxHP-s.PlayerHP, amount)
+	s.PlayerHP += healed
+	return healed
+}
+
+// Replay は rules のバトルにイベント列を順に適用し、最後の状態を返す。
+// 途中で受け付けられないイベントがあれば、その位置をエラーに含める
+func Replay(rules *Ruleset, events []Event) (State, error) {
+	return NewBattle(rules).replay(events)
+}
+
+// ReplayEndless は Replay のエンドレスモード版
+func ReplayEndless(rules *Ruleset, events []Event) (State, error) {
+	b, err := NewEndlessBattle(rules)
+	if err != nil {
+		return State{}, err
+	}
+	return b.replay(events)
+}
+
+func (b *Battle) replay(events []Event) (State, error) {
+	for i, ev := range events {
+		if _, err := b.Apply(ev); err != nil {
+			return b.State(), fmt.Errorf("events[%d]: %w", i, err)
+		}
+	}
+	return b.State(), nil
+}
@@ -0,0 +1,145 @@
+package game
+
+import (
+	"errors"
+	"testing"
+)
+
+func builtinV1(t *testing.T) *Ruleset {
+	t.Helper()
+	rules, err := Builtin("v1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rules
+}
+
+// TestScoreMatchesFrontend は calculateScore（GameLogic.tsx）と同じ値になることを確認する
+func TestScoreMatchesFrontend(t *testing.T) {
+	rules := builtinV1(t)
+	tests := []struct {
+		name      string
+		wordType  WordType
+		combo     int
+		wantDmg   int
+		wantScore ScoreBreakdown
+	}{
+		{name: "normal", wordType: Normal, combo: 1, wantDmg: 20, wantScore: ScoreBreakdown{Base: 200, Total: 200}},
+		{name: "combo 3", wordType: Normal, combo: 3, wantDmg: 24, wantScore: ScoreBreakdown{Base: 240, Combo: 50, Total: 290}},
+		// (5-2)^1.5*50 = 259.8...
+		{name: "combo 5", wordType: Normal, combo: 5, wantDmg: 32, wantScore: ScoreBreakdown{Base: 320, Combo: 259, Total: 579}},
+		{name: "bonus", wordType: Bonus, combo: 1, wantDmg: 40, wantScore: ScoreBreakdown{Base: 400, Special: 200, Time: 100, Total: 700}},
+		{name: "debuff", wordType: Debuff, combo: 2, wantDmg: 10, wantScore: ScoreBreakdown{Base: 100, Special: 100, Total: 200}},
+		// 倍率は 1+(12-2)*0.2 = 3 で頭打ちになる
+		{name: "combo cap", wordType: Normal, combo: 20, wantDmg: 60},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dmg := rules.Damage(tt.wordType, tt.combo)
+			if dmg != tt.wantDmg {
+				t.Errorf("damage = %d, want %d", dmg, tt.wantDmg)
+			}
+			if tt.wantScore.Total == 0 {
+				return
+			}
+			if got := rules.Score(dmg, tt.combo, tt.wordType, rules.Words[tt.wordType].TimeBonus); got != tt.wantScore {
+				t.Errorf("score = %+v, want %+v", got, tt.wantScore)
+			}
+		})
+	}
+}
+
+func TestBattle(t *testing.T) {
+	rules := builtinV1(t)
+	t.Run("round clear heals and starts the next enemy", func(t *testing.T) {
+		b := NewBattle(rules)
+		events := []Event{RoundStart(), Mistake(), Mistake()}
+		for i := 0; i < 5; i++ {
+			events = append(events, WordCompleted(Normal))
+		}
+		for _, ev := range events {
+			if _, err := b.Apply(ev); err != nil {
+				t.Fatal(err)
+			}
+		}
+		// ミスでコンボが途切れた後の5単語: 20+20+24+28+32 = 124
+		s := b.State()
+		if s.Phase != PhaseRoundCleared || s.EnemyHP != 0 || s.PlayerHP != 90 {
+			t.Fatalf("state = %+v, want round cleared with 70+20 HP", s)
+		}
+		if _, err := b.Apply(RoundStart()); err != nil {
+			t.Fatal(err)
+		}
+		if s := b.State(); s.Round != 2 || s.EnemyHP != 120 || s.TimeLeft != 45 || s.Combo != 0 {
+			t.Errorf("state = %+v, want round 2 against a fresh enemy", s)
+		}
+	})
+
+	t.Run("bonus word adds time", func(t *testing.T) {
+		s, err := Replay(rules, []Event{RoundStart(), Tick(10), WordCompleted(Bonus)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s.TimeLeft != 45 || s.Elapsed != 10 || s.Score.Time != 100 {
+			t.Errorf("state = %+v, want 45 seconds left", s)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		b := NewBattle(rules)
+		b.Apply(RoundStart())
+		step, err := b.Apply(Tick(60))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if step.Outcome != OutcomeTimeout || b.State().Phase != PhaseLost || b.State().Elapsed != 50 {
+			t.Errorf("step = %+v, state = %+v, want a timeout after 50 seconds", step, b.State())
+		}
+	})
+
+	t.Run("player defeated", func(t *testing.T) {
+		events := []Event{RoundStart()}
+		for i := 0; i < 7; i++ {
+			events = append(events, Mistake())
+		}
+		s, err := Replay(rules, events)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s.Phase != PhaseLost || s.PlayerHP != 0 {
+			t.Errorf("state = %+v, want lost", s)
+		}
+	})
+
+	t.Run("win after the final round", func(t *testing.T) {
+		b := NewBattle(rules)
+		for range rules.Enemies {
+			b.Apply(RoundStart())
+			for b.State().Phase == PhasePlaying {
+				if _, err := b.Apply(WordCompleted(Normal)); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}
+		if s := b.State(); s.Phase != PhaseWon || s.Round != 5 || s.Score.Total != s.Score.Base+s.Score.Combo {
+			t.Errorf("state = %+v, want won after round 5", s)
+		}
+		if _, err := b.Apply(WordCompleted(Normal)); !errors.Is(err, ErrInvalidEvent) {
+			t.Errorf("err = %v, want ErrInvalidEvent after the game is won", err)
+		}
+	})
+
+	t.Run("invalid events", func(t *testing.T) {
+		for _, events := range [][]Event{
+			{WordCompleted(Normal)},
+			{RoundStart(), RoundStart()},
+			{RoundStart(), WordCompleted("rare")},
+			{RoundStart(), Tick(0)},
+		} {
+			if _, err := Replay(rules, events); !errors.Is(err, ErrInvalidEvent) {
+				t.Errorf("Replay(%v) err = %v, want ErrInvalidEvent", events, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,149 @@
+package game
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// WordModifier はエンドレスモードで単語を使い回すときに加える変化
+type WordModifier string
+
+const (
+	// ModifierCompound は通常の単語を2つつなげて1つの単語にする
+	ModifierCompound WordModifier = "compound"
+	// ModifierNoBonus はボーナス単語を出題しない
+	ModifierNoBonus WordModifier = "no_bonus"
+)
+
+// EndlessRules はエンドレスモードのウェーブの作り方。
+// 最初のウェーブは Enemies と同じで、最後の敵より後は最後の敵をもとに HP を増やし、制限時間を減らす
+type EndlessRules struct {
+	// HPGrowth は最後の敵より後のウェーブごとの HP の増加率（0.1 で 10% ずつ）
+	HPGrowth float64 `json:"hp_growth"`
+	// MaxHP は敵の HP の上限
+	MaxHP int `json:"max_hp"`
+	// TimeDecaySeconds は最後の敵より後のウェーブごとに減らす制限時間
+	TimeDecaySeconds    int `json:"time_decay_seconds"`
+	MinTimeLimitSeconds int `json:"min_time_limit_seconds"`
+	// Modifiers はラウンドの単語を一巡するごとに先頭から1つずつ加える変化（2巡目は先頭の1つ、3巡目は先頭の2つ）
+	Modifiers []WordModifier `json:"modifiers"`
+}
+
+func (e *EndlessRules) validate() error {
+	var errs []error
+	if e.HPGrowth < 0 {
+		errs = append(errs, errors.New("endless.hp_growth must not be negative"))
+	}
+	if e.MaxHP <= 0 || e.MinTimeLimitSeconds <= 0 || e.TimeDecaySeconds < 0 {
+		errs = append(errs, errors.New("endless.max_hp and endless.min_time_limit_seconds must be positive and endless.time_decay_seconds must not be negative"))
+	}
+	for i, m := range e.Modifiers {
+		if m != ModifierCompound && m != ModifierNoBonus {
+			errs = append(errs, fmt.Errorf("endless.modifiers[%d]: unknown modifier %q", i, m))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Wave はエンドレスモードの1ウェーブ
+type Wave struct {
+	Wave  int   `json:"wave"`
+	Enemy Enemy `json:"enemy"`
+	// Round は単語を出題するラウンド
+	Round     int            `json:"round"`
+	Modifiers []WordModifier `json:"modifiers"`
+}
+
+// SupportsEndless はルールセットにエンドレスモードのルールがあるかを返す
+func (r *Ruleset) SupportsEndless() bool {
+	return r.Endless != nil
+}
+
+// Wave は wave（1から）のウェーブを返す。エンドレスモードのルールがない場合は false を返す。
+// 単語はラウンド 1 から順に難しくなり、最後のラウンドの後はラウンド 1 に戻って変化を加える
+func (r *Ruleset) Wave(wave int) (Wave, bool) {
+	if r.Endless == nil || wave < 1 {
+		return Wave{}, false
+	}
+	rounds := r.Rounds()
+	w := Wave{Wave: wave, Round: (wave-1)%rounds + 1, Modifiers: []WordModifier{}}
+	if cycle := (wave - 1) / rounds; cycle > 0 {
+		w.Modifiers = append(w.Modifiers, r.Endless.Modifiers[:min(cycle, len(r.Endless.Modifiers))]...)
+	}
+
+	if wave <= rounds {
+		w.Enemy = r.Enemies[wave-1]
+		return w, true
+	}
+	// 見た目はラウンド順の敵を繰り返し、強さは最後の敵から上げていく
+	over := wave - rounds
+	last := r.Enemies[rounds-1]
+	w.Enemy = r.Enemies[w.Round-1]
+	w.Enemy.MaxHP = r.Endless.MaxHP
+	if hp := float64(last.MaxHP) * math.Pow(1+r.Endless.HPGrowth, float64(over)); hp < float64(r.Endless.MaxHP) {
+		w.Enemy.MaxHP = int(hp)
+	}
+	w.Enemy.TimeLimitSeconds = max(r.Endless.MinTimeLimitSeconds, last.TimeLimitSeconds-over*r.Endless.TimeDecaySeconds)
+	return w, true
+}
+
+// wordDamageRange は単語1つで敵に与えるダメージの最小（コンボなし）と最大（コンボ倍率の上限）を返す
+func (r *Ruleset) wordDamageRange() (least, most int) {
+	least = math.MaxInt
+	for t, effect := range r.Words {
+		least = min(least, r.Damage(t, 1))
+		most = max(most, int(math.Floor(float64(effect.Damage)*r.Combo.MaxMultiplier)))
+	}
+	return least, most
+}
+
+// minWaveMs は wave の敵を倒すのにかかる最短の時間（ミリ秒）。
+// 敵の HP を単語1つの最大のダメージで削り切る単語数を、1単語1打鍵・MaxWPM の速さで入力したとみなす
+func (r *Ruleset) minWaveMs(wave int) float64 {
+	w, _ := r.Wave(wave)
+	_, most := r.wordDamageRange()
+	words := (w.Enemy.MaxHP + most - 1) / most
+	return float64(words) * 60000 / float64(MaxWPM*5)
+}
+
+// MaxWaves は seconds 秒（送信された時間は秒に丸められているため、1秒の余裕を持たせる）のプレイで
+// 到達できるウェーブの上限を返す。エンドレスモードのルールがない場合は0
+func (r *Ruleset) MaxWaves(seconds int) int {
+	if r.Endless == nil {
+		return 0
+	}
+	budget := float64(seconds+1) * 1000
+	wave := 1
+	for spent := r.minWaveMs(1); spent <= budget; spent += r.minWaveMs(wave) {
+		wave++
+	}
+	return wave
+}
+
+// MaxEndlessScore は wave に到達したプレイで得られるスコアの上限を返す（到達したウェーブで得たスコアを含む）。
+// 各ウェーブの基本スコアは、敵の HP を削り切るまでのダメージ（最後の1単語は最大のダメージ）から、
+// コンボ・特殊単語・時間ボーナスは、最小のダメージで入力できる単語数（コンボはウェーブごとに0に戻る）から求める
+func (r *Ruleset) MaxEndlessScore(wave int) int {
+	least, most := r.wordDamageRange()
+	perWord := 0
+	for t, effect := range r.Words {
+		perWord = max(perWord, r.Score(0, 0, t, effect.TimeBonus).Total)
+	}
+
+	// comboTotals[n] はコンボ1〜n のコンボボーナスの合計
+	comboTotals := []int{0}
+	total := 0
+	for i := 1; i <= wave; i++ {
+		w, ok := r.Wave(i)
+		if !ok {
+			return 0
+		}
+		words := (w.Enemy.MaxHP + least - 1) / least
+		for n := len(comboTotals); n <= words; n++ {
+			comboTotals = append(comboTotals, comboTotals[n-1]+r.Score(0, n, Normal, 0).Combo)
+		}
+		total += (w.Enemy.MaxHP-1+most)*r.Scoring.PerDamage + comboTotals[words] + words*perWord
+	}
+	return total
+}
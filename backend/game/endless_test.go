@@ -0,0 +1,120 @@
+package game
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestWave(t *testing.T) {
+	rules := builtinV1(t)
+	tests := []struct {
+		wave      int
+		wantHP    int
+		wantTime  int
+		wantRound int
+		wantMods  []WordModifier
+	}{
+		{wave: 1, wantHP: 100, wantTime: 50, wantRound: 1, wantMods: []WordModifier{}},
+		{wave: 5, wantHP: 300, wantTime: 30, wantRound: 5, wantMods: []WordModifier{}},
+		{wave: 6, wantHP: 330, wantTime: 29, wantRound: 1, wantMods: []WordModifier{ModifierCompound}},
+		// 300 * 1.1^6 = 531.4...
+		{wave: 11, wantHP: 531, wantTime: 24, wantRound: 1, wantMods: []WordModifier{ModifierCompound, ModifierNoBonus}},
+		{wave: 100, wantHP: 3000, wantTime: 15, wantRound: 5, wantMods: []WordModifier{ModifierCompound, ModifierNoBonus}},
+	}
+	for _, tt := range tests {
+		w, ok := rules.Wave(tt.wave)
+		if !ok {
+			t.Fatalf("Wave(%d) not found", tt.wave)
+		}
+		if w.Enemy.MaxHP != tt.wantHP || w.Enemy.TimeLimitSeconds != tt.wantTime || w.Round != tt.wantRound || !slices.Equal(w.Modifiers, tt.wantMods) {
+			t.Errorf("Wave(%d) = hp %d, time %d, round %d, modifiers %v; want %d, %d, %d, %v",
+				tt.wave, w.Enemy.MaxHP, w.Enemy.TimeLimitSeconds, w.Round, w.Modifiers, tt.wantHP, tt.wantTime, tt.wantRound, tt.wantMods)
+		}
+	}
+	if w, _ := rules.Wave(6); w.Enemy.Icon != rules.Enemies[0].Icon {
+		t.Errorf("wave 6 icon = %s, want the first enemy's", w.Enemy.Icon)
+	}
+
+	noEndless := rules.Clone()
+	noEndless.Endless = nil
+	if _, ok := noEndless.Wave(1); ok {
+		t.Error("Wave without endless rules: want false")
+	}
+	if _, err := NewEndlessBattle(noEndless); err == nil {
+		t.Error("NewEndlessBattle without endless rules: want error")
+	}
+}
+
+func TestEndlessBattle(t *testing.T) {
+	rules := builtinV1(t)
+	b, err := NewEndlessBattle(rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for wave := 1; wave <= rules.Rounds()+1; wave++ {
+		if _, err := b.Apply(RoundStart()); err != nil {
+			t.Fatal(err)
+		}
+		for b.State().Phase == PhasePlaying {
+			if _, err := b.Apply(WordCompleted(Normal)); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if s := b.State(); s.Phase != PhaseRoundCleared || s.Round != 6 {
+		t.Fatalf("state = %+v, want wave 6 cleared", s)
+	}
+	b.Apply(RoundStart())
+	if s := b.State(); s.Round != 7 || s.EnemyHP != 363 || s.TimeLeft != 28 {
+		t.Errorf("state = %+v, want wave 7 with 363 HP and 28 seconds", s)
+	}
+}
+
+func TestEndlessLimits(t *testing.T) {
+	rules := builtinV1(t)
+
+	// 最初の2ウェーブ（HP 100・120）は単語1つ（最大のダメージは 40 × 3 = 120）、MaxWPM で 48ms ずつ
+	if got := rules.MaxWaves(0); got < 3 {
+		t.Errorf("MaxWaves(0) = %d, want at least 3", got)
+	}
+	prev := 0
+	for _, seconds := range []int{0, 10, 60, 600, 3600} {
+		got := rules.MaxWaves(seconds)
+		if got <= prev {
+			t.Errorf("MaxWaves(%d) = %d, want more than %d", seconds, got, prev)
+		}
+		prev = got
+	}
+	// HP が上限（3000）のウェーブは25単語で 1.2 秒かかるため、3600 秒では 3000 ウェーブ程度
+	if got := rules.MaxWaves(3600); got > 3100 {
+		t.Errorf("MaxWaves(3600) = %d, want about 3000", got)
+	}
+
+	// 実際のプレイ（ダメージが最も小さく単語数が最も多いデバフ単語だけ・ミスなし）のスコアは上限を超えない
+	b, err := NewEndlessBattle(rules)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for wave := 1; wave <= 12; wave++ {
+		if _, err := b.Apply(RoundStart()); err != nil {
+			t.Fatal(err)
+		}
+		for b.State().Phase == PhasePlaying {
+			if _, err := b.Apply(WordCompleted(Debuff)); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if score, limit := b.State().Score.Total, rules.MaxEndlessScore(wave); score > limit {
+			t.Errorf("wave %d: score %d exceeds MaxEndlessScore %d", wave, score, limit)
+		}
+	}
+	if rules.MaxEndlessScore(2) <= rules.MaxEndlessScore(1) {
+		t.Error("MaxEndlessScore does not grow with the waves")
+	}
+
+	noEndless := rules.Clone()
+	noEndless.Endless = nil
+	if got := noEndless.MaxWaves(60); got != 0 {
+		t.Errorf("MaxWaves without endless rules = %d, want 0", got)
+	}
+}
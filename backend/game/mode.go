@@ -0,0 +1,32 @@
+package game
+
+import "slices"
+
+// Mode はゲームのモード
+type Mode string
+
+const (
+	ModeStandard   Mode = "standard"    // 固定のラウンド（敵の数）をクリアする
+	ModeEndless    Mode = "endless"     // 負けるまで強くなる敵が続く
+	ModeTimeAttack Mode = "time_attack" // 制限時間内にできるだけ多く入力する（タイピングテスト）
+	ModePractice   Mode = "practice"    // 決まった数の単語をできるだけ速く入力する（タイピングテスト）
+	ModePassage    Mode = "passage"     // 複数の文からなる文章を1文ずつ入力する
+)
+
+// Modes はプレイできるモード
+var Modes = []Mode{ModeStandard, ModeEndless, ModeTimeAttack, ModePractice, ModePassage}
+
+// Valid は m がプレイできるモードかを返す
+func (m Mode) Valid() bool {
+	return slices.Contains(Modes, m)
+}
+
+// Battle は m がバトル（ルールセットの敵と戦う）のモードかを返す
+func (m Mode) Battle() bool {
+	return m == ModeStandard || m == ModeEndless
+}
+
+// Typing は m がタイピングテスト（TypingStats で記録する）のモードかを返す
+func (m Mode) Typing() bool {
+	return m == ModeTimeAttack || m == ModePractice
+}
@@ -0,0 +1,34 @@
+package game
+
+import "math"
+
+// SentenceResult は文章モードの1文の入力の記録
+type SentenceResult struct {
+	// Characters は正しく入力した打鍵数、Keystrokes は間違いを含むすべての打鍵数
+	Characters int `json:"characters" dynamodbav:"characters"`
+	Keystrokes int `json:"keystrokes" dynamodbav:"keystrokes"`
+	ElapsedMs  int `json:"elapsed_ms" dynamodbav:"elapsed_ms"`
+}
+
+// PassageProgress は文章モードの進捗。Sentences は文章の先頭から入力を完了した文ごとの記録
+type PassageProgress struct {
+	PassageID string           `json:"passage_id" dynamodbav:"passage_id"`
+	Sentences []SentenceResult `json:"sentences" dynamodbav:"sentences"`
+}
+
+// Stats は入力を完了した文の記録の合計を返す（Words は完了した文の数）
+func (p PassageProgress) Stats() TypingStats {
+	stats := TypingStats{Words: len(p.Sentences)}
+	for _, s := range p.Sentences {
+		stats.Characters += s.Characters
+		stats.Keystrokes += s.Keystrokes
+		stats.ElapsedMs += s.ElapsedMs
+	}
+	return stats
+}
+
+// PassageScore は文章モードのスコア。入力を完了した文の文字数（読みの文字数）の合計に正確さをかけた値の10倍で、
+// 長い文章を最後まで正確に入力するほど高くなる
+func PassageScore(characters int, accuracy float64) int {
+	return int(math.Round(float64(characters) * accuracy * 10))
+}
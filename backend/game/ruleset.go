@@ -0,0 +1,156 @@
+// Package game はバトルのルール（敵のHP・制限時間・ダメージ・コンボ・スコア計算）をフロントエンドと同じ内容で実装する。
+// バトルは Battle の状態機械で表し、同じルールセットと同じイベント列からは常に同じ結果になる。
+// バックエンドでのスコアの検証・シミュレーション・バランス調整に使う
+package game
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// WordType は単語の種類（words テーブルの type と同じ値）
+type WordType string
+
+const (
+	Normal WordType = "normal"
+	Bonus  WordType = "bonus"
+	Debuff WordType = "debuff"
+)
+
+// Enemy はラウンドごとの敵（frontend/src/components/GameData.ts の ENEMY_DATA）
+type Enemy struct {
+	Name             map[string]string `json:"name"` // 言語（jp / en）ごとの名前
+	Icon             string            `json:"icon"`
+	DefeatedIcon     string            `json:"defeated_icon"`
+	MaxHP            int               `json:"max_hp"`
+	TimeLimitSeconds int               `json:"time_limit_seconds"`
+	BackgroundImage  string            `json:"background_image"`
+	// BackgroundOverlay は背景に重ねるCSSクラス
+	BackgroundOverlay string `json:"background_overlay,omitempty"`
+	Theme             string `json:"theme,omitempty"`
+}
+
+// WordEffect は単語を正しく入力したときの効果
+type WordEffect struct {
+	Damage    int `json:"damage"`     // 敵へのダメージ（コンボ倍率をかける前）
+	Heal      int `json:"heal"`       // プレイヤーのHP回復
+	TimeBonus int `json:"time_bonus"` // 残り時間に加える秒数
+	// ScoreBonus は特殊単語のスコアボーナス（calculateScore の specialBonus）
+	ScoreBonus int `json:"score_bonus"`
+}
+
+// ComboRules はコンボによるダメージ倍率とスコアボーナス
+type ComboRules struct {
+	// Threshold 以上のコンボでボーナスが付く
+	Threshold int `json:"threshold"`
+	// DamageStep はコンボ1つごとのダメージ倍率の増分（倍率は 1 + (combo - (Threshold-1)) * DamageStep）
+	DamageStep float64 `json:"damage_step"`
+	// MaxMultiplier はダメージ倍率の上限
+	MaxMultiplier float64 `json:"max_multiplier"`
+	// スコアボーナスは (combo - (Threshold-1))^ScoreExponent * ScoreBase
+	ScoreBase     float64 `json:"score_base"`
+	ScoreExponent float64 `json:"score_exponent"`
+}
+
+// ScoreRules は1単語あたりのスコアの計算（calculateScore）
+type ScoreRules struct {
+	PerDamage      int `json:"per_damage"`       // ダメージ1あたりの基本スコア
+	PerBonusSecond int `json:"per_bonus_second"` // 時間ボーナス1秒あたりのスコア
+}
+
+// SpecialWords は出題時に特殊単語を選ぶ確率
+type SpecialWords struct {
+	Chance     float64 `json:"chance"`      // 特殊単語を選ぶ確率
+	BonusShare float64 `json:"bonus_share"` // 特殊単語のうちボーナス単語の割合（残りはデバフ単語）
+}
+
+// Ruleset はバトルのルール一式。スコアの記録と照合できるよう Version で区別する。
+// 組み込みのルールセットは rulesets/<version>.json で、一度公開したバージョンの値は変更しない
+type Ruleset struct {
+	Version string `json:"version"`
+
+	PlayerMaxHP int `json:"player_max_hp"`
+	// MistakeDamage は入力を間違えたときにプレイヤーが受けるダメージ
+	MistakeDamage int `json:"mistake_damage"`
+	// RoundClearHeal は敵を倒したときのプレイヤーのHP回復（最大HPまで）
+	RoundClearHeal int `json:"round_clear_heal"`
+
+	// Enemies はラウンド順の敵。ラウンド数は len(Enemies)
+	Enemies []Enemy                 `json:"enemies"`
+	Words   map[WordType]WordEffect `json:"words"`
+	Special SpecialWords            `json:"special_words"`
+	Combo   ComboRules              `json:"combo"`
+	Scoring ScoreRules              `json:"scoring"`
+	// Endless はエンドレスモードのルール（ない場合はエンドレスモードでプレイできない）
+	Endless *EndlessRules `json:"endless,omitempty"`
+}
+
+// Rounds はラウンド数を返す
+func (r *Ruleset) Rounds() int {
+	return len(r.Enemies)
+}
+
+// Enemy は round（1から）の敵を返す
+func (r *Ruleset) Enemy(round int) (Enemy, bool) {
+	if round < 1 || round > len(r.Enemies) {
+		return Enemy{}, false
+	}
+	return r.Enemies[round-1], true
+}
+
+// Clone は値を変更しても元のルールセットに影響しないコピーを返す
+func (r *Ruleset) Clone() *Ruleset {
+	c := *r
+	c.Enemies = slices.Clone(r.Enemies)
+	c.Words = maps.Clone(r.Words)
+	if r.Endless != nil {
+		endless := *r.Endless
+		endless.Modifiers = slices.Clone(r.Endless.Modifiers)
+		c.Endless = &endless
+	}
+	return &c
+}
+
+// Validate はルールセットの値を検証し、問題をまとめて1つのエラーとして返す
+func (r *Ruleset) Validate() error {
+	var errs []error
+	if r.Version == "" {
+		errs = append(errs, errors.New("version must not be empty"))
+	}
+	if r.PlayerMaxHP <= 0 {
+		errs = append(errs, fmt.Errorf("player_max_hp must be positive, got %d", r.PlayerMaxHP))
+	}
+	if r.MistakeDamage < 0 || r.RoundClearHeal < 0 {
+		errs = append(errs, errors.New("mistake_damage and round_clear_heal must not be negative"))
+	}
+	if len(r.Enemies) == 0 {
+		errs = append(errs, errors.New("enemies must not be empty"))
+	}
+	for i, e := range r.Enemies {
+		if e.MaxHP <= 0 || e.TimeLimitSeconds <= 0 {
+			errs = append(errs, fmt.Errorf("enemies[%d]: max_hp and time_limit_seconds must be positive", i))
+		}
+	}
+	for _, t := range []WordType{Normal, Bonus, Debuff} {
+		effect, ok := r.Words[t]
+		switch {
+		case !ok:
+			errs = append(errs, fmt.Errorf("words.%s is missing", t))
+		case effect.Damage <= 0:
+			// エンドレスモードのスコアの検証は、単語ごとに必ず敵の HP が減ることを前提にする
+			errs = append(errs, fmt.Errorf("words.%s.damage must be positive, got %d", t, effect.Damage))
+		}
+	}
+	if r.Special.Chance < 0 || r.Special.Chance > 1 || r.Special.BonusShare < 0 || r.Special.BonusShare > 1 {
+		errs = append(errs, errors.New("special_words.chance and special_words.bonus_share must be between 0 and 1"))
+	}
+	if r.Combo.Threshold < 1 || r.Combo.MaxMultiplier < 1 {
+		errs = append(errs, errors.New("combo.threshold and combo.max_multiplier must be at least 1"))
+	}
+	if r.Endless != nil {
+		errs = append(errs, r.Endless.validate())
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,126 @@
+package game
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+)
+
+//go:embed rulesets/*.json
+var builtin embed.FS
+
+// Options はルールセットの読み込みの設定
+type Options struct {
+	// Dir のJSONファイル（1ファイルに1つのルールセット）を組み込みのルールセットに追加する
+	Dir string
+	// Current は新しいゲームに使うバージョン
+	Current string
+}
+
+// Rulesets はバージョンごとのルールセット。
+// 過去のスコアを記録時のルールで扱えるよう、現在のバージョン以外も残す
+type Rulesets struct {
+	byVersion map[string]*Ruleset
+	versions  []string
+	current   *Ruleset
+}
+
+// Load は組み込みのルールセットと opts.Dir のルールセットを読み込む。
+// 同じバージョンが複数ある場合や、opts.Current のルールセットがない場合はエラーを返す
+func Load(opts Options) (*Rulesets, error) {
+	r := &Rulesets{byVersion: make(map[string]*Ruleset)}
+	if err := r.addDir(builtin, "rulesets"); err != nil {
+		return nil, err
+	}
+	if opts.Dir != "" {
+		if err := r.addDir(os.DirFS(opts.Dir), "."); err != nil {
+			return nil, fmt.Errorf("rulesets dir %s: %w", opts.Dir, err)
+		}
+	}
+	sort.Strings(r.versions)
+
+	current, ok := r.byVersion[opts.Current]
+	if !ok {
+		return nil, fmt.Errorf("ruleset %q not found (available: %v)", opts.Current, r.versions)
+	}
+	r.current = current
+	return r, nil
+}
+
+// Builtin は組み込みのルールセットを返す（テスト・シミュレーション用）
+func Builtin(version string) (*Ruleset, error) {
+	data, err := builtin.ReadFile("rulesets/" + version + ".json")
+	if err != nil {
+		return nil, fmt.Errorf("builtin ruleset %q not found", version)
+	}
+	return Parse(data)
+}
+
+func (r *Rulesets) addDir(fsys fs.FS, dir string) error {
+	names, err := fs.Glob(fsys, path.Join(dir, "*.json"))
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return fmt.Errorf("failed to read ruleset %s: %w", name, err)
+		}
+		rs, err := Parse(data)
+		if err != nil {
+			return fmt.Errorf("invalid ruleset %s: %w", name, err)
+		}
+		if _, ok := r.byVersion[rs.Version]; ok {
+			return fmt.Errorf("ruleset %s: version %q is already defined", name, rs.Version)
+		}
+		r.byVersion[rs.Version] = rs
+		r.versions = append(r.versions, rs.Version)
+	}
+	return nil
+}
+
+// Parse はJSONのルールセットを読み込んで検証する。定義にない項目はエラーにする
+func Parse(data []byte) (*Ruleset, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var rs Ruleset
+	if err := dec.Decode(&rs); err != nil {
+		return nil, err
+	}
+	if err := rs.Validate(); err != nil {
+		return nil, err
+	}
+	return &rs, nil
+}
+
+// Current は新しいゲームに使うルールセットを返す
+func (r *Rulesets) Current() *Ruleset { return r.current }
+
+// Get は version のルールセットを返す
+func (r *Rulesets) Get(version string) (*Ruleset, bool) {
+	rs, ok := r.byVersion[version]
+	return rs, ok
+}
+
+// All はすべてのルールセットをバージョン順に返す
+func (r *Rulesets) All() []*Ruleset {
+	all := make([]*Ruleset, 0, len(r.versions))
+	for _, v := range r.versions {
+		all = append(all, r.byVersion[v])
+	}
+	return all
+}
+
+// MaxRounds はすべてのルールセットのうち最も多いラウンド数を返す
+func (r *Rulesets) MaxRounds() int {
+	rounds := 0
+	for _, rs := range r.byVersion {
+		rounds = max(rounds, rs.Rounds())
+	}
+	return rounds
+}
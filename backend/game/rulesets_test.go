@@ -0,0 +1,82 @@
+package game
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	v1 := builtinV1(t)
+
+	writeRuleset := func(t *testing.T, dir string, rs Ruleset) {
+		t.Helper()
+		data, err := json.Marshal(rs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, rs.Version+".json"), data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("builtin", func(t *testing.T) {
+		rulesets, err := Load(Options{Current: "v1"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rulesets.Current().Version != "v1" || rulesets.MaxRounds() != 5 {
+			t.Errorf("current = %s with %d rounds, want v1 with 5", rulesets.Current().Version, rulesets.MaxRounds())
+		}
+	})
+
+	t.Run("directory", func(t *testing.T) {
+		dir := t.TempDir()
+		v2 := *v1
+		v2.Version = "v2"
+		v2.Enemies = append(v2.Enemies, Enemy{Name: map[string]string{"jp": "隠しボス"}, MaxHP: 500, TimeLimitSeconds: 30})
+		writeRuleset(t, dir, v2)
+
+		rulesets, err := Load(Options{Dir: dir, Current: "v2"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(rulesets.All()) != 2 || rulesets.Current().Rounds() != 6 || rulesets.MaxRounds() != 6 {
+			t.Errorf("got %d rulesets, current has %d rounds, want v1 and v2 with 6 rounds", len(rulesets.All()), rulesets.Current().Rounds())
+		}
+		if _, ok := rulesets.Get("v1"); !ok {
+			t.Error("builtin v1 should still be available")
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		duplicate := t.TempDir()
+		writeRuleset(t, duplicate, *v1)
+		invalid := t.TempDir()
+		broken := *v1
+		broken.Version = "broken"
+		broken.Enemies = nil
+		writeRuleset(t, invalid, broken)
+
+		for _, tt := range []struct {
+			opts Options
+			want string
+		}{
+			{Options{Current: "v9"}, "not found"},
+			{Options{Dir: duplicate, Current: "v1"}, "already defined"},
+			{Options{Dir: invalid, Current: "v1"}, "enemies must not be empty"},
+		} {
+			if _, err := Load(tt.opts); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Load(%+v) err = %v, want %q", tt.opts, err, tt.want)
+			}
+		}
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		if _, err := Parse([]byte(`{"version":"v1","max_rounds":5}`)); err == nil {
+			t.Error("unknown fields should be rejected")
+		}
+	})
+}
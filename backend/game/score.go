@@ -0,0 +1,54 @@
+package game
+
+import "math"
+
+// ScoreBreakdown はスコアの内訳（calculateScore の各項）
+type ScoreBreakdown struct {
+	Base    int `json:"base"`    // ダメージ × PerDamage
+	Combo   int `json:"combo"`   // コンボボーナス
+	Special int `json:"special"` // 特殊単語のボーナス
+	Time    int `json:"time"`    // 時間ボーナス × PerBonusSecond
+	Total   int `json:"total"`
+}
+
+// Add は内訳を項目ごとに足し合わせる
+func (b ScoreBreakdown) Add(o ScoreBreakdown) ScoreBreakdown {
+	return ScoreBreakdown{
+		Base:    b.Base + o.Base,
+		Combo:   b.Combo + o.Combo,
+		Special: b.Special + o.Special,
+		Time:    b.Time + o.Time,
+		Total:   b.Total + o.Total,
+	}
+}
+
+// ComboMultiplier は combo でのダメージ倍率を返す（Threshold 未満は1倍、上限は MaxMultiplier）
+func (r *Ruleset) ComboMultiplier(combo int) float64 {
+	c := r.Combo
+	if combo < c.Threshold {
+		return 1
+	}
+	return math.Min(1+float64(combo-(c.Threshold-1))*c.DamageStep, c.MaxMultiplier)
+}
+
+// Damage は単語の種類とコンボから敵へのダメージを返す（小数は切り捨て）
+func (r *Ruleset) Damage(t WordType, combo int) int {
+	return int(math.Floor(float64(r.Words[t].Damage) * r.ComboMultiplier(combo)))
+}
+
+// Score は1単語のスコアを計算する（frontend の calculateScore と同じ）。
+// フロントエンドは合計を切り捨てるが、コンボボーナス以外は整数のため、コンボボーナスを切り捨てても合計は変わらない
+func (r *Ruleset) Score(damage, combo int, t WordType, timeBonus int) ScoreBreakdown {
+	b := ScoreBreakdown{
+		Base: damage * r.Scoring.PerDamage,
+		Time: timeBonus * r.Scoring.PerBonusSecond,
+	}
+	if t != Normal {
+		b.Special = r.Words[t].ScoreBonus
+	}
+	if c := r.Combo; combo >= c.Threshold {
+		b.Combo = int(math.Floor(math.Pow(float64(combo-(c.Threshold-1)), c.ScoreExponent) * c.ScoreBase))
+	}
+	b.Total = b.Base + b.Combo + b.Special + b.Time
+	return b
+}
@@ -0,0 +1,74 @@
+package game
+
+import (
+	"errors"
+	"math"
+	"slices"
+)
+
+// TimeAttackDurations はタイムアタックの制限時間（秒）。リーダーボードは制限時間ごとに分ける
+var TimeAttackDurations = []int{60, 120, 300}
+
+// PracticeWords は練習モードで入力する単語数
+const PracticeWords = 50
+
+// MaxWPM は記録として受け付ける WPM の上限（これを超える記録は不正な送信とみなす）
+const MaxWPM = 250
+
+// ValidDuration は d がタイムアタックの制限時間かを返す
+func ValidDuration(d int) bool {
+	return slices.Contains(TimeAttackDurations, d)
+}
+
+// TypingStats はタイピングテスト（タイムアタック・練習）の入力の記録
+type TypingStats struct {
+	// Words は入力を完了した単語数
+	Words int `json:"words" dynamodbav:"words"`
+	// Characters は正しく入力した打鍵数（日本語はローマ字の打鍵数）
+	Characters int `json:"characters" dynamodbav:"characters"`
+	// Keystrokes は間違いを含むすべての打鍵数
+	Keystrokes int `json:"keystrokes" dynamodbav:"keystrokes"`
+	// ElapsedMs は入力にかかった時間（タイムアタックは制限時間）
+	ElapsedMs int `json:"elapsed_ms" dynamodbav:"elapsed_ms"`
+}
+
+// Validate は記録の値の整合性を検証する
+func (t TypingStats) Validate() error {
+	var errs []error
+	if t.Words < 0 || t.Characters < 0 {
+		errs = append(errs, errors.New("words and characters must not be negative"))
+	}
+	if t.Keystrokes < t.Characters {
+		errs = append(errs, errors.New("keystrokes must not be less than characters"))
+	}
+	if t.ElapsedMs <= 0 {
+		errs = append(errs, errors.New("elapsed_ms must be positive"))
+	}
+	return errors.Join(errs...)
+}
+
+// WPM は1分あたりの語数（正しい5打鍵を1語とする）
+func (t TypingStats) WPM() float64 {
+	if t.ElapsedMs <= 0 {
+		return 0
+	}
+	return float64(t.Characters) / 5 / (float64(t.ElapsedMs) / 60000)
+}
+
+// Accuracy は打鍵のうち正しかった割合
+func (t TypingStats) Accuracy() float64 {
+	if t.Keystrokes <= 0 {
+		return 0
+	}
+	return min(1, float64(t.Characters)/float64(t.Keystrokes))
+}
+
+// MaxCharacters は ElapsedMs の間に MaxWPM で入力できる打鍵数
+func (t TypingStats) MaxCharacters() int {
+	return int(float64(MaxWPM*5) * float64(t.ElapsedMs) / 60000)
+}
+
+// Score はタイピングテストのスコア（WPM に正確さをかけた値の100倍）
+func (t TypingStats) Score() int {
+	return int(math.Round(t.WPM() * t.Accuracy() * 100))
+}
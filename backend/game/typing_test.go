@@ -0,0 +1,50 @@
+package game
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTypingStats(t *testing.T) {
+	// 60秒で正しい打鍵 300、全打鍵 320: 60 WPM、正確さ 93.75%
+	s := TypingStats{Words: 70, Characters: 300, Keystrokes: 320, ElapsedMs: 60000}
+	if err := s.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if s.WPM() != 60 || s.Accuracy() != 0.9375 || s.Score() != 5625 {
+		t.Errorf("wpm = %v, accuracy = %v, score = %d; want 60, 0.9375, 5625", s.WPM(), s.Accuracy(), s.Score())
+	}
+	if got := s.MaxCharacters(); got != MaxWPM*5 {
+		t.Errorf("MaxCharacters = %d, want %d", got, MaxWPM*5)
+	}
+
+	half := TypingStats{Words: 50, Characters: 200, Keystrokes: 200, ElapsedMs: 30000}
+	if math.Abs(half.WPM()-80) > 1e-9 || half.Accuracy() != 1 {
+		t.Errorf("wpm = %v, accuracy = %v; want 80, 1", half.WPM(), half.Accuracy())
+	}
+
+	for _, invalid := range []TypingStats{
+		{Characters: 10, Keystrokes: 5, ElapsedMs: 1000},
+		{Characters: 10, Keystrokes: 10},
+		{Words: -1, ElapsedMs: 1000},
+	} {
+		if err := invalid.Validate(); err == nil {
+			t.Errorf("Validate(%+v): want error", invalid)
+		}
+	}
+}
+
+func TestPassageProgress(t *testing.T) {
+	p := PassageProgress{PassageID: "p_1", Sentences: []SentenceResult{
+		{Characters: 40, Keystrokes: 42, ElapsedMs: 10000},
+		{Characters: 60, Keystrokes: 63, ElapsedMs: 20000},
+	}}
+	stats := p.Stats()
+	if stats != (TypingStats{Words: 2, Characters: 100, Keystrokes: 105, ElapsedMs: 30000}) {
+		t.Errorf("Stats = %+v", stats)
+	}
+	// 読み 30 文字、正確さ 100/105
+	if got := PassageScore(30, stats.Accuracy()); got != 286 {
+		t.Errorf("PassageScore = %d, want 286", got)
+	}
+}
@@ -0,0 +1,653 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"typing-game-backend/apierror"
+	"typing-game-backend/game"
+	"typing-game-backend/leaderboard"
+	"typing-game-backend/names"
+	"typing-game-backend/passage"
+	"typing-game-backend/wordlist"
+)
+
+func (s *server) healthCheck(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "ok",
+		"message": "Typing Game API is running",
+	})
+}
+
+// getConfig はクライアントに必要なゲームの制限値と、新しいゲームに使うルールセットのバージョンを返す
+func (s *server) getConfig(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"game":    s.cfg.Game,
+		"ruleset": s.rulesets.Current().Version,
+	})
+}
+
+// ScoreSubmission は登録するスコア（POST /game/score のボディ）
+type ScoreSubmission struct {
+	PlayerName string `json:"player_name" binding:"required"`
+	// Score・Round はバトルのモードのみ。タイピングテスト・文章モードでは Typing・Progress からサーバーが計算する
+	Score int `json:"score" binding:"min=0"`
+	Round int `json:"round" binding:"min=0"`
+	Time  int `json:"time" binding:"min=0"`
+	// Category は単語のカテゴリー（文章モードではサーバーが文章の ID を設定する）
+	Category string `json:"category"`
+	// RulesetVersion はプレイしたルールセットのバージョン（省略時は現在のバージョン）
+	RulesetVersion string `json:"ruleset_version,omitempty"`
+	// Mode はプレイしたモード（省略時は standard）。エンドレスモードの Round は到達したウェーブ
+	Mode game.Mode `json:"mode"`
+	// Duration はタイムアタックの制限時間（秒）
+	Duration int `json:"duration,omitempty"`
+	// Typing はタイピングテストのモード（タイムアタック・練習）の入力の記録
+	Typing *game.TypingStats `json:"typing,omitempty"`
+	// Progress は文章モードの文ごとの入力の記録
+	Progress *game.PassageProgress `json:"progress,omitempty"`
+	// WPM・Accuracy は Typing・Progress からサーバーが計算する（送信された値は使わない）
+	WPM      float64 `json:"wpm,omitempty"`
+	Accuracy float64 `json:"accuracy,omitempty"`
+}
+
+func (s *server) submitScore(c *gin.Context) {
+	var scoreData ScoreSubmission
+	if err := c.ShouldBindJSON(&scoreData); err != nil {
+		respondError(c, bindError(err))
+		return
+	}
+
+	limits := s.cfg.Game
+	logger := loggerFrom(c.Request.Context())
+
+	// プレイヤー名の検証（正規化・使用できない文字・禁止語・予約名）。保存には正規化後の名前を使う
+	playerName, err := s.names.Check(scoreData.PlayerName)
+	var violation *names.Violation
+	if errors.As(err, &violation) {
+		logger.Info("Player name rejected", "code", violation.Code)
+		respondError(c, playerNameError(violation))
+		return
+	}
+	scoreData.PlayerName = playerName
+
+	// 記録の検証はモードで異なる（タイピングテストのモードはここでスコアを計算する）
+	if scoreData.Mode == "" {
+		scoreData.Mode = game.ModeStandard
+	}
+	var invalid []apierror.FieldError
+	if scoreData.Category == "" && scoreData.Mode != game.ModePassage {
+		invalid = append(invalid, apierror.Field("category", apierror.FieldRequired))
+	}
+	// カスタム単語リストのスコアはリストのリーダーボードに記録するため、作成されたリストかを確認する。
+	// 通常のモードのスコアはカテゴリー別ビューにも記録するため、リーダーボードの取得と同じくカテゴリーを確認する
+	if scoreData.Mode != game.ModePassage {
+		list, fields, err := s.wordListCategory(c.Request.Context(), scoreData.Category)
+		if err != nil {
+			logger.Error("Failed to fetch word list", "list_id", scoreData.Category, "error", err)
+			respondError(c, storageError(err))
+			return
+		}
+		invalid = append(invalid, fields...)
+		if list == nil && fields == nil && scoreData.Category != "" && scoreData.Mode == game.ModeStandard &&
+			!slices.Contains(validCategories, scoreData.Category) {
+			invalid = append(invalid, apierror.Field("category", apierror.FieldInvalid))
+		}
+	}
+	switch {
+	case !scoreData.Mode.Valid():
+		invalid = append(invalid, apierror.Field("mode", apierror.FieldInvalid))
+	case scoreData.Mode == game.ModePassage:
+		fields, err := s.checkPassageScore(c.Request.Context(), &scoreData)
+		if err != nil {
+			logger.Error("Failed to fetch passage", "error", err)
+			respondError(c, storageError(err))
+			return
+		}
+		invalid = append(invalid, fields...)
+	case scoreData.Mode.Typing():
+		invalid = append(invalid, s.checkTypingScore(&scoreData)...)
+	default:
+		invalid = append(invalid, s.checkBattleScore(&scoreData)...)
+	}
+	if scoreData.Score < 0 || scoreData.Score > limits.MaxScore {
+		invalid = append(invalid, apierror.Field("score", apierror.FieldOutOfRange, 0, limits.MaxScore))
+	}
+	if scoreData.Time < 0 || scoreData.Time > limits.MaxGameTimeSeconds {
+		invalid = append(invalid, apierror.Field("time", apierror.FieldOutOfRange, 0, limits.MaxGameTimeSeconds))
+	}
+	if len(invalid) > 0 {
+		respondError(c, apierror.Validation(invalid...))
+		return
+	}
+
+	// スコアの保存とリーダーボードの更新（自己ベストの場合のみ）をまとめて行う
+	result, err := s.store.recordScore(c.Request.Context(), scoreData)
+	if errors.As(err, &violation) {
+		logger.Info("Player name rejected", "code", violation.Code)
+		respondError(c, playerNameError(violation))
+		return
+	}
+	if err != nil {
+		logger.Error("Failed to save score", "player_name", scoreData.PlayerName, "error", err)
+		respondError(c, storageError(err))
+		return
+	}
+
+	logger.Info("Score submitted",
+		"player_name", scoreData.PlayerName,
+		"score", scoreData.Score,
+		"round", scoreData.Round,
+		"game_time", scoreData.Time,
+		"category", scoreData.Category,
+		"ruleset_version", scoreData.RulesetVersion,
+		"mode", scoreData.Mode,
+		"duration", scoreData.Duration,
+		"personal_best", result.PersonalBest,
+	)
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":       "Score submitted successfully",
+		"data":          scoreData,
+		"personal_best": result.PersonalBest,
+		"previous_best": result.PreviousBest,
+	})
+}
+
+// checkBattleScore はバトルのモード（通常・エンドレス）のラウンド数を、スコアを記録したルールセットとモードで検証する
+func (s *server) checkBattleScore(sub *ScoreSubmission) []apierror.FieldError {
+	// ルールセットを省略した場合は現在のバージョンでプレイしたとみなす
+	if sub.RulesetVersion == "" {
+		sub.RulesetVersion = s.rulesets.Current().Version
+	}
+	// タイピングテスト・文章モードの項目は使わない
+	sub.Duration, sub.Typing, sub.Progress, sub.WPM, sub.Accuracy = 0, nil, nil, 0, 0
+
+	var invalid []apierror.FieldError
+	rules, ok := s.rulesets.Get(sub.RulesetVersion)
+	switch {
+	case !ok:
+		invalid = append(invalid, apierror.Field("ruleset_version", apierror.FieldInvalid))
+	case sub.Mode == game.ModeEndless && !rules.SupportsEndless():
+		invalid = append(invalid, apierror.Field("mode", apierror.FieldInvalid))
+	case sub.Mode == game.ModeEndless:
+		// ウェーブ数はプレイ時間から、スコアはウェーブ数からルールセットで到達・獲得できる上限までを受け付ける
+		if limit := rules.MaxWaves(sub.Time); sub.Round < 1 || sub.Round > limit {
+			invalid = append(invalid, apierror.Field("round", apierror.FieldOutOfRange, 1, limit))
+		} else if limit := rules.MaxEndlessScore(sub.Round); sub.Score > limit {
+			invalid = append(invalid, apierror.Field("score", apierror.FieldOutOfRange, 0, limit))
+		}
+	case sub.Round < 1 || sub.Round > rules.Rounds():
+		invalid = append(invalid, apierror.Field("round", apierror.FieldOutOfRange, 1, rules.Rounds()))
+	}
+	return invalid
+}
+
+// checkTypingScore はタイピングテストのモード（タイムアタック・練習）の入力の記録を検証し、
+// スコア・WPM・正確さ・時間を記録から計算する。ルールセットとラウンドはバトルのモードのものなので記録しない
+func (s *server) checkTypingScore(sub *ScoreSubmission) []apierror.FieldError {
+	var invalid []apierror.FieldError
+	switch {
+	case sub.Mode == game.ModeTimeAttack && sub.Duration == 0:
+		invalid = append(invalid, apierror.Field("duration", apierror.FieldRequired))
+	case sub.Mode == game.ModeTimeAttack && !game.ValidDuration(sub.Duration),
+		sub.Mode != game.ModeTimeAttack && sub.Duration != 0:
+		invalid = append(invalid, apierror.Field("duration", apierror.FieldInvalid))
+	}
+	sub.Progress = nil
+	if sub.Typing == nil {
+		return append(invalid, apierror.Field("typing", apierror.FieldRequired))
+	}
+
+	t := sub.Typing
+	if sub.Mode == game.ModeTimeAttack {
+		// タイムアタックは制限時間いっぱいまで入力する
+		t.ElapsedMs = sub.Duration * 1000
+	}
+	if sub.Mode == game.ModePractice && t.Words != game.PracticeWords {
+		invalid = append(invalid, apierror.Field("typing.words", apierror.FieldOutOfRange, game.PracticeWords, game.PracticeWords))
+	}
+	if t.Words < 0 {
+		invalid = append(invalid, apierror.Field("typing.words", apierror.FieldInvalid))
+	}
+	if limit := s.cfg.Game.MaxGameTimeSeconds * 1000; t.ElapsedMs < 1 || t.ElapsedMs > limit {
+		invalid = append(invalid, apierror.Field("typing.elapsed_ms", apierror.FieldOutOfRange, 1, limit))
+	}
+	// MaxWPM を超える速さの記録は受け付けない
+	if limit := t.MaxCharacters(); t.Characters < 0 || t.Characters > limit {
+		invalid = append(invalid, apierror.Field("typing.characters", apierror.FieldOutOfRange, 0, limit))
+	}
+	if t.Keystrokes < t.Characters {
+		invalid = append(invalid, apierror.Field("typing.keystrokes", apierror.FieldInvalid))
+	}
+
+	sub.RulesetVersion, sub.Round = "", 0
+	sub.Score = t.Score()
+	sub.Time = max(0, min((t.ElapsedMs+999)/1000, s.cfg.Game.MaxGameTimeSeconds))
+	sub.WPM = math.Round(t.WPM()*100) / 100
+	sub.Accuracy = math.Round(t.Accuracy()*10000) / 10000
+	return invalid
+}
+
+// checkPassageScore は文章モードの文ごとの記録を文章と照らし合わせて検証し、
+// 入力を完了した文の文字数（読みの文字数）の合計からスコアを計算する。
+// 文章を取得できない（ストレージの障害）場合はエラーを返す
+func (s *server) checkPassageScore(ctx context.Context, sub *ScoreSubmission) ([]apierror.FieldError, error) {
+	sub.RulesetVersion, sub.Round, sub.Duration, sub.Typing = "", 0, 0, nil
+	if sub.Progress == nil {
+		return []apierror.FieldError{apierror.Field("progress", apierror.FieldRequired)}, nil
+	}
+
+	p, err := s.cachedFetchPassage(ctx, sub.Progress.PassageID)
+	if errors.Is(err, errPassageNotFound) {
+		return []apierror.FieldError{apierror.Field("progress.passage_id", apierror.FieldInvalid)}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var invalid []apierror.FieldError
+	completed := len(sub.Progress.Sentences)
+	if completed < 1 || completed > len(p.Sentences) {
+		invalid = append(invalid, apierror.Field("progress.sentences", apierror.FieldOutOfRange, 1, len(p.Sentences)))
+	}
+	for i, r := range sub.Progress.Sentences {
+		// 正しく入力した打鍵数は文の読みを入力するのに必要な打鍵数より少なくならない
+		if r.Characters < 0 || r.Keystrokes < r.Characters || r.ElapsedMs < 1 ||
+			(i < len(p.Sentences) && r.Characters < p.Sentences[i].MinKeystrokes()) {
+			invalid = append(invalid, apierror.Field(fmt.Sprintf("progress.sentences.%d", i), apierror.FieldInvalid))
+		}
+	}
+	stats := sub.Progress.Stats()
+	characters := p.CharactersUpTo(completed)
+	// 文章の読みを MaxWPM より速く入力した記録・最長のプレイ時間を超える記録は受け付けない
+	// （送信された打鍵数ではなく、文章から決まる打鍵数で確かめる）
+	if max(stats.Characters, characters) > stats.MaxCharacters() || stats.ElapsedMs > s.cfg.Game.MaxGameTimeSeconds*1000 {
+		invalid = append(invalid, apierror.Field("progress.sentences", apierror.FieldInvalid))
+	}
+
+	sub.Category = p.PassageID
+	sub.Score = game.PassageScore(characters, stats.Accuracy())
+	sub.Time = max(0, min((stats.ElapsedMs+999)/1000, s.cfg.Game.MaxGameTimeSeconds))
+	sub.WPM = math.Round(stats.WPM()*100) / 100
+	sub.Accuracy = math.Round(stats.Accuracy()*10000) / 10000
+	return invalid, nil
+}
+
+func (s *server) getLeaderboard(c *gin.Context) {
+	// category・period（weekly / monthly）・mode（standard 以外）のいずれかを指定するとそのビューを返す。
+	// タイムアタックは duration（制限時間）ごとのビューを返す。
+	// category がカスタム単語リストの ID の場合は mode と組み合わせて、リストのモードごとのビューを返す
+	category := c.Query("category")
+	period := c.Query("period")
+	mode := game.Mode(c.DefaultQuery("mode", string(game.ModeStandard)))
+	duration := c.Query("duration")
+	list := wordlist.IsID(category)
+
+	view := leaderboard.Global
+	switch {
+	case category != "" && period != "":
+		respondError(c, apierror.Validation(apierror.Field("period", apierror.FieldConflict, "category")))
+		return
+	case !mode.Valid():
+		respondError(c, apierror.Validation(apierror.Field("mode", apierror.FieldInvalid)))
+		return
+	case mode != game.ModeStandard && period != "":
+		respondError(c, apierror.Validation(apierror.Field("mode", apierror.FieldConflict, "period")))
+		return
+	case mode != game.ModeStandard && category != "" && !list:
+		respondError(c, apierror.Validation(apierror.Field("mode", apierror.FieldConflict, "category")))
+		return
+	case mode == game.ModeTimeAttack:
+		seconds, err := strconv.Atoi(duration)
+		switch {
+		case duration == "":
+			respondError(c, apierror.Validation(apierror.Field("duration", apierror.FieldRequired)))
+			return
+		case err != nil || !game.ValidDuration(seconds):
+			respondError(c, apierror.Validation(apierror.Field("duration", apierror.FieldInvalid)))
+			return
+		}
+		view = leaderboard.PrimaryView(category, mode, seconds)
+	case duration != "":
+		respondError(c, apierror.Validation(apierror.Field("duration", apierror.FieldInvalid)))
+		return
+	case mode != game.ModeStandard, list:
+		view = leaderboard.PrimaryView(category, mode, 0)
+	case category != "":
+		if !slices.Contains(validCategories, category) {
+			respondError(c, apierror.Validation(apierror.Field("category", apierror.FieldInvalid)))
+			return
+		}
+		view = leaderboard.CategoryView(category)
+	case period != "":
+		if period != string(leaderboard.Weekly) && period != string(leaderboard.Monthly) {
+			respondError(c, apierror.Validation(apierror.Field("period", apierror.FieldInvalid)))
+			return
+		}
+		view = leaderboard.PeriodView(leaderboard.Period(period), time.Now())
+	}
+	if list {
+		_, invalid, err := s.wordListCategory(c.Request.Context(), category)
+		if err != nil {
+			loggerFrom(c.Request.Context()).Error("Failed to fetch word list", "list_id", category, "error", err)
+			respondError(c, storageError(err))
+			return
+		}
+		if len(invalid) > 0 {
+			respondError(c, apierror.Validation(invalid...))
+			return
+		}
+	}
+
+	var items []LeaderboardItem
+	var err error
+	if view == leaderboard.Global {
+		items, err = s.store.fetchLeaderboard(c.Request.Context(), s.cfg.Game.LeaderboardSize)
+	} else {
+		items, err = s.store.fetchLeaderboardView(c.Request.Context(), view, s.cfg.Game.LeaderboardSize)
+	}
+	if err != nil {
+		loggerFrom(c.Request.Context()).Error("Failed to fetch leaderboard", "view", view, "error", err)
+		respondError(c, storageError(err))
+		return
+	}
+	if items == nil {
+		items = []LeaderboardItem{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"leaderboard": items,
+		"view":        view,
+	})
+}
+
+func (s *server) getWords(c *gin.Context) {
+	category := c.Param("category")
+	roundStr := c.Param("round")
+	language := c.DefaultQuery("language", "jp") // 言語パラメータを取得（デフォルトは日本語）
+	// エンドレスモードでは round はウェーブで、ruleset（デフォルトは現在のバージョン）のウェーブの単語を返す
+	mode := game.Mode(c.DefaultQuery("mode", string(game.ModeStandard)))
+
+	// カテゴリー（またはカスタム単語リスト）・言語・モード・ラウンドの検証
+	list, invalid, err := s.wordsCategory(c, category, &language)
+	if err != nil {
+		return
+	}
+	round, err := strconv.Atoi(roundStr)
+	var wave *game.Wave
+	switch mode {
+	case game.ModeStandard:
+		// 単語は過去のルールセットでプレイ中のクライアントにも返すため、最も多いラウンド数まで受け付ける
+		maxRounds := s.rulesets.MaxRounds()
+		if err != nil || round < 1 || round > maxRounds {
+			invalid = append(invalid, apierror.Field("round", apierror.FieldOutOfRange, 1, maxRounds))
+		}
+	case game.ModeEndless:
+		rules, ok := s.rulesets.Get(c.DefaultQuery("ruleset", s.rulesets.Current().Version))
+		switch {
+		case !ok:
+			invalid = append(invalid, apierror.Field("ruleset", apierror.FieldInvalid))
+		case !rules.SupportsEndless():
+			invalid = append(invalid, apierror.Field("mode", apierror.FieldInvalid))
+		default:
+			// 最長のプレイ時間で到達できるウェーブまで
+			if limit := rules.MaxWaves(s.cfg.Game.MaxGameTimeSeconds); err != nil || round < 1 || round > limit {
+				invalid = append(invalid, apierror.Field("round", apierror.FieldOutOfRange, 1, limit))
+				break
+			}
+			w, _ := rules.Wave(round)
+			wave = &w
+		}
+	default:
+		invalid = append(invalid, apierror.Field("mode", apierror.FieldInvalid))
+	}
+	if len(invalid) > 0 {
+		respondError(c, apierror.Validation(invalid...))
+		return
+	}
+
+	wordsRound := round
+	if wave != nil {
+		wordsRound = wave.Round
+	}
+	response := gin.H{
+		"category": category,
+		"round":    round,
+		"language": language,
+	}
+	if wave != nil {
+		response["wave"] = wave
+	}
+
+	if list != nil {
+		// カスタム単語リストはリストのラウンドの単語（ラウンドが足りない場合は繰り返す）を返す
+		words := listWordItems(list.Round(wordsRound))
+		if wave != nil {
+			words = applyModifiers(words, wave.Modifiers)
+		}
+		if words == nil {
+			words = []WordItem{}
+		}
+		response["words"] = words
+		s.respondCacheable(c, response)
+		return
+	}
+
+	words, err := s.cachedFetchWords(c.Request.Context(), category, wordsRound, language)
+	if err != nil {
+		// ストレージ障害時はエラーにせず、古いキャッシュかフォールバック単語でゲームを続けられるようにする
+		words, degraded := s.degradedWords(category, wordsRound, language)
+		loggerFrom(c.Request.Context()).Warn("Failed to fetch words, serving degraded response", "category", category, "round", wordsRound, "language", language, "degraded", degraded, "error", err)
+		if wave != nil {
+			words = applyModifiers(words, wave.Modifiers)
+		}
+		response["words"] = words
+		response["degraded"] = degraded
+		c.Header("Cache-Control", "no-store")
+		c.JSON(http.StatusOK, response)
+		return
+	}
+	if wave != nil {
+		words = applyModifiers(words, wave.Modifiers)
+	}
+	if words == nil {
+		words = []WordItem{}
+	}
+
+	response["words"] = words
+	s.respondCacheable(c, response)
+}
+
+// getTypingWords はタイピングテストのモード（タイムアタック・練習）の単語を返す。
+// 同じカテゴリーのプレイヤーが同じ単語で競えるように、カテゴリーのすべてのラウンドの単語をラウンド順に並べ、
+// 練習モードは game.PracticeWords 語を返す
+func (s *server) getTypingWords(c *gin.Context) {
+	category := c.Param("category")
+	language := c.DefaultQuery("language", "jp")
+	mode := game.Mode(c.Query("mode"))
+
+	list, invalid, err := s.wordsCategory(c, category, &language)
+	if err != nil {
+		return
+	}
+	switch {
+	case mode == "":
+		invalid = append(invalid, apierror.Field("mode", apierror.FieldRequired))
+	case !mode.Typing():
+		invalid = append(invalid, apierror.Field("mode", apierror.FieldInvalid))
+	}
+	if len(invalid) > 0 {
+		respondError(c, apierror.Validation(invalid...))
+		return
+	}
+
+	// 取得できなかったラウンドは古いキャッシュかフォールバック単語で補う（フォールバックを1つでも使えば fallback）。
+	// カスタム単語リストはリストのすべての単語を返す
+	var words []WordItem
+	degraded := ""
+	if list != nil {
+		words = listWordItems(list.Words)
+	}
+	for round := 1; list == nil && round <= s.rulesets.MaxRounds(); round++ {
+		roundWords, err := s.cachedFetchWords(c.Request.Context(), category, round, language)
+		if err != nil {
+			var reason string
+			roundWords, reason = s.degradedWords(category, round, language)
+			loggerFrom(c.Request.Context()).Warn("Failed to fetch words, serving degraded response", "category", category, "round", round, "language", language, "degraded", reason, "error", err)
+			if degraded != "fallback" {
+				degraded = reason
+			}
+		}
+		words = append(words, roundWords...)
+	}
+	if mode == game.ModePractice {
+		words = practiceWords(words)
+	}
+	if words == nil {
+		words = []WordItem{}
+	}
+
+	response := gin.H{
+		"category": category,
+		"language": language,
+		"mode":     mode,
+		"words":    words,
+	}
+	if degraded != "" {
+		response["degraded"] = degraded
+		c.Header("Cache-Control", "no-store")
+		c.JSON(http.StatusOK, response)
+		return
+	}
+	s.respondCacheable(c, response)
+}
+
+func (s *server) getCategories(c *gin.Context) {
+	// 言語パラメータを取得（デフォルトは日本語）
+	language := c.DefaultQuery("language", "jp")
+
+	s.respondCacheable(c, gin.H{
+		"categories": s.cachedCategories(language),
+	})
+}
+
+func (s *server) getTranslation(c *gin.Context) {
+	wordID := c.Param("word_id")
+	targetLanguage := c.Query("language")
+
+	var invalid []apierror.FieldError
+	if wordID == "" {
+		invalid = append(invalid, apierror.Field("word_id", apierror.FieldRequired))
+	}
+	switch {
+	case targetLanguage == "":
+		invalid = append(invalid, apierror.Field("language", apierror.FieldRequired))
+	case !slices.Contains(s.cfg.Game.TranslationLanguages, targetLanguage):
+		invalid = append(invalid, apierror.Field("language", apierror.FieldInvalid))
+	}
+	if len(invalid) > 0 {
+		respondError(c, apierror.Validation(invalid...))
+		return
+	}
+
+	translation, err := s.fetchWordTranslation(c.Request.Context(), wordID, targetLanguage)
+	if errors.Is(err, errTranslationNotFound) {
+		respondError(c, apierror.Wrap(apierror.CodeTranslationNotFound, err))
+		return
+	}
+	if err != nil {
+		loggerFrom(c.Request.Context()).Error("Failed to fetch translation", "word_id", wordID, "language", targetLanguage, "error", err)
+		respondError(c, storageError(err))
+		return
+	}
+
+	s.respondCacheable(c, gin.H{
+		"translation": translation,
+		"word_id":     wordID,
+		"language":    targetLanguage,
+	})
+}
+
+// getPassages は文章モードの文章の一覧（文を含まない概要）を返す
+func (s *server) getPassages(c *gin.Context) {
+	language := c.DefaultQuery("language", "jp")
+	if !slices.Contains(s.cfg.Game.WordLanguages, language) {
+		respondError(c, apierror.Validation(apierror.Field("language", apierror.FieldInvalid)))
+		return
+	}
+
+	passages, err := s.cachedFetchPassages(c.Request.Context(), language)
+	if err != nil {
+		loggerFrom(c.Request.Context()).Error("Failed to fetch passages", "language", language, "error", err)
+		respondError(c, storageError(err))
+		return
+	}
+
+	summaries := make([]passage.Summary, len(passages))
+	for i := range passages {
+		summaries[i] = passages[i].Summary()
+	}
+	s.respondCacheable(c, gin.H{
+		"passages": summaries,
+		"language": language,
+	})
+}
+
+// getPassage は文章を文ごとの表示テキスト・読みとともに返す
+func (s *server) getPassage(c *gin.Context) {
+	passageID := c.Param("passage_id")
+
+	p, err := s.cachedFetchPassage(c.Request.Context(), passageID)
+	if errors.Is(err, errPassageNotFound) {
+		respondError(c, apierror.Wrap(apierror.CodePassageNotFound, err))
+		return
+	}
+	if err != nil {
+		loggerFrom(c.Request.Context()).Error("Failed to fetch passage", "passage_id", passageID, "error", err)
+		respondError(c, storageError(err))
+		return
+	}
+
+	s.respondCacheable(c, gin.H{
+		"passage": p,
+	})
+}
+
+// getRulesets はすべてのルールセットと、新しいゲームに使うバージョンを返す
+func (s *server) getRulesets(c *gin.Context) {
+	s.respondCacheable(c, gin.H{
+		"current":  s.rulesets.Current().Version,
+		"rulesets": s.rulesets.All(),
+	})
+}
+
+// wordsCategory は単語を取得するカテゴリー（またはカスタム単語リスト）と言語を検証する。
+// カスタム単語リストはリストの言語の単語だけを返すため、language を省略した場合はリストの言語にする。
+// リストを取得できない（ストレージの障害）場合はエラーを返し、レスポンスを書き込む
+func (s *server) wordsCategory(c *gin.Context, category string, language *string) (*wordlist.List, []apierror.FieldError, error) {
+	list, invalid, err := s.wordListCategory(c.Request.Context(), category)
+	if err != nil {
+		loggerFrom(c.Request.Context()).Error("Failed to fetch word list", "list_id", category, "error", err)
+		respondError(c, storageError(err))
+		return nil, nil, err
+	}
+	switch {
+	case list != nil:
+		*language = c.DefaultQuery("language", list.Language)
+	case invalid == nil && !slices.Contains(validCategories, category):
+		invalid = append(invalid, apierror.Field("category", apierror.FieldInvalid))
+	}
+	if !slices.Contains(s.cfg.Game.WordLanguages, *language) || (list != nil && *language != list.Language) {
+		invalid = append(invalid, apierror.Field("language", apierror.FieldInvalid))
+	}
+	return list, invalid, nil
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/aws/smithy-go"
+	"github.com/gin-gonic/gin"
+)
+
+// version はビルド時に -ldflags "-X main.version=..." で埋め込まれる
+var version = "dev"
+
+// dependencyStatus はレディネスチェックの依存先ごとの結果
+type dependencyStatus struct {
+	Name      string  `json:"name"`
+	Target    string  `json:"target,omitempty"`
+	Status    string  `json:"status"` // "ok", "error", "skipped"
+	LatencyMS float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// liveness はプロセスが応答できるかだけを返す（依存先には触れない）
+func (s *server) liveness(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"status":  "ok",
+		"version": version,
+	})
+}
+
+// readiness は設定済みのすべてのテーブルにストレージ層経由で到達できるかを確認する
+func (s *server) readiness(c *gin.Context) {
+	checks := s.checkDependencies(c.Request.Context())
+
+	ready := true
+	for _, check := range checks {
+		if check.Status == "error" {
+			ready = false
+		}
+	}
+
+	status := http.StatusOK
+	overall := "ok"
+	if !ready {
+		status = http.StatusServiceUnavailable
+		overall = "unavailable"
+	}
+
+	c.JSON(status, gin.H{
+		"status":       overall,
+		"version":      version,
+		"environment":  s.cfg.Environment,
+		"dependencies": checks,
+		"config": gin.H{
+			"warnings": s.cfg.Warnings(),
+		},
+	})
+}
+
+// checkDependencies は各テーブルを並行してチェックする。1件ごとに CheckTimeout の時間制限を設ける
+func (s *server) checkDependencies(ctx context.Context) []dependencyStatus {
+	tables := s.store.tableNames()
+	checks := make([]dependencyStatus, len(tables))
+
+	var wg sync.WaitGroup
+	for i, table := range tables {
+		checks[i] = dependencyStatus{Name: table.name + "_table", Target: table.table}
+		if table.table == "" {
+			checks[i].Status = "skipped"
+			checks[i].Error = "table is not configured"
+			continue
+		}
+
+		wg.Add(1)
+		go func(check *dependencyStatus) {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, s.cfg.Health.CheckTimeout.Duration)
+			defer cancel()
+
+			start := time.Now()
+			err := s.store.pingTable(checkCtx, check.Target)
+			check.LatencyMS = float64(time.Since(start).Microseconds()) / 1000
+			if err != nil {
+				check.Status = "error"
+				check.Error = dependencyErrorSummary(err)
+				loggerFrom(ctx).Warn("Readiness check failed", "dependency", check.Name, "error", err)
+				return
+			}
+			check.Status = "ok"
+		}(&checks[i])
+	}
+	wg.Wait()
+
+	return checks
+}
+
+// dependencyErrorSummary はクライアントに返すエラーの要約を作る。
+// AWSのエラーメッセージにはARNなどが含まれるため、エラーコードだけを返す
+func dependencyErrorSummary(err error) string {
+	var apiErr smithy.APIError
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.As(err, &apiErr):
+		return "dynamodb error: " + apiErr.ErrorCode()
+	case errors.Is(err, errTableNotActive):
+		return err.Error()
+	default:
+		return "unreachable"
+	}
+}
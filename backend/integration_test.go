@@ -0,0 +1,271 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"typing-game-backend/config"
+	"typing-game-backend/privacy"
+)
+
+type leaderboardBody struct {
+	Leaderboard []LeaderboardItem `json:"leaderboard"`
+	View        string            `json:"view"`
+}
+
+type wordsBody struct {
+	Words    []WordItem `json:"words"`
+	Degraded string     `json:"degraded"`
+}
+
+func (e *testEnv) leaderboard(query string) leaderboardBody {
+	e.t.Helper()
+	rec := e.do(http.MethodGet, apiV1Prefix+"/game/leaderboard"+query, "")
+	if rec.Code != http.StatusOK {
+		e.t.Fatalf("leaderboard%s: status %d: %s", query, rec.Code, rec.Body.String())
+	}
+	var body leaderboardBody
+	decodeJSON(e.t, rec, &body)
+	return body
+}
+
+func (e *testEnv) words(path string) (wordsBody, *httptest.ResponseRecorder) {
+	e.t.Helper()
+	rec := e.do(http.MethodGet, apiV1Prefix+"/game/words/"+path, "")
+	if rec.Code != http.StatusOK {
+		e.t.Fatalf("words %s: status %d: %s", path, rec.Code, rec.Body.String())
+	}
+	var body wordsBody
+	decodeJSON(e.t, rec, &body)
+	return body, rec
+}
+
+// submitConcurrently はスコアを並行して登録し、ステータスが 200 でなかったレスポンスを返す
+func (e *testEnv) submitConcurrently(bodies []string) []string {
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		failures []string
+	)
+	for _, body := range bodies {
+		wg.Add(1)
+		go func(body string) {
+			defer wg.Done()
+			req := httptest.NewRequest(http.MethodPost, apiV1Prefix+"/game/score", strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			e.router.ServeHTTP(rec, req)
+			if rec.Code != http.StatusOK {
+				mu.Lock()
+				failures = append(failures, fmt.Sprintf("%d %s", rec.Code, rec.Body.String()))
+				mu.Unlock()
+			}
+		}(body)
+	}
+	wg.Wait()
+	return failures
+}
+
+// トランザクションの競合（TransactionConflict）はリトライで解消するため、試行回数を増やす
+func withRetries(cfg *config.Config) { cfg.Storage.MaxAttempts = 10 }
+
+func TestConcurrentSubmissionsSamePlayer(t *testing.T) {
+	e := newTestEnv(t, withRetries)
+
+	const n = 20
+	var bodies []string
+	total := 0
+	for i := 1; i <= n; i++ {
+		bodies = append(bodies, scoreBody("concurrent", i*100, 1, 10))
+		total += i * 100
+	}
+	if failures := e.submitConcurrently(bodies); len(failures) > 0 {
+		t.Fatalf("%d submissions failed: %v", len(failures), failures)
+	}
+
+	// リーダーボードには最高スコアだけが残る
+	board := e.leaderboard("")
+	if len(board.Leaderboard) != 1 || board.Leaderboard[0].Score != n*100 {
+		t.Errorf("leaderboard = %+v, want a single entry with score %d", board.Leaderboard, n*100)
+	}
+	category := e.leaderboard("?category=beginner_words")
+	if len(category.Leaderboard) != 1 || category.Leaderboard[0].Score != n*100 {
+		t.Errorf("category leaderboard = %+v, want a single entry with score %d", category.Leaderboard, n*100)
+	}
+
+	// 累計はすべての登録を数える
+	var stats PlayerStatsItem
+	key := map[string]types.AttributeValue{"player_name": &types.AttributeValueMemberS{Value: "concurrent"}}
+	if !e.get(e.cfg.Tables.PlayerStats, key, &stats) {
+		t.Fatal("player stats not found")
+	}
+	if stats.GamesPlayed != n || stats.TotalScore != int64(total) || stats.TotalTime != n*10 {
+		t.Errorf("player stats = %+v, want %d games, total score %d", stats, n, total)
+	}
+
+	// 同じ秒のスコアも上書きせずにすべて保存する（リーダーボードの再構築はスコアから行う）
+	scores := e.scoresOf("concurrent")
+	sum := 0
+	for _, s := range scores {
+		sum += s.Score
+	}
+	if len(scores) != n || sum != total {
+		t.Errorf("scores table has %d scores (total %d) for the player, want %d (total %d)", len(scores), sum, n, total)
+	}
+}
+
+func TestConcurrentSubmissionsManyPlayers(t *testing.T) {
+	e := newTestEnv(t, withRetries)
+
+	const n = 20
+	var bodies []string
+	for i := 0; i < n; i++ {
+		bodies = append(bodies, scoreBody(fmt.Sprintf("player%02d", i), 1000+i*10, 1+i%5, 30))
+	}
+	if failures := e.submitConcurrently(bodies); len(failures) > 0 {
+		t.Fatalf("%d submissions failed: %v", len(failures), failures)
+	}
+
+	board := e.leaderboard("")
+	if len(board.Leaderboard) != n {
+		t.Fatalf("leaderboard has %d entries, want %d", len(board.Leaderboard), n)
+	}
+	for i, item := range board.Leaderboard {
+		if want := fmt.Sprintf("player%02d", n-1-i); item.PlayerName != want || item.Rank != i+1 {
+			t.Errorf("leaderboard[%d] = %s (rank %d), want %s (rank %d)", i, item.PlayerName, item.Rank, want, i+1)
+		}
+	}
+}
+
+func TestSubmitScoreStorageUnavailable(t *testing.T) {
+	e := newTestEnv(t)
+	e.skipIfExternal()
+
+	e.fake.Fail("TransactWriteItems", "ProvisionedThroughputExceededException", -1)
+	assertError(t, e.do(http.MethodPost, apiV1Prefix+"/game/score", scoreBody("throttled", 100, 1, 60)), http.StatusServiceUnavailable, "storage_unavailable")
+
+	e.fake.Fail("TransactWriteItems", "", 0)
+	e.submit("throttled", 100, 1, "beginner_words")
+}
+
+// TestPagination は DynamoDB が結果を複数のページに分けて返す場合に、すべてのページを読むことを確認する
+func TestPagination(t *testing.T) {
+	e := newTestEnv(t, func(cfg *config.Config) { cfg.Game.LeaderboardSize = 5 })
+	e.skipIfExternal()
+	e.fake.SetPageSize(2)
+
+	for i := 0; i < 7; i++ {
+		e.submit(fmt.Sprintf("paged%d", i), 100*(i+1), 1, "beginner_words")
+	}
+
+	t.Run("leaderboard", func(t *testing.T) {
+		board := e.leaderboard("")
+		if len(board.Leaderboard) != 5 || board.Leaderboard[0].PlayerName != "paged6" || board.Leaderboard[4].PlayerName != "paged2" {
+			t.Errorf("leaderboard = %+v, want the top 5 of 7 players", board.Leaderboard)
+		}
+	})
+
+	t.Run("leaderboard view", func(t *testing.T) {
+		board := e.leaderboard("?category=beginner_words")
+		if len(board.Leaderboard) != 5 || board.Leaderboard[0].PlayerName != "paged6" {
+			t.Errorf("category leaderboard = %+v, want the top 5 of 7 players", board.Leaderboard)
+		}
+	})
+
+	t.Run("words", func(t *testing.T) {
+		for i := 0; i < 5; i++ {
+			e.put(e.cfg.Tables.Words, WordItem{
+				Category: "intermediate_words", WordID: fmt.Sprintf("iw_%03d", i), Word: fmt.Sprintf("word%d", i),
+				Round: 2, Type: "normal", Language: "en", LookupKey: wordLookupKey("intermediate_words", "en", 2),
+			})
+		}
+		body, _ := e.words("intermediate_words/2?language=en")
+		if len(body.Words) != 5 {
+			t.Errorf("got %d words, want 5", len(body.Words))
+		}
+	})
+
+	t.Run("privacy export", func(t *testing.T) {
+		for i := 0; i < 5; i++ {
+			e.put(e.cfg.Tables.Scores, ScoreItem{PlayerName: "paged0", Score: i, Round: 1, Category: "beginner_words", Timestamp: int64(1000 + i), ScoreType: "game"})
+		}
+		rec := e.do(http.MethodPost, apiV1Prefix+"/admin/players/export", `{"player_name":"paged0"}`, "X-Admin-Key", adminKey)
+		var export privacy.Export
+		decodeJSON(t, rec, &export)
+		if len(export.Scores) != 6 || len(export.LeaderboardViews) != 3 {
+			t.Errorf("export has %d scores and %d views, want 6 and 3", len(export.Scores), len(export.LeaderboardViews))
+		}
+	})
+}
+
+func seedLegacyWords(e *testEnv) {
+	// lookup_key のない旧スキーマの項目
+	for i := 0; i < 3; i++ {
+		e.put(e.cfg.Tables.Words, WordItem{Category: "beginner_conversation", WordID: fmt.Sprintf("bc_%03d", i), Word: fmt.Sprintf("legacy%d", i), Round: 3, Type: "normal", Language: "jp"})
+	}
+	e.put(e.cfg.Tables.Words, WordItem{Category: "beginner_conversation", WordID: "bc_999", Word: "other round", Round: 4, Type: "normal", Language: "jp"})
+}
+
+func TestFallbackWords(t *testing.T) {
+	t.Run("words table not configured", func(t *testing.T) {
+		e := newTestEnv(t, func(cfg *config.Config) { cfg.Tables.Words = "" })
+		body, rec := e.words("beginner_words/2")
+		want := fallbackWords("beginner_words", 2, "jp")
+		if len(body.Words) != len(want) || body.Words[0].WordID != want[0].WordID || body.Degraded != "" {
+			t.Errorf("words = %+v (degraded %q), want the local fallback words", body.Words, body.Degraded)
+		}
+		if rec.Header().Get("ETag") == "" {
+			t.Error("fallback words for an unconfigured table should be cacheable")
+		}
+	})
+
+	t.Run("legacy items without lookup_key", func(t *testing.T) {
+		e := newTestEnv(t)
+		seedLegacyWords(e)
+		body, _ := e.words("beginner_conversation/3")
+		if len(body.Words) != 3 || body.Words[0].Word != "legacy0" {
+			t.Errorf("words = %+v, want the 3 legacy words of round 3", body.Words)
+		}
+	})
+
+	t.Run("storage failure", func(t *testing.T) {
+		e := newTestEnv(t, func(cfg *config.Config) { cfg.Storage.MaxAttempts = 1 })
+		e.skipIfExternal()
+
+		e.fake.Fail("Query", "InternalServerError", -1)
+		body, rec := e.words("beginner_words/2")
+		if body.Degraded != "fallback" || len(body.Words) != len(fallbackWords("beginner_words", 2, "jp")) {
+			t.Errorf("words = %+v (degraded %q), want fallback words", body.Words, body.Degraded)
+		}
+		if got := rec.Header().Get("Cache-Control"); got != "no-store" {
+			t.Errorf("Cache-Control = %q, want no-store", got)
+		}
+	})
+
+	t.Run("stale cache", func(t *testing.T) {
+		e := newTestEnv(t, func(cfg *config.Config) {
+			cfg.Storage.MaxAttempts = 1
+			cfg.Cache.TTL = config.Duration{Duration: time.Millisecond}
+		})
+		e.skipIfExternal()
+		seedLegacyWords(e)
+
+		if body, _ := e.words("beginner_conversation/3"); len(body.Words) != 3 {
+			t.Fatalf("got %d words, want 3", len(body.Words))
+		}
+		time.Sleep(5 * time.Millisecond)
+
+		e.fake.Fail("Query", "InternalServerError", -1)
+		body, _ := e.words("beginner_conversation/3")
+		if body.Degraded != "stale" || len(body.Words) != 3 || body.Words[0].Word != "legacy0" {
+			t.Errorf("words = %+v (degraded %q), want the stale cached words", body.Words, body.Degraded)
+		}
+	})
+}
@@ -0,0 +1,306 @@
+// Package kanadict は組み込みの辞書で日本語のテキストを語に分け、ひらがなの読みを付ける。
+// 辞書はオフラインで使えるようにバイナリに埋め込み、読みが複数ある語は確認が必要な語として返す
+package kanadict
+
+import (
+	"bufio"
+	"bytes"
+	"embed"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"typing-game-backend/furigana"
+)
+
+// dictFiles は組み込みの辞書。名前の順に読み込み、先に読み込んだ読みを優先する
+//
+//go:embed dict/*.tsv
+var dictFiles embed.FS
+
+// 語の分け方のコスト。語の数が少ない（長い語で読む）分け方を選び、辞書にない漢字はできるだけ使わない
+const (
+	entryCost   = 1
+	kanaCost    = 1
+	unknownCost = 100
+)
+
+// Dictionary は表記から読みを引く辞書
+type Dictionary struct {
+	entries map[string][]string
+	// maxLen は最も長い表記の文字数
+	maxLen int
+}
+
+// New は空の辞書を返す
+func New() *Dictionary {
+	return &Dictionary{entries: make(map[string][]string)}
+}
+
+// Default は組み込みの辞書を返す
+var Default = sync.OnceValue(func() *Dictionary {
+	d, err := loadEmbedded()
+	if err != nil {
+		// 組み込みの辞書はテストで検証している
+		panic(fmt.Sprintf("kanadict: embedded dictionary: %v", err))
+	}
+	return d
+})
+
+func loadEmbedded() (*Dictionary, error) {
+	d := New()
+	entries, err := dictFiles.ReadDir("dict")
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		data, err := dictFiles.ReadFile(path.Join("dict", entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		if err := d.Load(bytes.NewReader(data)); err != nil {
+			return nil, fmt.Errorf("%s: %w", entry.Name(), err)
+		}
+	}
+	return d, nil
+}
+
+// Load は「表記<TAB>読み[,読み...]」の行からなる辞書を読み込んで加える（# で始まる行と空行は読み飛ばす）。
+// 既にある表記は読みを合わせる
+func (d *Dictionary) Load(r io.Reader) error {
+	var errs []error
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		surface, readings, ok := strings.Cut(text, "\t")
+		if !ok || surface == "" || readings == "" {
+			errs = append(errs, fmt.Errorf("line %d: expected surface<TAB>readings", line))
+			continue
+		}
+		for _, reading := range strings.Split(readings, ",") {
+			if err := d.Add(surface, strings.TrimSpace(reading)); err != nil {
+				errs = append(errs, fmt.Errorf("line %d: %w", line, err))
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read dictionary: %w", err)
+	}
+	return errors.Join(errs...)
+}
+
+// ipadicReading は IPADIC 形式の CSV（表記,左文脈ID,右文脈ID,コスト,品詞,...,原形,読み,発音）の読みの列
+const ipadicReading = 11
+
+// LoadIPADIC は IPADIC 形式の CSV（UTF-8）の語を加え、加えた行の数を返す。
+// 漢字を含まない語と読みのない語（読みが *）は読み飛ばす
+func (d *Dictionary) LoadIPADIC(r io.Reader) (int, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	added := 0
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return added, nil
+		}
+		if err != nil {
+			return added, fmt.Errorf("failed to read IPADIC CSV: %w", err)
+		}
+		if len(record) <= ipadicReading {
+			line, _ := reader.FieldPos(0)
+			return added, fmt.Errorf("line %d: expected at least %d columns, got %d", line, ipadicReading+1, len(record))
+		}
+		surface, reading := record[0], record[ipadicReading]
+		if furigana.FirstKanji(surface) == "" || reading == "*" || !furigana.IsKana(reading) {
+			continue
+		}
+		if err := d.Add(surface, reading); err != nil {
+			return added, err
+		}
+		added++
+	}
+}
+
+// Add は語を辞書に加える。読みはかなで、ひらがなにして保存する
+func (d *Dictionary) Add(surface, reading string) error {
+	if furigana.FirstKanji(surface) == "" {
+		return fmt.Errorf("surface %q has no kanji", surface)
+	}
+	if reading == "" || !furigana.IsKana(reading) {
+		return fmt.Errorf("reading %q of %q must be kana", reading, surface)
+	}
+	reading = furigana.ToHiragana(reading)
+	for _, r := range d.entries[surface] {
+		if r == reading {
+			return nil
+		}
+	}
+	d.entries[surface] = append(d.entries[surface], reading)
+	d.maxLen = max(d.maxLen, utf8.RuneCountInString(surface))
+	return nil
+}
+
+// Len は辞書の語の数を返す
+func (d *Dictionary) Len() int {
+	return len(d.entries)
+}
+
+// Write は辞書を Load で読み込める形式で書き出す（表記の順）
+func (d *Dictionary) Write(w io.Writer) error {
+	surfaces := make([]string, 0, len(d.entries))
+	for s := range d.entries {
+		surfaces = append(surfaces, s)
+	}
+	sort.Strings(surfaces)
+	for _, s := range surfaces {
+		if _, err := fmt.Fprintf(w, "%s\t%s\n", s, strings.Join(d.entries[s], ",")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Token はテキストを分けた語。Readings は辞書の読み（最初の読みを Reading に使う）で、
+// 2つ以上ある語は読みを確認する必要がある。辞書にない漢字は Reading が空になる
+type Token struct {
+	Text     string
+	Reading  string
+	Readings []string
+}
+
+// Ambiguous は読みが複数あり確認が必要な語かを返す
+func (t Token) Ambiguous() bool {
+	return len(t.Readings) > 1
+}
+
+// Unknown は辞書にない漢字かを返す
+func (t Token) Unknown() bool {
+	return t.Reading == "" && furigana.FirstKanji(t.Text) != ""
+}
+
+// Result はテキストを語に分けた結果
+type Result struct {
+	Tokens []Token
+}
+
+// Read はテキストを語に分けて読みを付ける。辞書の語の数とかなの文字数が最も少なくなるように分け、
+// かな・記号はそのまま（カタカナはひらがなで）読む
+func (d *Dictionary) Read(text string) Result {
+	runes := []rune(text)
+	n := len(runes)
+
+	// best[i] は runes[i:] を分けたときの最小のコスト、next[i] はそのときの最初の語
+	best := make([]int, n+1)
+	next := make([]Token, n)
+	for i := n - 1; i >= 0; i-- {
+		best[i] = -1
+		consider := func(end, cost int, t Token) {
+			if c := cost + best[end]; best[i] < 0 || c < best[i] {
+				best[i], next[i] = c, t
+			}
+		}
+		// 長い語を先に見て、コストが同じ場合は長い語を選ぶ
+		for l := min(d.maxLen, n-i); l >= 1; l-- {
+			surface := string(runes[i : i+l])
+			if readings, ok := d.entries[surface]; ok {
+				consider(i+l, entryCost, Token{Text: surface, Reading: readings[0], Readings: readings})
+			}
+		}
+		switch r := runes[i]; {
+		case furigana.IsKanji(r):
+			consider(i+1, unknownCost, Token{Text: string(r)})
+		default:
+			consider(i+1, kanaCost, Token{Text: string(r), Reading: furigana.ToHiragana(string(r))})
+		}
+	}
+
+	var result Result
+	for i := 0; i < n; {
+		t := next[i]
+		i += utf8.RuneCountInString(t.Text)
+		// 辞書にないかな・記号の並びは1つの語にまとめる
+		if last := len(result.Tokens) - 1; last >= 0 && t.Readings == nil && !t.Unknown() &&
+			result.Tokens[last].Readings == nil && !result.Tokens[last].Unknown() {
+			result.Tokens[last].Text += t.Text
+			result.Tokens[last].Reading += t.Reading
+			continue
+		}
+		result.Tokens = append(result.Tokens, t)
+	}
+	return result
+}
+
+// Reading は語の読みをつなげた読みを返す
+func (r Result) Reading() string {
+	var b strings.Builder
+	for _, t := range r.Tokens {
+		b.WriteString(t.Reading)
+	}
+	return b.String()
+}
+
+// Ambiguous は読みの確認が必要な語を返す
+func (r Result) Ambiguous() []Token {
+	var ambiguous []Token
+	for _, t := range r.Tokens {
+		if t.Ambiguous() {
+			ambiguous = append(ambiguous, t)
+		}
+	}
+	return ambiguous
+}
+
+// Unknown は辞書にない漢字を返す
+func (r Result) Unknown() []string {
+	var unknown []string
+	for _, t := range r.Tokens {
+		if t.Unknown() {
+			unknown = append(unknown, t.Text)
+		}
+	}
+	return unknown
+}
+
+// Segments は語の読みをふりがなの区間にする。漢字を含む語は送りがなを分けた区間にし、
+// 続くかなだけの区間は1つにまとめる。辞書にない漢字がある場合はエラーを返す
+func (r Result) Segments() ([]furigana.Segment, error) {
+	if unknown := r.Unknown(); len(unknown) > 0 {
+		return nil, fmt.Errorf("no reading for kanji %s", strings.Join(unknown, ", "))
+	}
+	var segments []furigana.Segment
+	add := func(s furigana.Segment) {
+		if last := len(segments) - 1; last >= 0 && s.Reading == "" && segments[last].Reading == "" {
+			segments[last].Text += s.Text
+			return
+		}
+		segments = append(segments, s)
+	}
+	for _, t := range r.Tokens {
+		if furigana.FirstKanji(t.Text) == "" {
+			add(furigana.Segment{Text: t.Text})
+			continue
+		}
+		aligned, err := furigana.Align(t.Text, t.Reading)
+		if err != nil {
+			// 送りがなで分けられない語（当て字など）は語全体に読みを付ける
+			aligned = []furigana.Segment{{Text: t.Text, Reading: t.Reading}}
+		}
+		for _, s := range aligned {
+			add(s)
+		}
+	}
+	return segments, nil
+}
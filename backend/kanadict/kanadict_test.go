@@ -0,0 +1,94 @@
+package kanadict
+
+import (
+	"strings"
+	"testing"
+
+	"typing-game-backend/furigana"
+)
+
+func TestEmbeddedDictionary(t *testing.T) {
+	d, err := loadEmbedded()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Len() < 100 {
+		t.Errorf("embedded dictionary has %d entries, want at least 100", d.Len())
+	}
+}
+
+func TestRead(t *testing.T) {
+	tests := []struct {
+		text, reading, ruby string
+		ambiguous           []string
+	}{
+		{"食べ物", "たべもの", "食《た》べ物《もの》", nil},
+		{"朝の公園は静かです。", "あさのこうえんはしずかです。", "朝《あさ》の公園《こうえん》は静《しず》かです。", nil},
+		{"犬が歩いています", "いぬがあるいています", "犬《いぬ》が歩《ある》いています", nil},
+		{"コーヒーを飲みます", "こーひーをのみます", "コーヒーを飲《の》みます", nil},
+		{"今日は一日", "きょうはいちにち", "今日《きょう》は一日《いちにち》", []string{"今日", "一日"}},
+	}
+	for _, tt := range tests {
+		result := Default().Read(tt.text)
+		segments, err := result.Segments()
+		if err != nil {
+			t.Errorf("Read(%q).Segments(): %v", tt.text, err)
+			continue
+		}
+		if got := furigana.ToHiragana(furigana.Reading(segments)); got != tt.reading || result.Reading() != tt.reading {
+			t.Errorf("Read(%q) reading = %q (%q), want %q", tt.text, got, result.Reading(), tt.reading)
+		}
+		if got := furigana.Ruby(segments); got != tt.ruby {
+			t.Errorf("Read(%q) ruby = %q, want %q", tt.text, got, tt.ruby)
+		}
+		var ambiguous []string
+		for _, a := range result.Ambiguous() {
+			ambiguous = append(ambiguous, a.Text)
+		}
+		if strings.Join(ambiguous, ",") != strings.Join(tt.ambiguous, ",") {
+			t.Errorf("Read(%q) ambiguous = %v, want %v", tt.text, ambiguous, tt.ambiguous)
+		}
+	}
+
+	result := Default().Read("鬱な日")
+	if unknown := result.Unknown(); len(unknown) != 1 || unknown[0] != "鬱" {
+		t.Errorf("Unknown() = %v, want [鬱]", unknown)
+	}
+	if _, err := result.Segments(); err == nil {
+		t.Errorf("Segments() with an unknown kanji: want error")
+	}
+}
+
+func TestLoad(t *testing.T) {
+	d := New()
+	if err := d.Load(strings.NewReader("# comment\n日本\tにほん,ニッポン\n日本\tにほん\n")); err != nil {
+		t.Fatal(err)
+	}
+	var b strings.Builder
+	if err := d.Write(&b); err != nil || b.String() != "日本\tにほん,にっぽん\n" {
+		t.Errorf("Write() = %q, %v; want the readings merged in hiragana", b.String(), err)
+	}
+
+	for _, invalid := range []string{"にほん\tにほん\n", "日本\tnihon\n", "日本\n"} {
+		if err := New().Load(strings.NewReader(invalid)); err == nil {
+			t.Errorf("Load(%q): want error", invalid)
+		}
+	}
+}
+
+func TestLoadIPADIC(t *testing.T) {
+	csv := "食べ物,1285,1285,5000,名詞,一般,*,*,*,*,食べ物,タベモノ,タベモノ\n" +
+		"です,1,1,100,助動詞,*,*,*,特殊・デス,基本形,です,デス,デス\n" +
+		"鬱,1285,1285,5000,名詞,一般,*,*,*,*,鬱,ウツ,ウツ\n"
+	d := New()
+	added, err := d.LoadIPADIC(strings.NewReader(csv))
+	if err != nil || added != 2 {
+		t.Fatalf("LoadIPADIC = %d, %v; want 2 words (kana-only words skipped)", added, err)
+	}
+	if got := d.Read("鬱な食べ物").Reading(); got != "うつなたべもの" {
+		t.Errorf("reading = %q, want うつなたべもの", got)
+	}
+	if _, err := d.LoadIPADIC(strings.NewReader("食べ物,1,1\n")); err == nil {
+		t.Errorf("LoadIPADIC with missing columns: want error")
+	}
+}
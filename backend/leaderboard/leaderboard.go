@@ -0,0 +1,320 @@
+// Package leaderboard はリーダーボードのビュー（全体・カテゴリー別・期間別・モード別・単語リスト別）の定義と、
+// スコアからビューを再構築して保存済みのデータと比較する処理をまとめたもの
+package leaderboard
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"typing-game-backend/game"
+	"typing-game-backend/wordlist"
+)
+
+// Global は全体のリーダーボード（leaderboard テーブル）を表すビュー
+const Global = "global"
+
+// ViewScoreIndex はビューごとにスコア順で取得するための leaderboard views テーブルのLSI
+const ViewScoreIndex = "ViewScoreIndex"
+
+// Period は期間別ビューの単位
+type Period string
+
+const (
+	Weekly  Period = "weekly"
+	Monthly Period = "monthly"
+)
+
+// 期間の区切りは日本時間で判定する
+var jst = time.FixedZone("JST", 9*60*60)
+
+var (
+	weeklyViewPattern  = regexp.MustCompile(`^weekly#\d{4}-W\d{2}$`)
+	monthlyViewPattern = regexp.MustCompile(`^monthly#\d{4}-\d{2}$`)
+)
+
+// CategoryView はカテゴリー別ビューのキーを返す（例: category#beginner_words）
+func CategoryView(category string) string {
+	return "category#" + category
+}
+
+// PeriodView は t を含む期間のビューのキーを返す（例: weekly#2025-W03, monthly#2025-01）
+func PeriodView(period Period, t time.Time) string {
+	t = t.In(jst)
+	switch period {
+	case Weekly:
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("weekly#%d-W%02d", year, week)
+	default:
+		return "monthly#" + t.Format("2006-01")
+	}
+}
+
+// ModeView はモード別ビューのキーを返す（例: mode#endless）。
+// タイムアタックは制限時間（duration 秒）ごとに分ける（例: mode#time_attack#60）
+func ModeView(mode game.Mode, duration int) string {
+	if mode == game.ModeTimeAttack {
+		return fmt.Sprintf("mode#%s#%d", mode, duration)
+	}
+	return "mode#" + string(mode)
+}
+
+// ListView はカスタム単語リスト別ビューのキーを返す。通常のモードは list#<リストの ID>、
+// それ以外のモードはモード別ビューのキーを続ける（例: list#list_K7Q2M9XA#mode#endless）
+func ListView(listID string, mode game.Mode, duration int) string {
+	if mode == "" || mode == game.ModeStandard {
+		return "list#" + listID
+	}
+	return "list#" + listID + "#" + ModeView(mode, duration)
+}
+
+// PrimaryView はスコアで自己ベストを判定するビューを返す。
+// カスタム単語リスト（category がリストの ID）のスコアはモードごとのリスト別ビュー、
+// 通常のモード（記録を始める前のスコアの空文字を含む）は全体（leaderboard テーブル）、それ以外はモード別ビュー
+func PrimaryView(category string, mode game.Mode, duration int) string {
+	switch {
+	case wordlist.IsID(category):
+		return ListView(category, mode, duration)
+	case mode == "" || mode == game.ModeStandard:
+		return Global
+	default:
+		return ModeView(mode, duration)
+	}
+}
+
+// Order はビューの順位の決め方
+type Order string
+
+const (
+	ByScore Order = "score" // スコアの高い順
+	ByRound Order = "round" // ラウンド数（エンドレスモードのウェーブ数）、スコアの高い順
+	ByTime  Order = "time"  // 入力時間（elapsed_ms）の短い順、スコアの高い順
+)
+
+// OrderOf は view の順位の決め方を返す（リスト別ビューはモード別ビューと同じ）
+func OrderOf(view string) Order {
+	if _, modeView, ok := splitListView(view); ok {
+		view = modeView
+	}
+	switch view {
+	case ModeView(game.ModeEndless, 0):
+		return ByRound
+	case ModeView(game.ModePractice, 0):
+		return ByTime
+	default:
+		return ByScore
+	}
+}
+
+// ViewsFor は通常のモードのスコアが反映されるビューを返す。全体（Global）は leaderboard テーブルで管理するため含まない。
+// 通常以外のモードとカスタム単語リストのスコアは PrimaryView だけに反映する
+func ViewsFor(category string, at time.Time) []string {
+	return []string{
+		CategoryView(category),
+		PeriodView(Weekly, at),
+		PeriodView(Monthly, at),
+	}
+}
+
+// ValidateView はコマンドラインなどで指定されたビューのキーを検証する
+func ValidateView(view string) error {
+	switch {
+	case view == Global:
+		return nil
+	case strings.HasPrefix(view, "category#") && len(view) > len("category#"):
+		return nil
+	case weeklyViewPattern.MatchString(view), monthlyViewPattern.MatchString(view):
+		return nil
+	case validModeView(view):
+		return nil
+	case validListView(view):
+		return nil
+	default:
+		return fmt.Errorf("invalid leaderboard view %q (expected global, category#<category>, weekly#YYYY-Www, monthly#YYYY-MM, mode#<mode>, mode#time_attack#<seconds> or list#<list>[#mode#<mode>])", view)
+	}
+}
+
+// splitListView はリスト別ビューのキーをリストの ID とモード別ビューのキー（通常のモードは空）に分ける
+func splitListView(view string) (listID, modeView string, ok bool) {
+	rest, ok := strings.CutPrefix(view, "list#")
+	if !ok {
+		return "", "", false
+	}
+	listID, modeView, _ = strings.Cut(rest, "#")
+	return listID, modeView, true
+}
+
+// validListView は view がカスタム単語リスト別ビューかを返す
+func validListView(view string) bool {
+	listID, modeView, ok := splitListView(view)
+	if !ok || !wordlist.IsID(listID) {
+		return false
+	}
+	return modeView == "" || validModeView(modeView)
+}
+
+// validModeView は view が通常以外のモードのビューかを返す
+func validModeView(view string) bool {
+	rest, ok := strings.CutPrefix(view, "mode#")
+	if !ok {
+		return false
+	}
+	name, duration, timed := strings.Cut(rest, "#")
+	mode := game.Mode(name)
+	if !mode.Valid() || mode == game.ModeStandard {
+		return false
+	}
+	if mode != game.ModeTimeAttack {
+		return !timed
+	}
+	seconds, err := strconv.Atoi(duration)
+	return err == nil && game.ValidDuration(seconds) && view == ModeView(mode, seconds)
+}
+
+// Entry はビュー内の1プレイヤーの自己ベスト
+type Entry struct {
+	PlayerName string `dynamodbav:"player_name" json:"player_name"`
+	Score      int    `dynamodbav:"score" json:"score"`
+	Round      int    `dynamodbav:"round" json:"round"`
+	Category   string `dynamodbav:"category" json:"category"`
+	Timestamp  int64  `dynamodbav:"timestamp" json:"timestamp"`
+	// Mode はスコアのモード（記録を始める前のスコアは空で、通常のモードとして扱う）
+	Mode game.Mode `dynamodbav:"mode,omitempty" json:"mode,omitempty"`
+	// 以下はタイピングテスト（タイムアタック・練習）のスコアのみ
+	Duration  int     `dynamodbav:"duration,omitempty" json:"duration,omitempty"`
+	ElapsedMs int     `dynamodbav:"elapsed_ms,omitempty" json:"elapsed_ms,omitempty"`
+	WPM       float64 `dynamodbav:"wpm,omitempty" json:"wpm,omitempty"`
+	Accuracy  float64 `dynamodbav:"accuracy,omitempty" json:"accuracy,omitempty"`
+}
+
+// compare は view での e と other の記録を比べ、e が上位なら正、下位なら負、同じ記録なら0を返す
+func compare(view string, e, other Entry) int {
+	switch OrderOf(view) {
+	case ByRound:
+		if e.Round != other.Round {
+			return e.Round - other.Round
+		}
+	case ByTime:
+		if e.ElapsedMs != other.ElapsedMs {
+			return other.ElapsedMs - e.ElapsedMs
+		}
+	}
+	return e.Score - other.Score
+}
+
+// better は view で e が other より上位の記録かを返す。同じ記録の場合は先に達成した記録を優先する
+func (e Entry) better(view string, other Entry) bool {
+	if c := compare(view, e, other); c != 0 {
+		return c > 0
+	}
+	return e.Timestamp < other.Timestamp
+}
+
+// Less は view の順位で a が b より上位かを返す（並べ替え用）
+func Less(view string, a, b Entry) bool {
+	return a.better(view, b)
+}
+
+// Builder はスコアを1件ずつ受け取り、ビューごとのプレイヤーの自己ベストを集計する。
+// 複数のスキャンセグメントから並行して呼び出してよい
+type Builder struct {
+	include func(view string) bool
+
+	mu    sync.Mutex
+	views map[string]map[string]Entry
+}
+
+// NewBuilder は include が true を返すビューだけを集計する Builder を返す
+func NewBuilder(include func(view string) bool) *Builder {
+	return &Builder{include: include, views: make(map[string]map[string]Entry)}
+}
+
+// Add はスコアを PrimaryView と、全体のビューに反映するスコアであればカテゴリー別・期間別のビューに反映する
+func (b *Builder) Add(score Entry) {
+	views := []string{PrimaryView(score.Category, score.Mode, score.Duration)}
+	if views[0] == Global {
+		views = append(views, ViewsFor(score.Category, time.Unix(score.Timestamp, 0))...)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, view := range views {
+		if !b.include(view) {
+			continue
+		}
+		entries, ok := b.views[view]
+		if !ok {
+			entries = make(map[string]Entry)
+			b.views[view] = entries
+		}
+		if current, ok := entries[score.PlayerName]; !ok || score.better(view, current) {
+			entries[score.PlayerName] = score
+		}
+	}
+}
+
+// Views は集計結果（ビュー → プレイヤー名 → 自己ベスト）を返す
+func (b *Builder) Views() map[string]map[string]Entry {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.views
+}
+
+// ChangeKind は保存済みのビューと再構築したビューの差分の種類
+type ChangeKind string
+
+const (
+	Missing ChangeKind = "missing" // スコアはあるがビューに記録がない
+	Stale   ChangeKind = "stale"   // ビューの記録がスコアと一致しない
+	Extra   ChangeKind = "extra"   // ビューに記録があるが対応するスコアがない
+)
+
+// Change はビュー内の1プレイヤー分の差分
+type Change struct {
+	View       string     `json:"view"`
+	PlayerName string     `json:"player_name"`
+	Kind       ChangeKind `json:"kind"`
+	Expected   *Entry     `json:"expected,omitempty"`
+	Live       *Entry     `json:"live,omitempty"`
+}
+
+// Diff は再構築したビュー（expected）と保存済みのビュー（live）を比較し、プレイヤー名順に差分を返す。
+// 同じ記録（同点）はどちらを保持していても一致とみなす。
+// expiredBefore（Unix秒、0 で無効）より前に達成された記録は、元のスコアが保持期間を過ぎて
+// 削除されている可能性があるため、再構築した記録より高くても差分にしない
+func Diff(view string, expected, live map[string]Entry, expiredBefore int64) []Change {
+	expired := func(e Entry) bool {
+		return expiredBefore > 0 && e.Timestamp < expiredBefore
+	}
+
+	var changes []Change
+	for name, want := range expected {
+		want := want
+		got, ok := live[name]
+		switch {
+		case !ok:
+			changes = append(changes, Change{View: view, PlayerName: name, Kind: Missing, Expected: &want})
+		case compare(view, got, want) > 0 && expired(got):
+		case compare(view, got, want) != 0:
+			got := got
+			changes = append(changes, Change{View: view, PlayerName: name, Kind: Stale, Expected: &want, Live: &got})
+		}
+	}
+	for name, got := range live {
+		got := got
+		if _, ok := expected[name]; !ok && !expired(got) {
+			changes = append(changes, Change{View: view, PlayerName: name, Kind: Extra, Live: &got})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].PlayerName < changes[j].PlayerName
+	})
+	return changes
+}
@@ -0,0 +1,175 @@
+package leaderboard
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"typing-game-backend/game"
+)
+
+func TestViewsFor(t *testing.T) {
+	// 日本時間では 2025-01-01（UTC では 2024-12-31）
+	at := time.Date(2024, 12, 31, 16, 0, 0, 0, time.UTC)
+	want := []string{"category#beginner_words", "weekly#2025-W01", "monthly#2025-01"}
+	if got := ViewsFor("beginner_words", at); !slices.Equal(got, want) {
+		t.Errorf("ViewsFor() = %v, want %v", got, want)
+	}
+}
+
+func TestValidateView(t *testing.T) {
+	tests := []struct {
+		view  string
+		valid bool
+	}{
+		{"global", true},
+		{"category#beginner_words", true},
+		{"weekly#2025-W03", true},
+		{"monthly#2025-01", true},
+		{"mode#endless", true},
+		{"mode#practice", true},
+		{"mode#time_attack#60", true},
+		{"list#list_K7Q2M9XA", true},
+		{"list#list_K7Q2M9XA#mode#time_attack#120", true},
+
+		{"", false},
+		{"category#", false},
+		{"weekly#2025-01", false},
+		{"monthly#2025-W03", false},
+		{"mode#standard", false}, // 通常のモードは global
+		{"mode#unknown", false},
+		{"mode#endless#60", false},
+		{"mode#time_attack", false},
+		{"mode#time_attack#45", false},
+		{"mode#time_attack#060", false},
+		{"list#beginner_words", false},
+		{"list#list_K7Q2M9XA#mode#standard", false},
+	}
+	for _, tt := range tests {
+		if err := ValidateView(tt.view); (err == nil) != tt.valid {
+			t.Errorf("ValidateView(%q) = %v, want valid = %v", tt.view, err, tt.valid)
+		}
+	}
+}
+
+func TestBuilder(t *testing.T) {
+	at := time.Date(2025, 1, 15, 3, 0, 0, 0, time.UTC).Unix()
+	scores := []Entry{
+		{PlayerName: "taro", Score: 100, Round: 3, Category: "beginner_words", Timestamp: at},
+		{PlayerName: "taro", Score: 300, Round: 5, Category: "beginner_words", Timestamp: at + 10},
+		{PlayerName: "taro", Score: 200, Round: 4, Category: "beginner_words", Timestamp: at + 20},
+		// 同じ記録は先に達成した記録を優先する
+		{PlayerName: "hanako", Score: 150, Round: 3, Category: "beginner_words", Timestamp: at + 5},
+		{PlayerName: "hanako", Score: 150, Round: 3, Category: "beginner_words", Timestamp: at + 1},
+		// エンドレスモードはウェーブ数、練習は入力時間で順位を決める
+		{PlayerName: "taro", Score: 900, Round: 2, Category: "beginner_words", Mode: game.ModeEndless, Timestamp: at},
+		{PlayerName: "taro", Score: 100, Round: 4, Category: "beginner_words", Mode: game.ModeEndless, Timestamp: at + 1},
+		{PlayerName: "taro", Score: 900, Category: "beginner_words", Mode: game.ModePractice, ElapsedMs: 30000, Timestamp: at},
+		{PlayerName: "taro", Score: 100, Category: "beginner_words", Mode: game.ModePractice, ElapsedMs: 20000, Timestamp: at + 1},
+		{PlayerName: "jiro", Score: 500, Category: "list_K7Q2M9XA", Timestamp: at},
+	}
+	b := NewBuilder(func(view string) bool { return view != "monthly#2025-01" })
+	for _, s := range scores {
+		b.Add(s)
+	}
+	views := b.Views()
+
+	best := func(view, player string) Entry {
+		t.Helper()
+		e, ok := views[view][player]
+		if !ok {
+			t.Fatalf("%s has no entry for %s", view, player)
+		}
+		return e
+	}
+	for _, view := range []string{Global, "category#beginner_words", "weekly#2025-W03"} {
+		if e := best(view, "taro"); e.Score != 300 {
+			t.Errorf("%s: taro = %d, want 300", view, e.Score)
+		}
+		if e := best(view, "hanako"); e.Timestamp != at+1 {
+			t.Errorf("%s: hanako timestamp = %d, want the earlier tie %d", view, e.Timestamp, at+1)
+		}
+	}
+	if e := best("mode#endless", "taro"); e.Round != 4 {
+		t.Errorf("mode#endless: taro round = %d, want 4", e.Round)
+	}
+	if e := best("mode#practice", "taro"); e.ElapsedMs != 20000 {
+		t.Errorf("mode#practice: taro elapsed = %d, want 20000", e.ElapsedMs)
+	}
+	if e := best("list#list_K7Q2M9XA", "jiro"); e.Score != 500 {
+		t.Errorf("list#list_K7Q2M9XA: jiro = %d, want 500", e.Score)
+	}
+
+	if _, ok := views["monthly#2025-01"]; ok {
+		t.Error("excluded view monthly#2025-01 was built")
+	}
+	if _, ok := views["category#list_K7Q2M9XA"]; ok {
+		t.Error("word list scores were added to a category view")
+	}
+	if _, ok := views[Global]["jiro"]; ok {
+		t.Error("word list scores were added to the global view")
+	}
+	if got := len(views[Global]); got != 2 {
+		t.Errorf("global has %d players, want 2", got)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	const expiredBefore = 1000
+	expected := map[string]Entry{
+		"same":    {PlayerName: "same", Score: 100, Timestamp: 2000},
+		"tie":     {PlayerName: "tie", Score: 100, Timestamp: 2000},
+		"missing": {PlayerName: "missing", Score: 100, Timestamp: 2000},
+		"lower":   {PlayerName: "lower", Score: 100, Timestamp: 2000},
+		"higher":  {PlayerName: "higher", Score: 100, Timestamp: 2000},
+		"expired": {PlayerName: "expired", Score: 100, Timestamp: 2000},
+	}
+	live := map[string]Entry{
+		"same":  {PlayerName: "same", Score: 100, Timestamp: 2000},
+		"tie":   {PlayerName: "tie", Score: 100, Timestamp: 1500}, // 同点は一致とみなす
+		"lower": {PlayerName: "lower", Score: 50, Timestamp: 1500},
+		// 保持期間内のスコアより高い記録は、スコアと一致しない
+		"higher": {PlayerName: "higher", Score: 200, Timestamp: 1500},
+		// 保持期間を過ぎたスコアの記録は、再構築した記録より高くても差分にしない
+		"expired": {PlayerName: "expired", Score: 200, Timestamp: 500},
+		"extra":   {PlayerName: "extra", Score: 100, Timestamp: 1500},
+		// 保持期間を過ぎたスコアだけの記録は、対応するスコアがなくても差分にしない
+		"expired_extra": {PlayerName: "expired_extra", Score: 100, Timestamp: 500},
+	}
+
+	var got []string
+	for _, c := range Diff(Global, expected, live, expiredBefore) {
+		got = append(got, c.PlayerName+":"+string(c.Kind))
+		if c.View != Global {
+			t.Errorf("%s: view = %q, want %q", c.PlayerName, c.View, Global)
+		}
+		if (c.Expected == nil) != (c.Kind == Extra) || (c.Live == nil) != (c.Kind == Missing) {
+			t.Errorf("%s (%s): expected = %v, live = %v", c.PlayerName, c.Kind, c.Expected, c.Live)
+		}
+	}
+	want := []string{"extra:extra", "higher:stale", "lower:stale", "missing:missing"}
+	if !slices.Equal(got, want) {
+		t.Errorf("Diff() = %v, want %v", got, want)
+	}
+
+	// expiredBefore が 0 の場合は、古い記録も差分にする
+	got = nil
+	for _, c := range Diff(Global, expected, live, 0) {
+		got = append(got, c.PlayerName+":"+string(c.Kind))
+	}
+	want = []string{"expired:stale", "expired_extra:extra", "extra:extra", "higher:stale", "lower:stale", "missing:missing"}
+	if !slices.Equal(got, want) {
+		t.Errorf("Diff() without expiry = %v, want %v", got, want)
+	}
+
+	// ビューの順位の決め方で比較する（エンドレスモードはウェーブ数。スコアが高くてもウェーブ数が少ない記録は保持期間に関わらず差分にする）
+	rebuilt := map[string]Entry{"taro": {PlayerName: "taro", Score: 100, Round: 5, Timestamp: 2000}}
+	saved := map[string]Entry{"taro": {PlayerName: "taro", Score: 900, Round: 4, Timestamp: 500}}
+	if changes := Diff(Global, rebuilt, saved, expiredBefore); len(changes) != 0 {
+		t.Errorf("Diff(%s) = %+v, want no changes", Global, changes)
+	}
+	endless := ModeView(game.ModeEndless, 0)
+	if changes := Diff(endless, rebuilt, saved, expiredBefore); len(changes) != 1 || changes[0].Kind != Stale {
+		t.Errorf("Diff(%s) = %+v, want one stale change", endless, changes)
+	}
+}
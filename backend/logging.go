@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/awslabs/aws-lambda-go-api-proxy/core"
+	"github.com/gin-gonic/gin"
+
+	"typing-game-backend/config"
+)
+
+const requestIDHeader = "X-Request-ID"
+
+type requestIDKey struct{}
+
+// validRequestID はクライアントから受け取るリクエストIDとして許可する形式
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._-]{1,64}$`)
+
+// redactAction はログ属性ごとの秘匿方法
+type redactAction int
+
+const (
+	// redactHash は値を鍵付きハッシュ（HMAC）に置き換える（同一プレイヤーの追跡はできるが名前は残らない）。
+	// 名前・IP アドレスは候補が少なく、鍵のないハッシュは総当たりで元に戻せるため、鍵がない場合は取り除く
+	redactHash redactAction = iota
+	// redactOmit は属性自体をログから取り除く
+	redactOmit
+)
+
+// redactionPolicy はプレイヤーに関わるログ属性の秘匿ポリシー
+var redactionPolicy = map[string]redactAction{
+	"player_name": redactHash,
+	"client_ip":   redactHash,
+	"user_agent":  redactOmit,
+}
+
+// newLogger はJSON形式の構造化ロガーを作成する
+func newLogger(w io.Writer, cfg config.LoggingConfig) *slog.Logger {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(cfg.Level)); err != nil {
+		level = slog.LevelInfo
+	}
+
+	opts := &slog.HandlerOptions{Level: level}
+	if cfg.RedactPlayerData {
+		opts.ReplaceAttr = redactor([]byte(cfg.RedactionKey))
+	}
+
+	return slog.New(slog.NewJSONHandler(w, opts))
+}
+
+// redactor は秘匿ポリシーの属性を key の HMAC-SHA256 に置き換える（key が空の場合は取り除く）ReplaceAttr を返す
+func redactor(key []byte) func(groups []string, a slog.Attr) slog.Attr {
+	return func(groups []string, a slog.Attr) slog.Attr {
+		action, ok := redactionPolicy[a.Key]
+		if !ok {
+			return a
+		}
+		if action == redactOmit || len(key) == 0 {
+			return slog.Attr{}
+		}
+
+		mac := hmac.New(sha256.New, key)
+		mac.Write([]byte(a.Value.String()))
+		return slog.String(a.Key, "redacted:"+hex.EncodeToString(mac.Sum(nil)[:8]))
+	}
+}
+
+// requestIDMiddleware はAPI GatewayのリクエストID（ローカルでは X-Request-ID または生成値）を
+// リクエストのcontextとレスポンスヘッダーに設定する
+func requestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := ""
+		if apiGwCtx, ok := core.GetAPIGatewayContextFromContext(c.Request.Context()); ok {
+			requestID = apiGwCtx.RequestID
+		}
+		if requestID == "" {
+			if id := c.GetHeader(requestIDHeader); validRequestID.MatchString(id) {
+				requestID = id
+			}
+		}
+		if requestID == "" {
+			requestID = newRequestID()
+		}
+
+		ctx := context.WithValue(c.Request.Context(), requestIDKey{}, requestID)
+		c.Request = c.Request.WithContext(ctx)
+		c.Header(requestIDHeader, requestID)
+
+		c.Next()
+	}
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(b)
+}
+
+// loggerFrom はリクエストIDを付与したロガーを返す
+func loggerFrom(ctx context.Context) *slog.Logger {
+	if requestID, ok := ctx.Value(requestIDKey{}).(string); ok {
+		return slog.Default().With("request_id", requestID)
+	}
+	return slog.Default()
+}
+
+// accessLogMiddleware はgin.Loggerの代わりにリクエストごとの構造化ログを出力する
+func accessLogMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		status := c.Writer.Status()
+		level := slog.LevelInfo
+		if status >= 500 {
+			level = slog.LevelError
+		} else if status >= 400 {
+			level = slog.LevelWarn
+		}
+
+		attrs := []slog.Attr{
+			slog.String("method", c.Request.Method),
+			slog.String("route", routeLabel(c)),
+			slog.String("path", c.Request.URL.Path),
+			slog.Int("status", status),
+			slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+			slog.String("client_ip", c.ClientIP()),
+			slog.String("user_agent", c.Request.UserAgent()),
+		}
+		if len(c.Errors) > 0 {
+			attrs = append(attrs, slog.String("errors", strings.TrimSpace(c.Errors.String())))
+		}
+
+		loggerFrom(c.Request.Context()).LogAttrs(c.Request.Context(), level, "request", attrs...)
+	}
+}
+
+// routeLabel はメトリクスとログで使うルート名（パラメータ展開前のパターン）を返す
+func routeLabel(c *gin.Context) string {
+	if route := c.FullPath(); route != "" {
+		return route
+	}
+	return "unmatched"
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// latencyBuckets はレイテンシヒストグラムのバケット上限（秒）
+var latencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5}
+
+type routeKey struct {
+	method string
+	route  string
+}
+
+type routeStats struct {
+	requests map[int]uint64 // ステータスコードごとのリクエスト数
+	errors   uint64         // 5xx の数
+	buckets  []uint64       // latencyBuckets ごとの累積でない件数
+	sum      float64
+	count    uint64
+}
+
+// metricsRegistry はルートごとのリクエスト数・エラー数・レイテンシを集計する
+type metricsRegistry struct {
+	mu     sync.Mutex
+	routes map[routeKey]*routeStats
+}
+
+func newMetricsRegistry() *metricsRegistry {
+	return &metricsRegistry{routes: make(map[routeKey]*routeStats)}
+}
+
+func (m *metricsRegistry) observe(method, route string, status int, latency time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := routeKey{method: method, route: route}
+	stats, ok := m.routes[key]
+	if !ok {
+		stats = &routeStats{
+			requests: make(map[int]uint64),
+			buckets:  make([]uint64, len(latencyBuckets)),
+		}
+		m.routes[key] = stats
+	}
+
+	stats.requests[status]++
+	if status >= 500 {
+		stats.errors++
+	}
+
+	seconds := latency.Seconds()
+	for i, upper := range latencyBuckets {
+		if seconds <= upper {
+			stats.buckets[i]++
+			break
+		}
+	}
+	stats.sum += seconds
+	stats.count++
+}
+
+// middleware はリクエストごとにメトリクスを記録する
+func (m *metricsRegistry) middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		m.observe(c.Request.Method, routeLabel(c), c.Writer.Status(), time.Since(start))
+	}
+}
+
+// handler はPrometheusのテキスト形式でメトリクスを返す
+func (m *metricsRegistry) handler(c *gin.Context) {
+	c.Data(http.StatusOK, "text/plain; version=0.0.4; charset=utf-8", []byte(m.render()))
+}
+
+func (m *metricsRegistry) render() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	keys := make([]routeKey, 0, len(m.routes))
+	for key := range m.routes {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].route != keys[j].route {
+			return keys[i].route < keys[j].route
+		}
+		return keys[i].method < keys[j].method
+	})
+
+	var b strings.Builder
+
+	b.WriteString("# HELP http_requests_total Total number of HTTP requests.\n")
+	b.WriteString("# TYPE http_requests_total counter\n")
+	for _, key := range keys {
+		stats := m.routes[key]
+		statuses := make([]int, 0, len(stats.requests))
+		for status := range stats.requests {
+			statuses = append(statuses, status)
+		}
+		sort.Ints(statuses)
+		for _, status := range statuses {
+			fmt.Fprintf(&b, "http_requests_total{%s,status=\"%d\"} %d\n", key.labels(), status, stats.requests[status])
+		}
+	}
+
+	b.WriteString("# HELP http_request_errors_total Total number of HTTP requests that failed with a 5xx status.\n")
+	b.WriteString("# TYPE http_request_errors_total counter\n")
+	for _, key := range keys {
+		fmt.Fprintf(&b, "http_request_errors_total{%s} %d\n", key.labels(), m.routes[key].errors)
+	}
+
+	b.WriteString("# HELP http_request_duration_seconds HTTP request latency in seconds.\n")
+	b.WriteString("# TYPE http_request_duration_seconds histogram\n")
+	for _, key := range keys {
+		stats := m.routes[key]
+		var cumulative uint64
+		for i, upper := range latencyBuckets {
+			cumulative += stats.buckets[i]
+			fmt.Fprintf(&b, "http_request_duration_seconds_bucket{%s,le=\"%s\"} %d\n", key.labels(), strconv.FormatFloat(upper, 'g', -1, 64), cumulative)
+		}
+		fmt.Fprintf(&b, "http_request_duration_seconds_bucket{%s,le=\"+Inf\"} %d\n", key.labels(), stats.count)
+		fmt.Fprintf(&b, "http_request_duration_seconds_sum{%s} %g\n", key.labels(), stats.sum)
+		fmt.Fprintf(&b, "http_request_duration_seconds_count{%s} %d\n", key.labels(), stats.count)
+	}
+
+	return b.String()
+}
+
+func (k routeKey) labels() string {
+	return fmt.Sprintf("method=%q,route=%q", k.method, k.route)
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"typing-game-backend/apierror"
+	"typing-game-backend/config"
+)
+
+var (
+	corsAllowedMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}
+	corsAllowedHeaders = []string{"Content-Type", "Authorization"}
+	corsExposedHeaders = []string{"ETag"}
+)
+
+// corsMiddleware は許可リストに含まれるオリジンにだけCORSヘッダーを返す
+func corsMiddleware(cfg config.CORSConfig) gin.HandlerFunc {
+	allowAll := slices.Contains(cfg.AllowedOrigins, "*")
+	methods := strings.Join(corsAllowedMethods, ", ")
+	headers := strings.Join(corsAllowedHeaders, ", ")
+	exposed := strings.Join(corsExposedHeaders, ", ")
+	maxAge := strconv.Itoa(int(cfg.MaxAge.Seconds()))
+
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		preflight := c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != ""
+
+		// Originがない（同一オリジン・サーバー間通信）場合はCORSの対象外
+		if origin == "" {
+			if c.Request.Method == http.MethodOptions {
+				c.AbortWithStatus(http.StatusNoContent)
+				return
+			}
+			c.Next()
+			return
+		}
+
+		allowed := allowAll || slices.Contains(cfg.AllowedOrigins, origin)
+		if !allowed {
+			if preflight {
+				c.AbortWithStatus(http.StatusForbidden)
+				return
+			}
+			// ヘッダーを付けなければブラウザがレスポンスの読み取りを拒否する
+			c.Next()
+			return
+		}
+
+		if allowAll {
+			c.Header("Access-Control-Allow-Origin", "*")
+		} else {
+			c.Header("Access-Control-Allow-Origin", origin)
+			c.Writer.Header().Add("Vary", "Origin")
+		}
+		if cfg.AllowCredentials {
+			c.Header("Access-Control-Allow-Credentials", "true")
+		}
+
+		if preflight {
+			c.Header("Access-Control-Allow-Methods", methods)
+			c.Header("Access-Control-Allow-Headers", headers)
+			c.Header("Access-Control-Max-Age", maxAge)
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+
+		c.Header("Access-Control-Expose-Headers", exposed)
+
+		if c.Request.Method == http.MethodOptions {
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// securityHeadersMiddleware はJSON APIに必要な標準のセキュリティヘッダーを付与する
+func securityHeadersMiddleware(cfg config.SecurityConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		h := c.Writer.Header()
+		h.Set("X-Content-Type-Options", "nosniff")
+		h.Set("X-Frame-Options", "DENY")
+		h.Set("Referrer-Policy", "no-referrer")
+		h.Set("Content-Security-Policy", "default-src 'none'; frame-ancestors 'none'")
+		if cfg.HSTS {
+			h.Set("Strict-Transport-Security", "max-age=31536000; includeSubDomains")
+		}
+
+		c.Next()
+	}
+}
+
+// uploadRoute はファイルのアップロードを受け付けるルート（/api/v1・/api からの相対パス）
+const uploadRoute = "/game/lists/import"
+
+// bodyLimitMiddleware はリクエストボディのサイズを制限する。アップロードのルートは MaxUploadBytes まで受け付ける
+func bodyLimitMiddleware(security config.SecurityConfig) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		maxBytes := security.MaxRequestBytes
+		if strings.HasSuffix(c.FullPath(), uploadRoute) {
+			maxBytes = security.MaxUploadBytes
+		}
+		if c.Request.ContentLength > maxBytes {
+			respondError(c, apierror.New(apierror.CodeRequestTooLarge))
+			return
+		}
+
+		// Content-Length が無い（chunked）場合も読み取り時に上限で打ち切る
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBytes)
+
+		c.Next()
+	}
+}
+
+// deprecatedAPIMiddleware はバージョンなしの /api のレスポンスに、/api/v1 への移行を促すヘッダーを付ける
+func deprecatedAPIMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Deprecation", "true")
+		c.Header("Link", `<`+apiV1Prefix+`/openapi.json>; rel="successor-version"`)
+		c.Next()
+	}
+}
@@ -0,0 +1,143 @@
+package names
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// maxCombiningMarks は1文字に続けて付けられる結合文字の上限（記号を重ねて表示を崩す名前を防ぐ）
+const maxCombiningMarks = 2
+
+// invisible は文字種としては文字・記号だが、表示されない（空白に見える）文字
+var invisible = map[rune]bool{
+	'ᅟ': true, // HANGUL CHOSEONG FILLER
+	'ᅠ': true, // HANGUL JUNGSEONG FILLER
+	'ㅤ': true, // HANGUL FILLER
+	'ﾠ': true, // HALFWIDTH HANGUL FILLER
+	'⠀': true, // BRAILLE PATTERN BLANK
+}
+
+// Normalize はNFKC正規化（全角英数字・半角カナなどを統一）し、前後の空白を除いて連続する空白を1つにまとめる
+func Normalize(name string) string {
+	return strings.Join(strings.Fields(norm.NFKC.String(name)), " ")
+}
+
+// validCharacters は制御文字・ゼロ幅文字などの書式文字・私用文字・未割り当ての文字・表示されない文字を含まないか、
+// 結合文字が重ねられすぎていないかを確認する
+func validCharacters(name string) bool {
+	marks := 0
+	for _, r := range name {
+		switch {
+		case r == utf8.RuneError, invisible[r]:
+			return false
+		case unicode.In(r, unicode.Cc, unicode.Cf, unicode.Co, unicode.Cs, unicode.Variation_Selector):
+			return false
+		case !unicode.In(r, unicode.L, unicode.M, unicode.N, unicode.P, unicode.S, unicode.Zs):
+			return false
+		}
+
+		if unicode.In(r, unicode.Mn, unicode.Me) {
+			marks++
+			if marks > maxCombiningMarks {
+				return false
+			}
+		} else {
+			marks = 0
+		}
+	}
+	return true
+}
+
+// Skeleton は見た目が紛らわしい名前が同じ値になるように変換した照合用のキーを返す
+// （Unicode TR39 の skeleton を簡略化したもの）。
+// 大文字・小文字、ひらがな・カタカナ、小書きのかな、ラテン文字の発音区別符号、空白や区切り記号の違いを無視し、
+// キリル文字・ギリシャ文字や数字などの紛らわしい文字をラテン文字・カタカナに寄せる
+func Skeleton(name string) string {
+	var b strings.Builder
+	for _, r := range norm.NFD.String(Normalize(name)) {
+		if r >= '̀' && r <= 'ͯ' {
+			// ラテン文字などの発音区別符号（濁点・半濁点は対象外）
+			continue
+		}
+		if ignored[r] {
+			continue
+		}
+		if m, ok := confusables[r]; ok {
+			r = m
+		}
+		r = unicode.ToLower(r)
+		if r >= 'ぁ' && r <= 'ゖ' {
+			r += 'ァ' - 'ぁ'
+		}
+		if m, ok := confusables[r]; ok {
+			r = m
+		}
+		b.WriteRune(r)
+	}
+
+	skeleton := norm.NFC.String(b.String())
+	for _, seq := range confusableSequences {
+		skeleton = strings.ReplaceAll(skeleton, seq.from, seq.to)
+	}
+	return skeleton
+}
+
+// ignored は照合時に無視する空白・区切り記号
+var ignored = map[rune]bool{
+	' ': true, '_': true, '.': true, '・': true, '·': true,
+}
+
+// confusables は紛らわしい文字と、照合時に置き換える文字
+var confusables = map[rune]rune{
+	// 数字・記号とラテン文字（i は点があるため l とは区別する。Ali と All を別の名前として扱う）
+	'0': 'o', '1': 'l', '|': 'l',
+
+	// キリル文字（大文字は小文字にする前に、見た目の近いラテン文字の大文字に寄せる）
+	'А': 'A', 'В': 'B', 'Е': 'E', 'К': 'K', 'М': 'M', 'Н': 'H', 'О': 'O', 'Р': 'P',
+	'С': 'C', 'Т': 'T', 'У': 'Y', 'Х': 'X', 'І': 'I', 'Ј': 'J', 'Ѕ': 'S',
+	'а': 'a', 'в': 'b', 'е': 'e', 'к': 'k', 'м': 'm', 'н': 'h', 'о': 'o', 'р': 'p',
+	'с': 'c', 'т': 't', 'у': 'y', 'х': 'x', 'і': 'i', 'ј': 'j', 'ѕ': 's', 'ь': 'b',
+	'ԁ': 'd', 'һ': 'h', 'ӏ': 'l', 'ԛ': 'q', 'ԝ': 'w',
+
+	// ギリシャ文字
+	'Α': 'A', 'Β': 'B', 'Ε': 'E', 'Ζ': 'Z', 'Η': 'H', 'Ι': 'I', 'Κ': 'K', 'Μ': 'M',
+	'Ν': 'N', 'Ο': 'O', 'Ρ': 'P', 'Τ': 'T', 'Υ': 'Y', 'Χ': 'X',
+	'α': 'a', 'β': 'b', 'γ': 'y', 'ε': 'e', 'η': 'n', 'ι': 'i', 'κ': 'k', 'ν': 'v',
+	'ο': 'o', 'ρ': 'p', 'τ': 't', 'υ': 'u', 'χ': 'x', 'ω': 'w',
+
+	// 長音記号と紛らわしい横棒
+	'-': 'ー', '‐': 'ー', '‑': 'ー', '‒': 'ー', '–': 'ー', '—': 'ー', '―': 'ー',
+	'−': 'ー', '─': 'ー', '━': 'ー', '一': 'ー',
+
+	// カタカナと紛らわしい漢字
+	'口': 'ロ', '力': 'カ', '工': 'エ', '二': 'ニ', '八': 'ハ', '夕': 'タ', '卜': 'ト',
+
+	// 小書きのかな
+	'ァ': 'ア', 'ィ': 'イ', 'ゥ': 'ウ', 'ェ': 'エ', 'ォ': 'オ', 'ッ': 'ツ',
+	'ャ': 'ヤ', 'ュ': 'ユ', 'ョ': 'ヨ', 'ヮ': 'ワ', 'ヵ': 'カ', 'ヶ': 'ケ',
+}
+
+// confusableSequences は複数の文字の並びが1文字に見えるもの
+var confusableSequences = []struct{ from, to string }{
+	{"rn", "m"},
+	{"vv", "w"},
+}
+
+// leet は禁止語・予約名の照合でだけ使う、文字の代わりに使われやすい数字・記号。
+// Skeleton では区別する i と l（1 は Skeleton で l になる）も、ここでは同じ文字として扱う（k1ll・adm1n を禁止語・予約名と照合する）
+var leet = map[rune]rune{
+	'3': 'e', '4': 'a', '5': 's', '7': 't', '@': 'a', '$': 's', 'i': 'l',
+}
+
+// matchKey は禁止語・予約名の照合に使うキー（Skeleton に加えて数字・記号による言い換えを戻す）
+func matchKey(s string) string {
+	return strings.Map(func(r rune) rune {
+		if m, ok := leet[r]; ok {
+			return m
+		}
+		return r
+	}, Skeleton(s))
+}
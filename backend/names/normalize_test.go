@@ -0,0 +1,41 @@
+package names
+
+import "testing"
+
+func TestSkeleton(t *testing.T) {
+	same := []struct{ a, b string }{
+		{"Taro", "taro"},
+		{"ｔａｒｏ", "taro"},
+		{"たろう", "タロウ"},
+		{"きゃら", "キヤラ"},
+		{"José", "jose"},
+		{"t a_r.o", "taro"},
+		{"T0ro", "toro"},
+		{"Bob1", "Bobl"},
+		{"A|ex", "Alex"},
+		{"Воb", "Bob"},  // キリル文字の В・о
+		{"Аlі", "Ali"},  // キリル文字の А・і
+		{"Sarn", "Sam"}, // rn と m
+		{"ター", "タ-"},    // 長音記号と横棒
+		{"カロ", "力口"},    // カタカナと漢字
+		{"A1l", "All"},
+	}
+	for _, tt := range same {
+		if a, b := Skeleton(tt.a), Skeleton(tt.b); a != b {
+			t.Errorf("Skeleton(%q) = %q, Skeleton(%q) = %q, want the same", tt.a, a, tt.b, b)
+		}
+	}
+
+	different := []struct{ a, b string }{
+		{"Ali", "All"},
+		{"ALI", "ALL"},
+		{"Taro", "Jiro"},
+		{"たろう", "たろ"},
+		{"ハナ", "バナ"}, // 濁点は区別する
+	}
+	for _, tt := range different {
+		if a, b := Skeleton(tt.a), Skeleton(tt.b); a == b {
+			t.Errorf("Skeleton(%q) = Skeleton(%q) = %q, want different", tt.a, tt.b, a)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+// Package names はプレイヤー名のポリシー（正規化・使用できない文字・禁止語・予約名・紛らわしい名前の照合）を扱う
+package names
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+// Code はプレイヤー名を受け付けない理由を表す安定したコード（APIのエラーコードとしてそのまま返す）
+type Code string
+
+const (
+	CodeEmpty             Code = "name_empty"
+	CodeTooLong           Code = "name_too_long"
+	CodeInvalidCharacters Code = "name_invalid_characters"
+	CodeBlocked           Code = "name_blocked"
+	CodeReserved          Code = "name_reserved"
+	// CodeTaken は別のプレイヤーの名前と紛らわしい（Skeleton が同じ）ことを表す
+	CodeTaken Code = "name_taken"
+)
+
+// Violation はプレイヤー名がポリシーに違反していることを表すエラー
+type Violation struct {
+	Code      Code
+	MaxLength int // CodeTooLong の場合の文字数の上限
+}
+
+func (v *Violation) Error() string {
+	return "invalid player name: " + string(v.Code)
+}
+
+//go:embed blocklist.txt
+var defaultBlocklist string
+
+//go:embed reserved.txt
+var defaultReserved string
+
+// Options はポリシーの設定。ファイル・リストは組み込みの禁止語・予約名に追加される
+type Options struct {
+	MaxLength     int
+	BlocklistFile string
+	ReservedFile  string
+	Blocked       []string
+	Reserved      []string
+}
+
+// term は照合用に変換した禁止語・予約名
+type term struct {
+	key string
+	// partial は部分一致で照合する（かな・漢字の語と、先頭に * を付けた語）。
+	// それ以外の英字の語は、名前を英字以外で区切った単語単位で照合する
+	partial bool
+	// prefix は前方一致で照合する（末尾に * を付けた予約名）
+	prefix bool
+}
+
+// Policy はプレイヤー名を検証する
+type Policy struct {
+	maxLength int
+	blocked   []term
+	reserved  []term
+}
+
+// New は組み込みのリストと opts からポリシーを作成する
+func New(opts Options) (*Policy, error) {
+	blocklist, err := readLists(defaultBlocklist, opts.BlocklistFile, opts.Blocked)
+	if err != nil {
+		return nil, err
+	}
+	reserved, err := readLists(defaultReserved, opts.ReservedFile, opts.Reserved)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Policy{maxLength: opts.MaxLength}
+	for _, entry := range blocklist {
+		p.blocked = append(p.blocked, blockedTerms(entry)...)
+	}
+	for _, entry := range reserved {
+		prefix := strings.HasSuffix(entry, "*")
+		p.reserved = append(p.reserved, term{key: matchKey(strings.TrimSuffix(entry, "*")), prefix: prefix})
+	}
+	return p, nil
+}
+
+// Check は名前を正規化して検証し、保存に使う正規化後の名前を返す。違反がある場合は *Violation を返す。
+// 他のプレイヤーとの重複（CodeTaken）は保存時に Skeleton で判定する
+func (p *Policy) Check(name string) (string, error) {
+	normalized := Normalize(name)
+	switch {
+	case normalized == "":
+		return "", &Violation{Code: CodeEmpty}
+	case !validCharacters(normalized):
+		return "", &Violation{Code: CodeInvalidCharacters}
+	case utf8.RuneCountInString(normalized) > p.maxLength:
+		return "", &Violation{Code: CodeTooLong, MaxLength: p.maxLength}
+	}
+
+	key := matchKey(normalized)
+	if key == "" {
+		// 区切り記号だけの名前
+		return "", &Violation{Code: CodeInvalidCharacters}
+	}
+	for _, t := range p.reserved {
+		if key == t.key || (t.prefix && strings.HasPrefix(key, t.key)) {
+			return "", &Violation{Code: CodeReserved}
+		}
+	}
+
+	words := strings.FieldsFunc(key, func(r rune) bool { return r < 'a' || r > 'z' })
+	for _, t := range p.blocked {
+		if t.partial && strings.Contains(key, t.key) {
+			return "", &Violation{Code: CodeBlocked}
+		}
+		for _, w := range words {
+			if !t.partial && w == t.key {
+				return "", &Violation{Code: CodeBlocked}
+			}
+		}
+	}
+
+	return normalized, nil
+}
+
+// blockedTerms は禁止語1件を照合用の語に変換する。かなの語にはローマ字表記の語も加える
+func blockedTerms(entry string) []term {
+	partial := strings.HasPrefix(entry, "*")
+	entry = strings.TrimPrefix(entry, "*")
+
+	key := matchKey(entry)
+	if key == "" {
+		return nil
+	}
+	terms := []term{{key: key, partial: partial || !isLatin(key)}}
+	for _, variant := range romajiVariants(entry) {
+		terms = append(terms, term{key: matchKey(variant), partial: partial})
+	}
+	return terms
+}
+
+func isLatin(s string) bool {
+	for _, r := range s {
+		if r < 'a' || r > 'z' {
+			return false
+		}
+	}
+	return true
+}
+
+// readLists は組み込みのリスト・ファイル・追加の語をまとめて1行1語のリストにする
+func readLists(embedded, path string, extra []string) ([]string, error) {
+	entries := parseList(embedded)
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read name list %s: %w", path, err)
+		}
+		entries = append(entries, parseList(string(data))...)
+	}
+	for _, e := range extra {
+		if e = strings.TrimSpace(e); e != "" {
+			entries = append(entries, e)
+		}
+	}
+	return entries, nil
+}
+
+// parseList は1行1語のリストを読む。空行と # で始まる行は無視する
+func parseList(s string) []string {
+	var entries []string
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		entries = append(entries, line)
+	}
+	return entries
+}
@@ -0,0 +1,91 @@
+package names
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCheck(t *testing.T) {
+	p, err := New(Options{MaxLength: 20, Blocked: []string{"*spam"}, Reserved: []string{"gm*"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		want Code // 空の場合は受け付ける
+	}{
+		// 受け付ける名前
+		{"Taro", ""},
+		{"たろう", ""},
+		{"Ali", ""},
+		{"Alice", ""},
+		{"Cassandra", ""}, // ass は単語単位で照合する
+		{"Sunshine", ""},  // shine（しね）は単語単位で照合する
+		{"shinei", ""},
+		{"myadmin", ""}, // 予約名は名前全体で照合する
+		{"admins", ""},
+		{"root beer", ""},
+		{"gem", ""},
+
+		// 使用できない文字・長さ
+		{"", CodeEmpty},
+		{"   ", CodeEmpty},
+		{"a\u200bb", CodeInvalidCharacters},
+		{"._", CodeInvalidCharacters},
+		{strings.Repeat("あ", 21), CodeTooLong},
+
+		// 禁止語（部分一致・単語単位・紛らわしい文字・数字による言い換え）
+		{"motherfucker", CodeBlocked},
+		{"ＦＵＣＫ", CodeBlocked},
+		{"ass", CodeBlocked},
+		{"ass!", CodeBlocked},
+		{"a$$", CodeBlocked},
+		{"k1llyourself", CodeBlocked},
+		{"spammer", CodeBlocked},
+
+		// かなの禁止語とローマ字表記
+		{"しね", CodeBlocked},
+		{"シネ", CodeBlocked},
+		{"おまえしね", CodeBlocked},
+		{"shine", CodeBlocked},
+		{"sine", CodeBlocked},
+		{"sh1ne", CodeBlocked},
+		{"殺す", CodeBlocked},
+
+		// 予約名（完全一致・前方一致）
+		{"admin", CodeReserved},
+		{"ADMIN", CodeReserved},
+		{"adm1n", CodeReserved},
+		{"ａｄｍｉｎ", CodeReserved},
+		{"аdmin", CodeReserved}, // キリル文字の а
+		{"運営", CodeReserved},
+		{"Anonymous 42", CodeReserved},
+		{"gm123", CodeReserved},
+	}
+	for _, tt := range tests {
+		_, err := p.Check(tt.name)
+		var v *Violation
+		switch {
+		case tt.want == "" && err != nil:
+			t.Errorf("Check(%q) = %v, want no error", tt.name, err)
+		case tt.want != "" && (!errors.As(err, &v) || v.Code != tt.want):
+			t.Errorf("Check(%q) = %v, want %s", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestCheckNormalizes(t *testing.T) {
+	p, err := New(Options{MaxLength: 20})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := p.Check("  ｔａｒｏ   ﾀﾛｳ ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "taro タロウ"; got != want {
+		t.Errorf("Check() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,126 @@
+package names
+
+import (
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// maxRomajiVariants は1語から作るローマ字表記の上限（ヘボン式・訓令式の組み合わせで増えすぎないようにする）
+const maxRomajiVariants = 64
+
+// romaji はカタカナ1〜2文字（拗音を含む）のローマ字表記。ヘボン式・訓令式の両方を含む
+var romaji = map[string][]string{
+	"ア": {"a"}, "イ": {"i"}, "ウ": {"u"}, "エ": {"e"}, "オ": {"o"},
+	"カ": {"ka"}, "キ": {"ki"}, "ク": {"ku"}, "ケ": {"ke"}, "コ": {"ko"},
+	"サ": {"sa"}, "シ": {"shi", "si"}, "ス": {"su"}, "セ": {"se"}, "ソ": {"so"},
+	"タ": {"ta"}, "チ": {"chi", "ti"}, "ツ": {"tsu", "tu"}, "テ": {"te"}, "ト": {"to"},
+	"ナ": {"na"}, "ニ": {"ni"}, "ヌ": {"nu"}, "ネ": {"ne"}, "ノ": {"no"},
+	"ハ": {"ha"}, "ヒ": {"hi"}, "フ": {"fu", "hu"}, "ヘ": {"he"}, "ホ": {"ho"},
+	"マ": {"ma"}, "ミ": {"mi"}, "ム": {"mu"}, "メ": {"me"}, "モ": {"mo"},
+	"ヤ": {"ya"}, "ユ": {"yu"}, "ヨ": {"yo"},
+	"ラ": {"ra"}, "リ": {"ri"}, "ル": {"ru"}, "レ": {"re"}, "ロ": {"ro"},
+	"ワ": {"wa"}, "ヲ": {"wo", "o"},
+	"ガ": {"ga"}, "ギ": {"gi"}, "グ": {"gu"}, "ゲ": {"ge"}, "ゴ": {"go"},
+	"ザ": {"za"}, "ジ": {"ji", "zi"}, "ズ": {"zu"}, "ゼ": {"ze"}, "ゾ": {"zo"},
+	"ダ": {"da"}, "ヂ": {"ji", "di"}, "ヅ": {"zu", "du"}, "デ": {"de"}, "ド": {"do"},
+	"バ": {"ba"}, "ビ": {"bi"}, "ブ": {"bu"}, "ベ": {"be"}, "ボ": {"bo"},
+	"パ": {"pa"}, "ピ": {"pi"}, "プ": {"pu"}, "ペ": {"pe"}, "ポ": {"po"},
+	"ヴ": {"vu"},
+	"ァ": {"a"}, "ィ": {"i"}, "ゥ": {"u"}, "ェ": {"e"}, "ォ": {"o"},
+	"ャ": {"ya"}, "ュ": {"yu"}, "ョ": {"yo"},
+	"ティ": {"ti"}, "ディ": {"di"}, "ファ": {"fa"}, "フィ": {"fi"}, "フェ": {"fe"}, "フォ": {"fo"},
+	"ウィ": {"wi"}, "ウェ": {"we"}, "シェ": {"she"}, "ジェ": {"je"}, "チェ": {"che"},
+}
+
+func init() {
+	// 拗音（キャ・シュ・チョなど）
+	yoon := map[string][]string{
+		"キ": {"ky"}, "ギ": {"gy"}, "ニ": {"ny"}, "ヒ": {"hy"}, "ビ": {"by"}, "ピ": {"py"}, "ミ": {"my"}, "リ": {"ry"},
+		"シ": {"sh", "sy"}, "ジ": {"j", "zy", "jy"}, "チ": {"ch", "ty", "cy"},
+	}
+	for kana, prefixes := range yoon {
+		for small, vowel := range map[string]string{"ャ": "a", "ュ": "u", "ョ": "o"} {
+			for _, p := range prefixes {
+				romaji[kana+small] = append(romaji[kana+small], p+vowel)
+			}
+		}
+	}
+}
+
+// romajiVariants はかなだけの語のローマ字表記をすべて返す。かな以外の文字を含む場合は nil を返す
+func romajiVariants(word string) []string {
+	kana := []rune(norm.NFKC.String(word))
+	for i, r := range kana {
+		if r >= 'ぁ' && r <= 'ゖ' {
+			kana[i] = r + ('ァ' - 'ぁ')
+		}
+	}
+
+	variants := []string{""}
+	doubleNext := false
+	for i := 0; i < len(kana); {
+		r := kana[i]
+
+		var options []string
+		switch r {
+		case 'ッ':
+			doubleNext = true
+			i++
+			continue
+		case 'ン':
+			options = []string{"n", "nn"}
+			i++
+		case 'ー':
+			// 長音は直前の母音を重ねるか、省略する
+			options = []string{""}
+			for _, v := range variants {
+				if v != "" {
+					options = append(options, v[len(v)-1:])
+					break
+				}
+			}
+			i++
+		default:
+			if i+1 < len(kana) {
+				options = romaji[string(kana[i:i+2])]
+			}
+			if options != nil {
+				i += 2
+			} else {
+				options = romaji[string(r)]
+				i++
+			}
+		}
+		if options == nil {
+			return nil
+		}
+
+		if doubleNext {
+			// 促音は次の子音を重ねる（チ → tchi / cchi）
+			doubled := make([]string, 0, len(options)*2)
+			for _, o := range options {
+				doubled = append(doubled, o[:1]+o)
+				if strings.HasPrefix(o, "ch") {
+					doubled = append(doubled, "t"+o)
+				}
+			}
+			options = doubled
+			doubleNext = false
+		}
+
+		next := make([]string, 0, len(variants)*len(options))
+		for _, v := range variants {
+			for _, o := range options {
+				if len(next) < maxRomajiVariants {
+					next = append(next, v+o)
+				}
+			}
+		}
+		variants = next
+	}
+	if len(variants) == 1 && variants[0] == "" {
+		return nil
+	}
+	return variants
+}
@@ -0,0 +1,229 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/gin-gonic/gin"
+
+	"typing-game-backend/apierror"
+	"typing-game-backend/openapi"
+)
+
+// apiV1Prefix は OpenAPI の定義（servers）と同じ、バージョン付きAPIのパス
+const apiV1Prefix = "/api/v1"
+
+// ginParam は Gin のパスパラメータ（:category）を OpenAPI の形式（{category}）に変換するための正規表現
+var ginParam = regexp.MustCompile(`:([A-Za-z0-9_]+)`)
+
+func init() {
+	// 単語帳のアップロード（multipart/form-data）でブラウザが .tsv のファイルに付ける Content-Type
+	openapi3filter.RegisterBodyDecoder("text/tab-separated-values", openapi3filter.FileBodyDecoder)
+}
+
+// specValidator は /api/v1 のリクエスト（と設定によりレスポンス）を OpenAPI の定義で検証する
+type specValidator struct {
+	doc               *openapi3.T
+	routes            map[string]*routers.Route // "GET /game/words/{category}/{round}" → ルート
+	validateRequests  bool
+	validateResponses bool
+}
+
+func newSpecValidator(doc *openapi3.T, validateRequests, validateResponses bool) *specValidator {
+	v := &specValidator{
+		doc:               doc,
+		routes:            make(map[string]*routers.Route),
+		validateRequests:  validateRequests,
+		validateResponses: validateResponses,
+	}
+	for path, item := range doc.Paths {
+		for method, op := range item.Operations() {
+			v.routes[method+" "+path] = &routers.Route{
+				Spec:      doc,
+				Path:      path,
+				PathItem:  item,
+				Method:    method,
+				Operation: op,
+			}
+		}
+	}
+	return v
+}
+
+// middleware はGinがルーティングした結果（FullPath）から定義のオペレーションを探して検証する。
+// 定義にないルートは検証せずにそのまま処理する
+func (v *specValidator) middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !v.validateRequests && !v.validateResponses {
+			c.Next()
+			return
+		}
+
+		path := ginParam.ReplaceAllString(strings.TrimPrefix(c.FullPath(), apiV1Prefix), "{$1}")
+		route, ok := v.routes[c.Request.Method+" "+path]
+		if !ok {
+			c.Next()
+			return
+		}
+
+		params := make(map[string]string, len(c.Params))
+		for _, p := range c.Params {
+			params[p.Key] = p.Value
+		}
+		input := &openapi3filter.RequestValidationInput{
+			Request:    c.Request,
+			PathParams: params,
+			Route:      route,
+			Options: &openapi3filter.Options{
+				MultiError: true,
+				// 管理用キーはハンドラー（requireAdmin）で検証する
+				AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+				// デフォルト値はハンドラーで補う（リクエストを書き換えない）
+				SkipSettingDefaults: true,
+			},
+		}
+
+		if v.validateRequests {
+			if err := openapi3filter.ValidateRequest(c.Request.Context(), input); err != nil {
+				// err にはリクエストの値（プレイヤー名など）が含まれるため、変換後のコードと項目名だけをログに出力する
+				apiErr := requestValidationError(err)
+				loggerFrom(c.Request.Context()).Info("Request does not match OpenAPI spec", "operation", route.Operation.OperationID, "error", apiErr.Error())
+				respondError(c, apiErr)
+				return
+			}
+		}
+
+		if !v.validateResponses {
+			c.Next()
+			return
+		}
+
+		recorder := &bodyRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		// レスポンスは送信済みのため、定義と一致しない場合はログに出力するだけにする
+		err := openapi3filter.ValidateResponse(c.Request.Context(), &openapi3filter.ResponseValidationInput{
+			RequestValidationInput: input,
+			Status:                 recorder.Status(),
+			Header:                 recorder.Header(),
+			Body:                   io.NopCloser(bytes.NewReader(recorder.body.Bytes())),
+			Options: &openapi3filter.Options{
+				MultiError:            true,
+				IncludeResponseStatus: true,
+			},
+		})
+		if err != nil {
+			loggerFrom(c.Request.Context()).Warn("Response does not match OpenAPI spec", "operation", route.Operation.OperationID, "status", recorder.Status(), "error", err)
+		}
+	}
+}
+
+// serveJSON は定義をJSONで返す
+func (v *specValidator) serveJSON(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, v.doc)
+}
+
+// serveYAML は定義の原文（YAML）を返す
+func (v *specValidator) serveYAML(c *gin.Context) {
+	c.Header("Cache-Control", "public, max-age=300")
+	c.Data(http.StatusOK, "application/yaml; charset=utf-8", openapi.YAML)
+}
+
+// bodyRecorder はレスポンスの検証のために、送信したボディを記録する
+type bodyRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *bodyRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *bodyRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// requestValidationError は定義による検証のエラーを項目ごとの検証エラーに変換する。
+// 項目に対応付けられないエラー（JSONとして読めない・Content-Type が違うなど）は invalid_request にする
+func requestValidationError(err error) *apierror.Error {
+	var tooLarge *http.MaxBytesError
+	if errors.As(err, &tooLarge) {
+		return apierror.Wrap(apierror.CodeRequestTooLarge, err)
+	}
+
+	var errs openapi3.MultiError
+	if !errors.As(err, &errs) {
+		errs = openapi3.MultiError{err}
+	}
+
+	var fields []apierror.FieldError
+	for _, e := range errs {
+		var reqErr *openapi3filter.RequestError
+		if !errors.As(e, &reqErr) {
+			continue
+		}
+
+		switch {
+		case reqErr.Parameter != nil:
+			code := apierror.FieldInvalid
+			if errors.Is(reqErr.Err, openapi3filter.ErrInvalidRequired) {
+				code = apierror.FieldRequired
+			}
+			fields = append(fields, apierror.Field(reqErr.Parameter.Name, code))
+		case reqErr.RequestBody != nil:
+			for _, schemaErr := range schemaErrors(reqErr.Err) {
+				fields = append(fields, schemaFieldError(schemaErr))
+			}
+		}
+	}
+	if len(fields) == 0 {
+		return apierror.Wrap(apierror.CodeInvalidRequest, err)
+	}
+	return apierror.Validation(fields...)
+}
+
+// schemaErrors は入れ子になった MultiError から SchemaError を取り出す
+func schemaErrors(err error) []*openapi3.SchemaError {
+	var errs openapi3.MultiError
+	if errors.As(err, &errs) {
+		var out []*openapi3.SchemaError
+		for _, e := range errs {
+			out = append(out, schemaErrors(e)...)
+		}
+		return out
+	}
+	var schemaErr *openapi3.SchemaError
+	if errors.As(err, &schemaErr) {
+		return []*openapi3.SchemaError{schemaErr}
+	}
+	return nil
+}
+
+// schemaFieldError はボディの SchemaError を項目の検証エラーにする（項目名は JSON Pointer を . で連結したもの）
+func schemaFieldError(err *openapi3.SchemaError) apierror.FieldError {
+	field := strings.Join(err.JSONPointer(), ".")
+	switch err.SchemaField {
+	case "required":
+		return apierror.Field(field, apierror.FieldRequired)
+	case "minimum", "maximum":
+		if err.Schema.Min != nil && err.Schema.Max != nil {
+			return apierror.Field(field, apierror.FieldOutOfRange, *err.Schema.Min, *err.Schema.Max)
+		}
+	case "minLength", "maxLength":
+		if err.Schema.MaxLength != nil {
+			return apierror.Field(field, apierror.FieldOutOfRange, err.Schema.MinLength, *err.Schema.MaxLength)
+		}
+	}
+	return apierror.Field(field, apierror.FieldInvalid)
+}
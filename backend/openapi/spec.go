@@ -0,0 +1,28 @@
+// Package openapi は /api/v1 の OpenAPI 3 の定義（openapi.yaml）を埋め込んで提供する。
+// 定義はリクエスト・レスポンスの検証と、Goクライアント（client パッケージ）の生成に使う
+package openapi
+
+import (
+	_ "embed"
+	"fmt"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// YAML は定義の原文
+//
+//go:embed openapi.yaml
+var YAML []byte
+
+// Load は定義を読み込み、OpenAPI 3 の文書として正しいことを検証する
+func Load() (*openapi3.T, error) {
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData(YAML)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load OpenAPI spec: %w", err)
+	}
+	if err := doc.Validate(loader.Context); err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI spec: %w", err)
+	}
+	return doc, nil
+}
@@ -0,0 +1,188 @@
+package passage
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"typing-game-backend/furigana"
+)
+
+// Options は Parse で作る文章の情報
+type Options struct {
+	Language string
+	Title    string
+	Source   string
+	License  string
+}
+
+// 文の終わり・文の終わりの後に続けて文に含める閉じ括弧・文を分けない括弧
+const (
+	terminators = "。．！？!?"
+	closers     = "」』）〕】〉)\"'”’"
+	openers     = "「『（〔【〈("
+)
+
+// annotationPattern は青空文庫形式の注記（［＃...］）。取り込み時に取り除く
+var annotationPattern = regexp.MustCompile(`［＃[^］]*］`)
+
+// Parse はプレーンテキストから文章を作る。空行で段落を分け、段落を文に分ける。
+// 日本語の漢字には青空文庫形式のルビ（漢字《かんじ》・｜漢字かな《かんじかな》）で読みを付ける。
+// 読みのない漢字がある文はすべてエラーとして返す
+func Parse(r io.Reader, opts Options) (*Passage, error) {
+	paragraphs, err := readParagraphs(r, opts.Language)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Passage{Language: opts.Language, Title: opts.Title, Source: opts.Source, License: opts.License}
+	var errs []error
+	for i, paragraph := range paragraphs {
+		for _, raw := range SplitSentences(paragraph) {
+			sentence, err := parseSentence(raw, opts.Language)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("paragraph %d, sentence %q: %w", i+1, raw, err))
+				continue
+			}
+			sentence.Paragraph = i
+			p.Sentences = append(p.Sentences, sentence)
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	p.PassageID = ID(p.Language, p.Title, p.Sentences)
+	p.Characters = p.CharactersUpTo(len(p.Sentences))
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// parseSentence はルビ付きの文を表示テキスト・読み・ふりがなに分ける（ふりがなは日本語のみ）
+func parseSentence(raw, language string) (Sentence, error) {
+	segments, err := furigana.ParseRuby(raw)
+	if err != nil {
+		return Sentence{}, err
+	}
+	text, reading := furigana.Text(segments), furigana.Reading(segments)
+	if k := furigana.FirstKanji(reading); k != "" {
+		return Sentence{}, fmt.Errorf("kanji %q has no reading (add ruby like %s《よみ》)", k, k)
+	}
+	s := Sentence{Text: text, Reading: reading}
+	if language == "jp" {
+		s.Furigana = segments
+	}
+	return s, nil
+}
+
+// readParagraphs は空行で区切られた段落を返す。段落内の改行は、日本語では詰め、それ以外は空白にする
+func readParagraphs(r io.Reader, language string) ([]string, error) {
+	sep := " "
+	if language == "jp" {
+		sep = ""
+	}
+
+	var paragraphs []string
+	var lines []string
+	flush := func() {
+		if len(lines) > 0 {
+			paragraphs = append(paragraphs, strings.Join(lines, sep))
+			lines = nil
+		}
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimPrefix(scanner.Text(), "\ufeff")
+		line = strings.TrimSpace(annotationPattern.ReplaceAllString(line, ""))
+		if line == "" {
+			flush()
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read text: %w", err)
+	}
+	flush()
+	return paragraphs, nil
+}
+
+// SplitSentences は段落を文に分ける。文は 。！？!? と、空白か段落の終わりが続く . で終わり、
+// 直後の閉じ括弧まで含める。括弧（「」など）の中とルビ（《》）の中では分けない
+func SplitSentences(paragraph string) []string {
+	runes := []rune(paragraph)
+	var sentences []string
+	add := func(s string) {
+		if s = strings.TrimSpace(s); s != "" {
+			sentences = append(sentences, s)
+		}
+	}
+
+	start, depth, inRuby := 0, 0, false
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == '《':
+			inRuby = true
+			continue
+		case r == '》':
+			inRuby = false
+			continue
+		case inRuby:
+			continue
+		case strings.ContainsRune(openers, r):
+			depth++
+			continue
+		case strings.ContainsRune(closers, r) && depth > 0 && r != '"' && r != '\'':
+			depth--
+			continue
+		}
+		if depth > 0 || !endsSentence(runes, i) {
+			continue
+		}
+
+		// 続く終わりの記号（！？など）と閉じ括弧を文に含める
+		end := i + 1
+		for end < len(runes) && (strings.ContainsRune(terminators, runes[end]) || strings.ContainsRune(closers, runes[end])) {
+			end++
+		}
+		add(string(runes[start:end]))
+		start, i = end, end-1
+	}
+	add(string(runes[start:]))
+	return sentences
+}
+
+// endsSentence は runes[i] が文の終わりかを返す
+func endsSentence(runes []rune, i int) bool {
+	r := runes[i]
+	if strings.ContainsRune(terminators, r) {
+		return true
+	}
+	if r != '.' {
+		return false
+	}
+	// 略語（e.g. など）や小数で分けないように、. の後に空白があり、次の文字が小文字でない場合だけ分ける
+	j := i + 1
+	for j < len(runes) && strings.ContainsRune(closers, runes[j]) {
+		j++
+	}
+	if j == len(runes) {
+		return true
+	}
+	if !unicode.IsSpace(runes[j]) {
+		return false
+	}
+	for j < len(runes) && unicode.IsSpace(runes[j]) {
+		j++
+	}
+	return j == len(runes) || !unicode.IsLower(runes[j])
+}
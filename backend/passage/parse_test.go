@@ -0,0 +1,62 @@
+package passage
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSplitSentences(t *testing.T) {
+	tests := []struct {
+		paragraph string
+		want      []string
+	}{
+		{"きょうははれです。あしたはあめでしょうか？", []string{"きょうははれです。", "あしたはあめでしょうか？"}},
+		{"「おはよう。げんき？」といった。ほんとうに！！", []string{"「おはよう。げんき？」といった。", "ほんとうに！！"}},
+		{"He said \"Hi.\" Then he left. It costs 3.50 dollars, e.g. a coffee.", []string{"He said \"Hi.\"", "Then he left.", "It costs 3.50 dollars, e.g. a coffee."}},
+		{"おわりのないぶん", []string{"おわりのないぶん"}},
+	}
+	for _, tt := range tests {
+		if got := SplitSentences(tt.paragraph); !slices.Equal(got, tt.want) {
+			t.Errorf("SplitSentences(%q) = %q, want %q", tt.paragraph, got, tt.want)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	text := "［＃ここから本文］\n吾輩《わがはい》は猫《ねこ》である。名前《なまえ》はまだ無《な》い。\n\nどこで生《う》まれたか\nとんと見当《けんとう》がつかぬ。\n"
+	p, err := Parse(strings.NewReader(text), Options{Language: "jp", Title: "吾輩は猫である", Source: "夏目漱石『吾輩は猫である』（青空文庫）", License: "Public Domain"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, s := range p.Sentences {
+		got = append(got, s.Text+"/"+s.Reading)
+	}
+	want := []string{
+		"吾輩は猫である。/わがはいはねこである。",
+		"名前はまだ無い。/なまえはまだない。",
+		"どこで生まれたかとんと見当がつかぬ。/どこでうまれたかとんとけんとうがつかぬ。",
+	}
+	if !slices.Equal(got, want) || p.Sentences[2].Paragraph != 1 {
+		t.Errorf("sentences = %q, want %q (the last one in paragraph 1)", got, want)
+	}
+	if p.Characters != 11+9+20 || p.CharactersUpTo(1) != 11 {
+		t.Errorf("characters = %d (first sentence %d), want 40 (11)", p.Characters, p.CharactersUpTo(1))
+	}
+	if n := len(p.Sentences[1].Furigana); n != 4 || p.Sentences[1].Furigana[3].Text != "い。" {
+		t.Errorf("furigana = %v, want 名前《なまえ》/はまだ/無《な》/い。", p.Sentences[1].Furigana)
+	}
+	if !strings.HasPrefix(p.PassageID, "p_") {
+		t.Errorf("PassageID = %q, want a p_ prefix", p.PassageID)
+	}
+	again, _ := Parse(strings.NewReader(text), Options{Language: "jp", Title: "吾輩は猫である", Source: "-", License: "-"})
+	if again.PassageID != p.PassageID {
+		t.Errorf("PassageID changed on re-import: %s, %s", again.PassageID, p.PassageID)
+	}
+
+	_, err = Parse(strings.NewReader("名前はまだ無い。ねこです。見当《けんとう》がつかぬ。"), Options{Language: "jp", Title: "t", Source: "s", License: "l"})
+	if err == nil || !strings.Contains(err.Error(), `"名"`) {
+		t.Errorf("Parse with kanji without reading: err = %v, want an error naming the kanji", err)
+	}
+}
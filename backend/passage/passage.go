@@ -0,0 +1,126 @@
+// Package passage は文章モードの文章（複数の文からなるテキスト）のデータ構造と、
+// プレーンテキストからの取り込み（文の分割・ルビの読み取り）をまとめたもの
+package passage
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"typing-game-backend/furigana"
+)
+
+// LanguageIndex は言語ごとに文章を一覧するための passages テーブルのGSI
+const LanguageIndex = "LanguageIndex"
+
+// Sentence は文章の1文
+type Sentence struct {
+	// Text は表示するテキスト（漢字かな混じり・句読点を含む）
+	Text string `dynamodbav:"text" json:"text"`
+	// Reading は入力する読み（かなと句読点。日本語以外は Text と同じ）
+	Reading string `dynamodbav:"reading" json:"reading"`
+	// Furigana は表示テキストの区間ごとの読み（日本語のみ）
+	Furigana []furigana.Segment `dynamodbav:"furigana,omitempty" json:"furigana,omitempty"`
+	// Paragraph は文を含む段落（0から）
+	Paragraph int `dynamodbav:"paragraph" json:"paragraph"`
+}
+
+// Passage は文章モードで入力する文章（passages テーブルの項目）
+type Passage struct {
+	PassageID string     `dynamodbav:"passage_id" json:"passage_id"`
+	Language  string     `dynamodbav:"language" json:"language"`
+	Title     string     `dynamodbav:"title" json:"title"`
+	Sentences []Sentence `dynamodbav:"sentences" json:"sentences"`
+	// Characters はすべての文の読みの文字数
+	Characters int `dynamodbav:"characters" json:"characters"`
+	// Source は出典（書名・URL など）、License は利用条件（CC0-1.0・Public Domain など）
+	Source  string `dynamodbav:"source" json:"source"`
+	License string `dynamodbav:"license" json:"license"`
+}
+
+// Summary は一覧に返す文章の概要（文を含まない）
+type Summary struct {
+	PassageID  string `json:"passage_id"`
+	Language   string `json:"language"`
+	Title      string `json:"title"`
+	Sentences  int    `json:"sentences"`
+	Characters int    `json:"characters"`
+	Source     string `json:"source"`
+	License    string `json:"license"`
+}
+
+// Summary は文章の概要を返す
+func (p *Passage) Summary() Summary {
+	return Summary{
+		PassageID:  p.PassageID,
+		Language:   p.Language,
+		Title:      p.Title,
+		Sentences:  len(p.Sentences),
+		Characters: p.Characters,
+		Source:     p.Source,
+		License:    p.License,
+	}
+}
+
+// CharactersUpTo は先頭から n 文の読みの文字数を返す
+func (p *Passage) CharactersUpTo(n int) int {
+	total := 0
+	for _, s := range p.Sentences[:min(n, len(p.Sentences))] {
+		total += s.MinKeystrokes()
+	}
+	return total
+}
+
+// MinKeystrokes は文の読みを入力するのに最低限必要な打鍵数。読みの1文字は1打鍵より少なく入力できないため、読みの文字数と同じ
+func (s Sentence) MinKeystrokes() int {
+	return utf8.RuneCountInString(s.Reading)
+}
+
+// Validate は文章を保存できるか検証する
+func (p *Passage) Validate() error {
+	var errs []error
+	for _, field := range []struct{ name, value string }{
+		{"passage_id", p.PassageID},
+		{"language", p.Language},
+		{"title", p.Title},
+		{"source", p.Source},
+		{"license", p.License},
+	} {
+		if strings.TrimSpace(field.value) == "" {
+			errs = append(errs, fmt.Errorf("%s is required", field.name))
+		}
+	}
+	if len(p.Sentences) == 0 {
+		errs = append(errs, errors.New("passage has no sentences"))
+	}
+	for i, s := range p.Sentences {
+		if s.Text == "" || s.Reading == "" {
+			errs = append(errs, fmt.Errorf("sentence %d: text and reading are required", i+1))
+		}
+		if k := furigana.FirstKanji(s.Reading); k != "" {
+			errs = append(errs, fmt.Errorf("sentence %d: reading contains kanji %q", i+1, k))
+		}
+		if len(s.Furigana) > 0 {
+			if err := furigana.Validate(s.Text, s.Reading, s.Furigana); err != nil {
+				errs = append(errs, fmt.Errorf("sentence %d: %w", i+1, err))
+			}
+		}
+	}
+	if p.Characters != p.CharactersUpTo(len(p.Sentences)) {
+		errs = append(errs, fmt.Errorf("characters = %d, want %d", p.Characters, p.CharactersUpTo(len(p.Sentences))))
+	}
+	return errors.Join(errs...)
+}
+
+// ID は言語・タイトル・文から文章のIDを返す。同じ内容を取り込み直しても同じIDになる
+func ID(language, title string, sentences []Sentence) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%s", language, title)
+	for _, s := range sentences {
+		fmt.Fprintf(h, "\x00%s\x00%s", s.Text, s.Reading)
+	}
+	return "p_" + hex.EncodeToString(h.Sum(nil))[:12]
+}
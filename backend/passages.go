@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"typing-game-backend/furigana"
+	"typing-game-backend/passage"
+)
+
+// samplePassages は passages テーブルが未設定のときに使う組み込みの文章（本プロジェクトで作成したもの）
+var samplePassages = []passage.Passage{
+	samplePassage("jp", "あさのさんぽ", []passage.Sentence{
+		{Text: "朝の公園は静かです。", Reading: "あさのこうえんはしずかです。", Paragraph: 0},
+		{Text: "犬をつれた人が、ゆっくり歩いています。", Reading: "いぬをつれたひとが、ゆっくりあるいています。", Paragraph: 0},
+		{Text: "ベンチにすわって、空を見上げました。", Reading: "べんちにすわって、そらをみあげました。", Paragraph: 1},
+		{Text: "今日もいい一日になりそうです。", Reading: "きょうもいいいちにちになりそうです。", Paragraph: 1},
+	}),
+	samplePassage("en", "A Morning Walk", []passage.Sentence{
+		{Text: "The park is quiet in the morning.", Reading: "The park is quiet in the morning.", Paragraph: 0},
+		{Text: "A man walks slowly with his dog.", Reading: "A man walks slowly with his dog.", Paragraph: 0},
+		{Text: "I sit on a bench and look up at the sky.", Reading: "I sit on a bench and look up at the sky.", Paragraph: 1},
+		{Text: "It is going to be a good day.", Reading: "It is going to be a good day.", Paragraph: 1},
+	}),
+}
+
+// samplePassage は組み込みの文章を作る。日本語の文のふりがなは表示テキストと読みから求める
+func samplePassage(language, title string, sentences []passage.Sentence) passage.Passage {
+	if language == "jp" {
+		for i := range sentences {
+			segments, err := furigana.Align(sentences[i].Text, sentences[i].Reading)
+			if err != nil {
+				panic(fmt.Sprintf("sample passage %q: %v", title, err))
+			}
+			sentences[i].Furigana = segments
+		}
+	}
+	p := passage.Passage{
+		Language:  language,
+		Title:     title,
+		Sentences: sentences,
+		Source:    "typing-game built-in sample",
+		License:   "CC0-1.0",
+	}
+	p.PassageID = passage.ID(language, title, sentences)
+	p.Characters = p.CharactersUpTo(len(sentences))
+	return p
+}
+
+// fallbackPassages は言語の組み込みの文章を返す
+func fallbackPassages(language string) []passage.Passage {
+	var passages []passage.Passage
+	for _, p := range samplePassages {
+		if p.Language == language {
+			passages = append(passages, p)
+		}
+	}
+	return passages
+}
+
+// fallbackPassage は組み込みの文章を ID で探す
+func fallbackPassage(passageID string) (*passage.Passage, bool) {
+	for i := range samplePassages {
+		if strings.EqualFold(samplePassages[i].PassageID, passageID) {
+			return &samplePassages[i], true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,393 @@
+// Package privacy はプレイヤー1人分の保存データのエクスポートと、削除・匿名化を行う。
+// APIの管理用エンドポイントと cmd/player-data の両方から使う
+package privacy
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+
+	"typing-game-backend/names"
+)
+
+// PlayerIndex は leaderboard views テーブルをプレイヤー名で引くためのGSI
+const PlayerIndex = "PlayerIndex"
+
+// Mode は削除の方法
+type Mode string
+
+const (
+	// Delete はプレイヤーのすべての項目を削除する
+	Delete Mode = "delete"
+	// Anonymize は項目を残したままプレイヤー名をランダムな仮名に置き換える（集計やランキングは維持される）
+	Anonymize Mode = "anonymize"
+)
+
+// ParseMode は文字列を Mode に変換する
+func ParseMode(s string) (Mode, error) {
+	switch Mode(s) {
+	case Delete, Anonymize:
+		return Mode(s), nil
+	default:
+		return "", fmt.Errorf("invalid mode %q (expected delete or anonymize)", s)
+	}
+}
+
+// Tables は対象のテーブル名。空のテーブルはスキップする
+type Tables struct {
+	Scores           string
+	Leaderboard      string
+	LeaderboardViews string
+	PlayerStats      string
+	PlayerNames      string
+}
+
+// Client は Service が使うDynamoDBの操作。*dynamodb.Client のほか、
+// APIではタイムアウト・リトライ・サーキットブレーカーを加えたクライアントを渡す
+type Client interface {
+	dynamodb.QueryAPIClient
+	GetItem(ctx context.Context, params *dynamodb.GetItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.GetItemOutput, error)
+	DeleteItem(ctx context.Context, params *dynamodb.DeleteItemInput, optFns ...func(*dynamodb.Options)) (*dynamodb.DeleteItemOutput, error)
+	TransactWriteItems(ctx context.Context, params *dynamodb.TransactWriteItemsInput, optFns ...func(*dynamodb.Options)) (*dynamodb.TransactWriteItemsOutput, error)
+}
+
+// Service はDynamoDB上のプレイヤーデータを扱う
+type Service struct {
+	client Client
+	tables Tables
+}
+
+func New(client Client, tables Tables) *Service {
+	return &Service{client: client, tables: tables}
+}
+
+// Export はプレイヤーについて保存されているすべてのデータ
+type Export struct {
+	PlayerName       string                   `json:"player_name"`
+	ExportedAt       time.Time                `json:"exported_at"`
+	Scores           []map[string]interface{} `json:"scores"`
+	Leaderboard      map[string]interface{}   `json:"leaderboard"`
+	LeaderboardViews []map[string]interface{} `json:"leaderboard_views"`
+	PlayerStats      map[string]interface{}   `json:"player_stats"`
+	NameClaim        map[string]interface{}   `json:"name_claim"`
+	// Replays はリプレイデータ。現在サーバーにはリプレイを保存していないため常に空
+	Replays []map[string]interface{} `json:"replays"`
+}
+
+// TableResult はテーブルごとの削除・匿名化の結果
+type TableResult struct {
+	Table     string `json:"table"`
+	Found     int    `json:"found"`
+	Processed int    `json:"processed"`
+	Remaining int    `json:"remaining"` // 処理後に再検索して残っていた件数（0 であること）
+	Skipped   bool   `json:"skipped,omitempty"`
+}
+
+// Report は削除・匿名化の結果。処理後に同じ名前で再検索した結果を含み、
+// Digest（Digest を空にした状態のJSONのSHA-256）を記録しておけば、後で内容が変わっていないことを照合できる
+type Report struct {
+	PlayerName  string        `json:"player_name"`
+	Mode        Mode          `json:"mode"`
+	Pseudonym   string        `json:"pseudonym,omitempty"`
+	StartedAt   time.Time     `json:"started_at"`
+	CompletedAt time.Time     `json:"completed_at"`
+	Tables      []TableResult `json:"tables"`
+	Verified    bool          `json:"verified"`
+	Notes       []string      `json:"notes"`
+	Digest      string        `json:"digest"`
+}
+
+// ComputeDigest は Digest を除いたレポートのSHA-256を返す
+func (r Report) ComputeDigest() (string, error) {
+	r.Digest = ""
+	data, err := json.Marshal(r)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// playerItems はプレイヤーに紐づく項目をテーブルごとに集めたもの
+type playerItems struct {
+	scores      []map[string]types.AttributeValue
+	leaderboard map[string]types.AttributeValue
+	views       []map[string]types.AttributeValue
+	stats       map[string]types.AttributeValue
+	nameClaim   map[string]types.AttributeValue
+}
+
+// Export はプレイヤーについて保存されているすべてのデータを返す
+func (s *Service) Export(ctx context.Context, playerName string) (*Export, error) {
+	items, err := s.find(ctx, playerName)
+	if err != nil {
+		return nil, err
+	}
+
+	export := &Export{
+		PlayerName: playerName,
+		ExportedAt: time.Now().UTC(),
+		Replays:    []map[string]interface{}{},
+	}
+	if export.Scores, err = toRecords(items.scores); err != nil {
+		return nil, err
+	}
+	if export.LeaderboardViews, err = toRecords(items.views); err != nil {
+		return nil, err
+	}
+	if export.Leaderboard, err = toRecord(items.leaderboard); err != nil {
+		return nil, err
+	}
+	if export.PlayerStats, err = toRecord(items.stats); err != nil {
+		return nil, err
+	}
+	if export.NameClaim, err = toRecord(items.nameClaim); err != nil {
+		return nil, err
+	}
+	return export, nil
+}
+
+// Erase はプレイヤーのデータを mode に従って削除または匿名化し、再検索で残っていないことを確認したレポートを返す。
+// notes はレポートに含める補足（ログの扱いなど）。途中で失敗しても、同じ名前で再実行すれば残りを処理できる
+func (s *Service) Erase(ctx context.Context, playerName string, mode Mode, notes ...string) (*Report, error) {
+	report := &Report{
+		PlayerName: playerName,
+		Mode:       mode,
+		StartedAt:  time.Now().UTC(),
+		Notes: append([]string{
+			"replays: no replay data is stored on the server",
+			"offline copies: verified covers the DynamoDB tables only; score archives written by cmd/archive-scores " +
+				"and table exports written by cmd/export-tables are not searched and must be purged separately",
+		}, notes...),
+	}
+	if mode == Anonymize {
+		pseudonym, err := newPseudonym()
+		if err != nil {
+			return nil, err
+		}
+		report.Pseudonym = pseudonym
+	}
+
+	items, err := s.find(ctx, playerName)
+	if err != nil {
+		return nil, err
+	}
+
+	// 名前の登録は名前から作られた値を含むため、匿名化の場合も削除する
+	groups := []struct {
+		table      string
+		items      []map[string]types.AttributeValue
+		key        []string
+		deleteOnly bool
+	}{
+		{s.tables.Scores, items.scores, []string{"player_name", "timestamp"}, false},
+		{s.tables.Leaderboard, nonNil(items.leaderboard), []string{"player_name"}, false},
+		{s.tables.LeaderboardViews, items.views, []string{"view", "player_name"}, false},
+		{s.tables.PlayerStats, nonNil(items.stats), []string{"player_name"}, false},
+		{s.tables.PlayerNames, nonNil(items.nameClaim), []string{"name_key"}, true},
+	}
+	for _, g := range groups {
+		pseudonym := report.Pseudonym
+		if g.deleteOnly {
+			pseudonym = ""
+		}
+
+		result := TableResult{Table: g.table, Found: len(g.items), Skipped: g.table == ""}
+		for _, item := range g.items {
+			if err := s.eraseItem(ctx, g.table, item, g.key, pseudonym); err != nil {
+				return nil, fmt.Errorf("failed to %s item in %s: %w", mode, g.table, err)
+			}
+			result.Processed++
+		}
+		report.Tables = append(report.Tables, result)
+	}
+
+	// 検証: 同じ名前で再検索して何も残っていないことを確認する
+	remaining, err := s.find(ctx, playerName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify erasure: %w", err)
+	}
+	counts := []int{len(remaining.scores), len(nonNil(remaining.leaderboard)), len(remaining.views), len(nonNil(remaining.stats)), len(nonNil(remaining.nameClaim))}
+	report.Verified = true
+	for i, count := range counts {
+		report.Tables[i].Remaining = count
+		if count > 0 {
+			report.Verified = false
+		}
+	}
+
+	report.CompletedAt = time.Now().UTC()
+	digest, err := report.ComputeDigest()
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute report digest: %w", err)
+	}
+	report.Digest = digest
+	return report, nil
+}
+
+// eraseItem は1項目を削除する。匿名化の場合は仮名の項目を作成して元の項目を削除する（1つのトランザクション）
+func (s *Service) eraseItem(ctx context.Context, table string, item map[string]types.AttributeValue, keyNames []string, pseudonym string) error {
+	key := make(map[string]types.AttributeValue, len(keyNames))
+	for _, name := range keyNames {
+		key[name] = item[name]
+	}
+
+	if pseudonym == "" {
+		_, err := s.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+			TableName: aws.String(table),
+			Key:       key,
+		})
+		return err
+	}
+
+	renamed := make(map[string]types.AttributeValue, len(item))
+	for name, value := range item {
+		renamed[name] = value
+	}
+	renamed["player_name"] = &types.AttributeValueMemberS{Value: pseudonym}
+
+	_, err := s.client.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems: []types.TransactWriteItem{
+			{Put: &types.Put{TableName: aws.String(table), Item: renamed}},
+			{Delete: &types.Delete{TableName: aws.String(table), Key: key}},
+		},
+	})
+	return err
+}
+
+// find は設定済みのすべてのテーブルからプレイヤーの項目を集める
+func (s *Service) find(ctx context.Context, playerName string) (*playerItems, error) {
+	items := &playerItems{}
+	name := &types.AttributeValueMemberS{Value: playerName}
+
+	if s.tables.Scores != "" {
+		paginator := dynamodb.NewQueryPaginator(s.client, &dynamodb.QueryInput{
+			TableName:                 aws.String(s.tables.Scores),
+			KeyConditionExpression:    aws.String("player_name = :name"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{":name": name},
+			ConsistentRead:            aws.Bool(true),
+		})
+		for paginator.HasMorePages() {
+			page, err := paginator.NextPage(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to query scores: %w", err)
+			}
+			items.scores = append(items.scores, page.Items...)
+		}
+	}
+
+	if s.tables.LeaderboardViews != "" {
+		// PlayerIndex はキーのみを射影しているため、元の項目を取得し直す
+		paginator := dynamodb.NewQueryPaginator(s.client, &dynamodb.QueryInput{
+			TableName:                 aws.String(s.tables.LeaderboardViews),
+			IndexName:                 aws.String(PlayerIndex),
+			KeyConditionExpression:    aws.String("player_name = :name"),
+			ExpressionAttributeValues: map[string]types.AttributeValue{":name": name},
+		})
+		for paginator.HasMorePages() {
+			page, err := paginator.NextPage(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to query leaderboard views: %w", err)
+			}
+			for _, keys := range page.Items {
+				item, err := s.get(ctx, s.tables.LeaderboardViews, map[string]types.AttributeValue{
+					"view":        keys["view"],
+					"player_name": name,
+				})
+				if err != nil {
+					return nil, fmt.Errorf("failed to get leaderboard view: %w", err)
+				}
+				if item != nil {
+					items.views = append(items.views, item)
+				}
+			}
+		}
+	}
+
+	var err error
+	if s.tables.Leaderboard != "" {
+		if items.leaderboard, err = s.get(ctx, s.tables.Leaderboard, map[string]types.AttributeValue{"player_name": name}); err != nil {
+			return nil, fmt.Errorf("failed to get leaderboard entry: %w", err)
+		}
+	}
+	if s.tables.PlayerStats != "" {
+		if items.stats, err = s.get(ctx, s.tables.PlayerStats, map[string]types.AttributeValue{"player_name": name}); err != nil {
+			return nil, fmt.Errorf("failed to get player stats: %w", err)
+		}
+	}
+	if s.tables.PlayerNames != "" {
+		claim, err := s.get(ctx, s.tables.PlayerNames, map[string]types.AttributeValue{
+			"name_key": &types.AttributeValueMemberS{Value: names.Skeleton(playerName)},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get name claim: %w", err)
+		}
+		// 紛らわしい別のプレイヤーが登録している場合は対象外
+		if owner, ok := claim["player_name"].(*types.AttributeValueMemberS); ok && owner.Value == playerName {
+			items.nameClaim = claim
+		}
+	}
+
+	return items, nil
+}
+
+func (s *Service) get(ctx context.Context, table string, key map[string]types.AttributeValue) (map[string]types.AttributeValue, error) {
+	out, err := s.client.GetItem(ctx, &dynamodb.GetItemInput{
+		TableName:      aws.String(table),
+		Key:            key,
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out.Item, nil
+}
+
+// newPseudonym は元の名前から推測できないランダムな仮名を返す
+func newPseudonym() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", errors.New("failed to generate pseudonym")
+	}
+	return "anonymous-" + hex.EncodeToString(b), nil
+}
+
+func nonNil(item map[string]types.AttributeValue) []map[string]types.AttributeValue {
+	if item == nil {
+		return nil
+	}
+	return []map[string]types.AttributeValue{item}
+}
+
+func toRecords(items []map[string]types.AttributeValue) ([]map[string]interface{}, error) {
+	records := make([]map[string]interface{}, 0, len(items))
+	for _, item := range items {
+		record, err := toRecord(item)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func toRecord(item map[string]types.AttributeValue) (map[string]interface{}, error) {
+	if item == nil {
+		return nil, nil
+	}
+	var record map[string]interface{}
+	if err := attributevalue.UnmarshalMap(item, &record); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal item: %w", err)
+	}
+	return record, nil
+}
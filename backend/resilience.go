@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/smithy-go"
+
+	"typing-game-backend/config"
+)
+
+// errCircuitOpen はサーキットブレーカーが開いていて呼び出しを行わなかったことを表す
+var errCircuitOpen = errors.New("circuit breaker is open")
+
+// throttlingErrorCodes はリトライ対象とするDynamoDBのスロットリングエラー
+var throttlingErrorCodes = map[string]bool{
+	"ProvisionedThroughputExceededException": true,
+	"ThrottlingException":                    true,
+	"RequestLimitExceeded":                   true,
+	"LimitExceededException":                 true,
+}
+
+// circuitBreaker は連続失敗が閾値を超えると一定時間呼び出しを遮断する。
+// 遮断時間が過ぎると1件だけ試行（half-open）し、成功すれば元に戻る
+type circuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	failures  int
+	openUntil time.Time
+	probing   bool
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{threshold: threshold, cooldown: cooldown}
+}
+
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures < b.threshold {
+		return true
+	}
+	if time.Now().Before(b.openUntil) || b.probing {
+		return false
+	}
+	b.probing = true
+	return true
+}
+
+func (b *circuitBreaker) record(failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+	if !failed {
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.failures >= b.threshold {
+		b.openUntil = time.Now().Add(b.cooldown)
+	}
+}
+
+// resilientCaller はタイムアウト・スロットリング時のリトライ・サーキットブレーカーをまとめたもの
+type resilientCaller struct {
+	cfg config.StorageConfig
+
+	mu       sync.Mutex
+	breakers map[string]*circuitBreaker // テーブルごと
+}
+
+func newResilientCaller(cfg config.StorageConfig) *resilientCaller {
+	return &resilientCaller{cfg: cfg, breakers: make(map[string]*circuitBreaker)}
+}
+
+func (r *resilientCaller) breaker(table string) *circuitBreaker {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b, ok := r.breakers[table]
+	if !ok {
+		b = newCircuitBreaker(r.cfg.BreakerThreshold, r.cfg.BreakerCooldown.Duration)
+		r.breakers[table] = b
+	}
+	return b
+}
+
+// call は fn を操作ごとのタイムアウト付きで実行し、スロットリング・サーバーエラーの場合は
+// ジッター付き指数バックオフでリトライする
+func (r *resilientCaller) call(ctx context.Context, table, op string, timeout time.Duration, fn func(ctx context.Context) error) error {
+	b := r.breaker(table)
+	if !b.allow() {
+		return fmt.Errorf("%s on %s: %w", op, table, errCircuitOpen)
+	}
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		opCtx, cancel := context.WithTimeout(ctx, timeout)
+		err = fn(opCtx)
+		cancel()
+
+		if err == nil || !isRetryable(err) || attempt >= r.cfg.MaxAttempts {
+			break
+		}
+
+		delay := r.backoff(attempt)
+		loggerFrom(ctx).Warn("Retrying DynamoDB operation", "operation", op, "table", table, "attempt", attempt, "delay_ms", delay.Milliseconds(), "error", err)
+
+		select {
+		case <-ctx.Done():
+			b.record(true)
+			return fmt.Errorf("%s on %s: %w", op, table, ctx.Err())
+		case <-time.After(delay):
+		}
+	}
+
+	b.record(err != nil && countsAsFailure(err))
+	return err
+}
+
+// backoff はフルジッターの指数バックオフ（base * 2^(attempt-1) を上限とする一様乱数）を返す
+func (r *resilientCaller) backoff(attempt int) time.Duration {
+	ceiling := r.cfg.RetryBaseDelay.Duration << (attempt - 1)
+	if ceiling <= 0 || ceiling > r.cfg.RetryMaxDelay.Duration {
+		ceiling = r.cfg.RetryMaxDelay.Duration
+	}
+	return time.Duration(rand.Int63n(int64(ceiling) + 1))
+}
+
+func isRetryable(err error) bool {
+	var canceled *types.TransactionCanceledException
+	if errors.As(err, &canceled) {
+		return transactionRetryable(canceled)
+	}
+
+	var apiErr smithy.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return throttlingErrorCodes[apiErr.ErrorCode()] || apiErr.ErrorFault() == smithy.FaultServer
+}
+
+// transactionRetryable はトランザクションが競合・スロットリングだけでキャンセルされたかを返す。
+// 同じプレイヤーの同時送信は TransactionConflict になるため、リトライで解消する
+func transactionRetryable(canceled *types.TransactionCanceledException) bool {
+	retryable := false
+	for _, reason := range canceled.CancellationReasons {
+		switch aws.ToString(reason.Code) {
+		case "None", "":
+		case "TransactionConflict", "ThrottlingError", "ProvisionedThroughputExceeded":
+			retryable = true
+		default:
+			return false
+		}
+	}
+	return retryable
+}
+
+// countsAsFailure はサーキットブレーカーの失敗として数えるエラーかどうかを返す。
+// 条件付き書き込みの失敗などクライアント側のエラーは依存先の障害ではないため数えない
+func countsAsFailure(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		return isRetryable(err)
+	}
+	return true
+}
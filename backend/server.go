@@ -0,0 +1,125 @@
+package main
+
+import (
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gin-gonic/gin"
+
+	"typing-game-backend/config"
+	"typing-game-backend/game"
+	"typing-game-backend/names"
+	"typing-game-backend/passage"
+	"typing-game-backend/privacy"
+	"typing-game-backend/wordlist"
+)
+
+// server はハンドラーが使う設定・ストレージ・キャッシュをまとめたもの
+type server struct {
+	cfg      *config.Config
+	store    *dynamoStore
+	metrics  *metricsRegistry
+	names    *names.Policy
+	rulesets *game.Rulesets
+	privacy  *privacy.Service
+	spec     *specValidator
+
+	wordsCache        *ttlCache[[]WordItem]
+	translationsCache *ttlCache[string]
+	categoriesCache   *ttlCache[[]map[string]interface{}]
+	passageListCache  *ttlCache[[]passage.Passage]
+	passageCache      *ttlCache[*passage.Passage]
+	wordListCache     *ttlCache[*wordlist.List]
+}
+
+func newServer(cfg *config.Config, store *dynamoStore, policy *names.Policy, rulesets *game.Rulesets, spec *openapi3.T) *server {
+	ttl := cfg.Cache.TTL.Duration
+	return &server{
+		cfg:               cfg,
+		store:             store,
+		metrics:           newMetricsRegistry(),
+		names:             policy,
+		rulesets:          rulesets,
+		spec:              newSpecValidator(spec, cfg.API.ValidateRequests, cfg.API.ValidateResponses),
+		wordsCache:        newTTLCache[[]WordItem](cfg.Cache.WordsSize, ttl),
+		translationsCache: newTTLCache[string](cfg.Cache.TranslationsSize, ttl),
+		categoriesCache:   newTTLCache[[]map[string]interface{}](categoriesCacheSize, ttl),
+		passageListCache:  newTTLCache[[]passage.Passage](passagesCacheSize, ttl),
+		passageCache:      newTTLCache[*passage.Passage](passagesCacheSize, ttl),
+		wordListCache:     newTTLCache[*wordlist.List](wordListsCacheSize, ttl),
+		privacy: privacy.New(resilientClient{store}, privacy.Tables{
+			Scores:           cfg.Tables.Scores,
+			Leaderboard:      cfg.Tables.Leaderboard,
+			LeaderboardViews: cfg.Tables.LeaderboardViews,
+			PlayerStats:      cfg.Tables.PlayerStats,
+			PlayerNames:      cfg.Tables.PlayerNames,
+		}),
+	}
+}
+
+// router はミドルウェアとルートを設定したGinエンジンを返す（Lambda・ローカル共通）
+func (s *server) router() *gin.Engine {
+	r := gin.New()
+	useJSONFieldNames()
+
+	// Lambda・ローカル共通のミドルウェア
+	r.Use(gin.CustomRecovery(recoveryHandler))
+	r.Use(requestIDMiddleware())
+	r.Use(accessLogMiddleware())
+	r.Use(s.metrics.middleware())
+	r.Use(securityHeadersMiddleware(s.cfg.Security))
+	r.Use(corsMiddleware(s.cfg.CORS))
+	r.Use(bodyLimitMiddleware(s.cfg.Security))
+
+	s.setupRoutes(r)
+	r.NoRoute(notFound)
+
+	// Prometheus形式のメトリクスはローカル実行時のみ公開する
+	if !s.cfg.Lambda {
+		r.GET("/metrics", s.metrics.handler)
+	}
+
+	return r
+}
+
+// setupRoutes はバージョン付きの /api/v1 と、互換用のバージョンなしの /api にルートを登録する。
+// API Gateway のステージ名（/production など）は Handler で取り除くため、ここでは扱わない
+func (s *server) setupRoutes(r *gin.Engine) {
+	v1 := r.Group(apiV1Prefix, s.spec.middleware())
+	v1.GET("/openapi.json", s.spec.serveJSON)
+	v1.GET("/openapi.yaml", s.spec.serveYAML)
+	s.registerAPI(v1)
+
+	// /api は /api/v1 を公開する前のクライアント向け（定義による検証なし）
+	s.registerAPI(r.Group("/api", deprecatedAPIMiddleware()))
+}
+
+func (s *server) registerAPI(api *gin.RouterGroup) {
+	// Health check
+	api.GET("/health", s.healthCheck)
+	api.GET("/health/live", s.liveness)
+	api.GET("/health/ready", s.readiness)
+
+	// Client-relevant configuration
+	api.GET("/config", s.getConfig)
+
+	// Game routes
+	game := api.Group("/game")
+	{
+		game.POST("/score", s.submitScore)
+		game.GET("/leaderboard", s.getLeaderboard)
+		game.GET("/words/:category/:round", s.getWords)
+		game.GET("/words/:category", s.getTypingWords)
+		game.GET("/categories", s.getCategories)
+		game.GET("/translation/:word_id", s.getTranslation)
+		game.GET("/rulesets", s.getRulesets)
+		game.GET("/passages", s.getPassages)
+		game.GET("/passages/:passage_id", s.getPassage)
+		game.POST("/lists", s.createWordList)
+		game.POST("/lists/import", s.importWordList)
+		game.GET("/lists/:share_code", s.getWordList)
+	}
+
+	// Admin routes
+	api.POST("/admin/cache/invalidate", s.invalidateCache)
+	api.POST("/admin/players/export", s.exportPlayerData)
+	api.POST("/admin/players/erase", s.erasePlayerData)
+}
@@ -0,0 +1,413 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log/slog"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/gin-gonic/gin"
+
+	"typing-game-backend/config"
+	"typing-game-backend/dynamotest"
+	"typing-game-backend/game"
+	"typing-game-backend/leaderboard"
+	"typing-game-backend/names"
+	"typing-game-backend/openapi"
+	"typing-game-backend/passage"
+	"typing-game-backend/privacy"
+)
+
+// テストは既定ではプロセス内の dynamotest サーバーを使う。
+// DYNAMODB_ENDPOINT（例: http://localhost:8000）を指定すると DynamoDB Local などの互換サーバーに対して同じテストを実行する
+var dynamoEndpoint = os.Getenv("DYNAMODB_ENDPOINT")
+
+// testTablePrefix はテスト用テーブル名の接頭辞。DynamoDB Local ではテストごとに一意な接尾辞を付ける
+const testTablePrefix = "typing-game-test-"
+
+func TestMain(m *testing.M) {
+	flag.Parse()
+	gin.SetMode(gin.TestMode)
+	slog.SetDefault(slog.New(testLogs))
+	os.Exit(m.Run())
+}
+
+// testLogs はテスト中のログを記録する（OpenAPI の定義と一致しないレスポンスの検出用）
+var testLogs = &logRecorder{}
+
+type logRecorder struct {
+	mu       sync.Mutex
+	messages []string
+}
+
+func (r *logRecorder) Enabled(context.Context, slog.Level) bool { return true }
+func (r *logRecorder) WithAttrs([]slog.Attr) slog.Handler       { return r }
+func (r *logRecorder) WithGroup(string) slog.Handler            { return r }
+
+func (r *logRecorder) Handle(_ context.Context, rec slog.Record) error {
+	if rec.Level < slog.LevelWarn {
+		return nil
+	}
+	msg := rec.Message
+	rec.Attrs(func(a slog.Attr) bool {
+		msg += fmt.Sprintf(" %s=%v", a.Key, a.Value)
+		return true
+	})
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.messages = append(r.messages, msg)
+	return nil
+}
+
+// since は n 件目以降に記録されたログのうち、message で始まるものを返す
+func (r *logRecorder) since(n int, message string) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []string
+	for _, msg := range r.messages[n:] {
+		if strings.HasPrefix(msg, message) {
+			out = append(out, msg)
+		}
+	}
+	return out
+}
+
+func (r *logRecorder) len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.messages)
+}
+
+// testEnv はテスト用のサーバーとストレージ
+type testEnv struct {
+	t      *testing.T
+	cfg    *config.Config
+	db     *dynamodb.Client
+	fake   *dynamotest.Server // DynamoDB Local の場合は nil
+	prefix string
+	server *server
+	router http.Handler
+}
+
+// envOption はテーブルの作成前に設定を変更する
+type envOption func(cfg *config.Config)
+
+// newTestEnv はすべてのテーブルを作成し、レスポンスを OpenAPI の定義で検証するサーバーを返す。
+// テストの終了時に、定義と一致しないレスポンスがあればテストを失敗させる
+func newTestEnv(t *testing.T, opts ...envOption) *testEnv {
+	t.Helper()
+
+	e := &testEnv{t: t, prefix: testTablePrefix}
+	if dynamoEndpoint != "" {
+		e.prefix = fmt.Sprintf("%s%d-", testTablePrefix, time.Now().UnixNano())
+		e.db = dynamotest.NewClient(dynamoEndpoint)
+	} else {
+		e.fake = dynamotest.NewServer()
+		t.Cleanup(e.fake.Close)
+		e.db = e.fake.Client()
+	}
+
+	cfg := config.Default()
+	cfg.Tables = config.TablesConfig{
+		Scores:           e.prefix + "scores",
+		Leaderboard:      e.prefix + "leaderboard",
+		LeaderboardViews: e.prefix + "leaderboard-views",
+		PlayerStats:      e.prefix + "player-stats",
+		PlayerNames:      e.prefix + "player-names",
+		Words:            e.prefix + "words",
+		Translations:     e.prefix + "translations",
+		Passages:         e.prefix + "passages",
+		WordLists:        e.prefix + "word-lists",
+	}
+	cfg.Storage.RetryBaseDelay = config.Duration{Duration: time.Millisecond}
+	cfg.Storage.RetryMaxDelay = config.Duration{Duration: 5 * time.Millisecond}
+	cfg.API.ValidateResponses = true
+	cfg.AdminAPIKey = "test-admin-key"
+	cfg.Logging.RedactionKey = "test-redaction-key"
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("invalid test config: %v", err)
+	}
+	e.cfg = cfg
+
+	e.createTables()
+
+	policy, err := names.New(names.Options{MaxLength: cfg.Game.MaxPlayerNameLength})
+	if err != nil {
+		t.Fatalf("names.New: %v", err)
+	}
+	rulesets, err := game.Load(game.Options{Dir: cfg.Rulesets.Dir, Current: cfg.Rulesets.Current})
+	if err != nil {
+		t.Fatalf("game.Load: %v", err)
+	}
+	spec, err := openapi.Load()
+	if err != nil {
+		t.Fatalf("openapi.Load: %v", err)
+	}
+	e.server = newServer(cfg, newDynamoStore(e.db, cfg.Tables, cfg.Storage, cfg.Retention), policy, rulesets, spec)
+	e.router = e.server.router()
+
+	logStart := testLogs.len()
+	t.Cleanup(func() {
+		for _, msg := range testLogs.since(logStart, "Response does not match OpenAPI spec") {
+			t.Errorf("%s", msg)
+		}
+	})
+	return e
+}
+
+// skipIfExternal は障害の注入やページの大きさの指定など、dynamotest でしか実行できないテストをスキップする
+func (e *testEnv) skipIfExternal() {
+	e.t.Helper()
+	if e.fake == nil {
+		e.t.Skip("requires the in-process dynamotest server (DYNAMODB_ENDPOINT is set)")
+	}
+}
+
+// createTables は infrastructure/modules/aws/dynamodb と同じキー・インデックスでテーブルを作成する
+func (e *testEnv) createTables() {
+	t := e.t
+	tables := e.cfg.Tables
+	definitions := []*dynamodb.CreateTableInput{
+		tableDefinition(tables.Scores, "player_name", "timestamp", types.ScalarAttributeTypeN,
+			gsi("ScoreIndex", "score_type", "score", types.ScalarAttributeTypeN, types.ProjectionTypeAll)),
+		tableDefinition(tables.Leaderboard, "player_name", "", ""),
+		tableDefinition(tables.LeaderboardViews, "view", "player_name", types.ScalarAttributeTypeS,
+			lsi(leaderboard.ViewScoreIndex, "view", "score", types.ScalarAttributeTypeN, types.ProjectionTypeAll),
+			gsi(privacy.PlayerIndex, "player_name", "", "", types.ProjectionTypeKeysOnly)),
+		tableDefinition(tables.PlayerStats, "player_name", "", ""),
+		tableDefinition(tables.PlayerNames, "name_key", "", ""),
+		tableDefinition(tables.Words, "category", "word_id", types.ScalarAttributeTypeS,
+			gsi(wordsLookupIndex, "lookup_key", "word_id", types.ScalarAttributeTypeS, types.ProjectionTypeAll)),
+		tableDefinition(tables.Translations, "word_id", "language", types.ScalarAttributeTypeS),
+		tableDefinition(tables.Passages, "passage_id", "", "",
+			gsi(passage.LanguageIndex, "language", "passage_id", types.ScalarAttributeTypeS, types.ProjectionTypeAll)),
+		tableDefinition(tables.WordLists, "list_id", "", ""),
+	}
+
+	ctx := context.Background()
+	for _, def := range definitions {
+		if aws.ToString(def.TableName) == "" {
+			continue
+		}
+		if _, err := e.db.CreateTable(ctx, def); err != nil {
+			t.Fatalf("create table %s: %v", aws.ToString(def.TableName), err)
+		}
+		if e.fake == nil {
+			name := def.TableName
+			t.Cleanup(func() {
+				_, _ = e.db.DeleteTable(context.Background(), &dynamodb.DeleteTableInput{TableName: name})
+			})
+		}
+	}
+}
+
+// indexDefinition はセカンダリインデックスの定義と、キーの属性定義
+type indexDefinition struct {
+	index types.GlobalSecondaryIndex
+	local bool
+	attrs []types.AttributeDefinition
+}
+
+// lsi はLSIの定義を返す（hashKey はテーブルのハッシュキーと同じ）
+func lsi(name, hashKey, rangeKey string, rangeType types.ScalarAttributeType, projection types.ProjectionType) indexDefinition {
+	def := gsi(name, hashKey, rangeKey, rangeType, projection)
+	def.local = true
+	return def
+}
+
+func gsi(name, hashKey, rangeKey string, rangeType types.ScalarAttributeType, projection types.ProjectionType) indexDefinition {
+	def := indexDefinition{
+		index: types.GlobalSecondaryIndex{
+			IndexName:  aws.String(name),
+			KeySchema:  []types.KeySchemaElement{{AttributeName: aws.String(hashKey), KeyType: types.KeyTypeHash}},
+			Projection: &types.Projection{ProjectionType: projection},
+		},
+		attrs: []types.AttributeDefinition{{AttributeName: aws.String(hashKey), AttributeType: types.ScalarAttributeTypeS}},
+	}
+	if rangeKey != "" {
+		def.index.KeySchema = append(def.index.KeySchema, types.KeySchemaElement{AttributeName: aws.String(rangeKey), KeyType: types.KeyTypeRange})
+		def.attrs = append(def.attrs, types.AttributeDefinition{AttributeName: aws.String(rangeKey), AttributeType: rangeType})
+	}
+	return def
+}
+
+// tableDefinition はハッシュキー（文字列）と、rangeKey が空でなければレンジキーを持つテーブルの定義を返す
+func tableDefinition(name, hashKey, rangeKey string, rangeType types.ScalarAttributeType, indexes ...indexDefinition) *dynamodb.CreateTableInput {
+	input := &dynamodb.CreateTableInput{
+		TableName:   aws.String(name),
+		BillingMode: types.BillingModePayPerRequest,
+		KeySchema:   []types.KeySchemaElement{{AttributeName: aws.String(hashKey), KeyType: types.KeyTypeHash}},
+	}
+	attrs := map[string]types.ScalarAttributeType{hashKey: types.ScalarAttributeTypeS}
+	if rangeKey != "" {
+		input.KeySchema = append(input.KeySchema, types.KeySchemaElement{AttributeName: aws.String(rangeKey), KeyType: types.KeyTypeRange})
+		attrs[rangeKey] = rangeType
+	}
+	for _, idx := range indexes {
+		if idx.local {
+			input.LocalSecondaryIndexes = append(input.LocalSecondaryIndexes, types.LocalSecondaryIndex{
+				IndexName:  idx.index.IndexName,
+				KeySchema:  idx.index.KeySchema,
+				Projection: idx.index.Projection,
+			})
+		} else {
+			input.GlobalSecondaryIndexes = append(input.GlobalSecondaryIndexes, idx.index)
+		}
+		for _, a := range idx.attrs {
+			attrs[aws.ToString(a.AttributeName)] = a.AttributeType
+		}
+	}
+	for name, typ := range attrs {
+		input.AttributeDefinitions = append(input.AttributeDefinitions, types.AttributeDefinition{AttributeName: aws.String(name), AttributeType: typ})
+	}
+	return input
+}
+
+// put はテーブルに項目を直接書き込む（attributevalue でマーシャルする）
+func (e *testEnv) put(table string, items ...interface{}) {
+	e.t.Helper()
+	for _, item := range items {
+		av, err := attributevalue.MarshalMap(item)
+		if err != nil {
+			e.t.Fatalf("marshal %T: %v", item, err)
+		}
+		if _, err := e.db.PutItem(context.Background(), &dynamodb.PutItemInput{TableName: aws.String(table), Item: av}); err != nil {
+			e.t.Fatalf("put item into %s: %v", table, err)
+		}
+	}
+}
+
+// get はテーブルの項目を読み込んで out にアンマーシャルする。項目がない場合は false
+func (e *testEnv) get(table string, key map[string]types.AttributeValue, out interface{}) bool {
+	e.t.Helper()
+	res, err := e.db.GetItem(context.Background(), &dynamodb.GetItemInput{TableName: aws.String(table), Key: key, ConsistentRead: aws.Bool(true)})
+	if err != nil {
+		e.t.Fatalf("get item from %s: %v", table, err)
+	}
+	if res.Item == nil {
+		return false
+	}
+	if err := attributevalue.UnmarshalMap(res.Item, out); err != nil {
+		e.t.Fatalf("unmarshal item from %s: %v", table, err)
+	}
+	return true
+}
+
+// scoresOf は scores テーブルのプレイヤーのスコアを返す
+func (e *testEnv) scoresOf(playerName string) []ScoreItem {
+	e.t.Helper()
+	res, err := e.db.Query(context.Background(), &dynamodb.QueryInput{
+		TableName:                 aws.String(e.cfg.Tables.Scores),
+		KeyConditionExpression:    aws.String("player_name = :name"),
+		ExpressionAttributeValues: map[string]types.AttributeValue{":name": &types.AttributeValueMemberS{Value: playerName}},
+		ConsistentRead:            aws.Bool(true),
+	})
+	if err != nil {
+		e.t.Fatalf("query scores of %s: %v", playerName, err)
+	}
+	var scores []ScoreItem
+	if err := attributevalue.UnmarshalListOfMaps(res.Items, &scores); err != nil {
+		e.t.Fatalf("unmarshal scores: %v", err)
+	}
+	return scores
+}
+
+// do はリクエストを送り、レスポンスを記録して返す。headers は名前と値を交互に並べる
+func (e *testEnv) do(method, path, body string, headers ...string) *httptest.ResponseRecorder {
+	e.t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+	rec := httptest.NewRecorder()
+	e.router.ServeHTTP(rec, req)
+	return rec
+}
+
+// uploadContentType は uploadBody のボディの Content-Type
+const uploadContentType = "multipart/form-data; boundary=deck-upload"
+
+// uploadBody は単語帳のファイル（file）と、名前と値を交互に並べたフォームの値の multipart/form-data のボディを返す
+func uploadBody(filename string, content []byte, fields ...string) string {
+	var b bytes.Buffer
+	w := multipart.NewWriter(&b)
+	if err := w.SetBoundary("deck-upload"); err != nil {
+		panic(err)
+	}
+	for i := 0; i+1 < len(fields); i += 2 {
+		w.WriteField(fields[i], fields[i+1])
+	}
+	if filename != "" {
+		part, err := w.CreateFormFile("file", filename)
+		if err != nil {
+			panic(err)
+		}
+		part.Write(content)
+	}
+	w.Close()
+	return b.String()
+}
+
+// submit はスコアを登録し、成功しなければテストを失敗させる
+func (e *testEnv) submit(playerName string, score, round int, category string) {
+	e.t.Helper()
+	body := fmt.Sprintf(`{"player_name":%q,"score":%d,"round":%d,"time":60,"category":%q}`, playerName, score, round, category)
+	if rec := e.do(http.MethodPost, apiV1Prefix+"/game/score", body); rec.Code != http.StatusOK {
+		e.t.Fatalf("submit score for %s: status %d: %s", playerName, rec.Code, rec.Body.String())
+	}
+}
+
+// decodeJSON はレスポンスのボディを out にデコードする
+func decodeJSON(t *testing.T, rec *httptest.ResponseRecorder, out interface{}) {
+	t.Helper()
+	if err := json.Unmarshal(rec.Body.Bytes(), out); err != nil {
+		t.Fatalf("decode response %q: %v", rec.Body.String(), err)
+	}
+}
+
+// errorBody は apierror のレスポンス
+type errorBody struct {
+	Code    string `json:"code"`
+	Details []struct {
+		Field string `json:"field"`
+		Code  string `json:"code"`
+	} `json:"details"`
+}
+
+// assertError はレスポンスがステータス status・エラーコード code であることを確認し、項目ごとの詳細を "項目:理由" の形で返す
+func assertError(t *testing.T, rec *httptest.ResponseRecorder, status int, code string) []string {
+	t.Helper()
+	if rec.Code != status {
+		t.Fatalf("status = %d, want %d: %s", rec.Code, status, rec.Body.String())
+	}
+	var body errorBody
+	decodeJSON(t, rec, &body)
+	if body.Code != code {
+		t.Fatalf("error code = %q, want %q: %s", body.Code, code, rec.Body.String())
+	}
+	var details []string
+	for _, d := range body.Details {
+		details = append(details, d.Field+":"+d.Code)
+	}
+	return details
+}
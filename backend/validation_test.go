@@ -0,0 +1,274 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"typing-game-backend/config"
+)
+
+// scoreBody はスコア登録のボディ
+func scoreBody(playerName string, score, round, gameTime int) string {
+	return fmt.Sprintf(`{"player_name":%q,"score":%d,"round":%d,"time":%d,"category":"beginner_words"}`, playerName, score, round, gameTime)
+}
+
+// TestSubmitScoreValidation はスコア登録の境界値を /api/v1（定義による検証あり）と /api（ハンドラーの検証のみ）の両方で確認する
+func TestSubmitScoreValidation(t *testing.T) {
+	jpName20 := strings.Repeat("あ", 20)
+
+	tests := []struct {
+		name    string
+		body    string
+		status  int
+		code    string
+		details []string // "項目:理由"（/api/v1 と /api で同じ）
+	}{
+		{name: "20-rune jp name", body: scoreBody(jpName20, 100, 1, 60), status: http.StatusOK},
+		{name: "21-rune jp name", body: scoreBody(jpName20+"い", 100, 1, 60), status: http.StatusBadRequest, code: "name_too_long"},
+		{name: "empty name", body: scoreBody("  ", 100, 1, 60), status: http.StatusBadRequest, code: "name_empty"},
+		{name: "round 1", body: scoreBody("round1", 100, 1, 60), status: http.StatusOK},
+		{name: "round 5", body: scoreBody("round5", 100, 5, 60), status: http.StatusOK},
+		{name: "round 0", body: scoreBody("round0", 100, 0, 60), status: http.StatusBadRequest, code: "validation_failed"},
+		{name: "round 6", body: scoreBody("round6", 100, 6, 60), status: http.StatusBadRequest, code: "validation_failed", details: []string{"round:out_of_range"}},
+		{name: "time 3600", body: scoreBody("time3600", 100, 1, 3600), status: http.StatusOK},
+		{name: "time 3601", body: scoreBody("time3601", 100, 1, 3601), status: http.StatusBadRequest, code: "validation_failed", details: []string{"time:out_of_range"}},
+		{name: "negative time", body: scoreBody("negative", 100, 1, -1), status: http.StatusBadRequest, code: "validation_failed"},
+		{name: "score over max", body: scoreBody("maxscore", 1000001, 1, 60), status: http.StatusBadRequest, code: "validation_failed", details: []string{"score:out_of_range"}},
+		{name: "unknown ruleset", body: `{"player_name":"ruleset","score":100,"round":1,"time":60,"category":"beginner_words","ruleset_version":"v0"}`, status: http.StatusBadRequest, code: "validation_failed", details: []string{"ruleset_version:invalid"}},
+		{name: "missing category", body: `{"player_name":"nocategory","score":100,"round":1,"time":60}`, status: http.StatusBadRequest, code: "validation_failed", details: []string{"category:required"}},
+		{name: "unknown category", body: `{"player_name":"category","score":100,"round":1,"time":60,"category":"unknown"}`, status: http.StatusBadRequest, code: "validation_failed", details: []string{"category:invalid"}},
+		{name: "string score", body: `{"player_name":"typed","score":"100","round":1,"time":60,"category":"beginner_words"}`, status: http.StatusBadRequest, code: "validation_failed", details: []string{"score:invalid"}},
+		{name: "endless score above the waves", body: `{"player_name":"endless","score":9391,"round":1,"time":10,"category":"beginner_words","mode":"endless"}`, status: http.StatusBadRequest, code: "validation_failed", details: []string{"score:out_of_range"}},
+		{name: "time attack", body: `{"player_name":"timeattack","category":"beginner_words","mode":"time_attack","duration":120,"typing":{"words":90,"characters":400,"keystrokes":420}}`, status: http.StatusOK},
+		{name: "time attack 90 seconds", body: `{"player_name":"timeattack","category":"beginner_words","mode":"time_attack","duration":90,"typing":{"words":90,"characters":400,"keystrokes":420}}`, status: http.StatusBadRequest, code: "validation_failed", details: []string{"duration:invalid"}},
+		{name: "time attack without duration", body: `{"player_name":"timeattack","category":"beginner_words","mode":"time_attack","typing":{"words":90,"characters":400,"keystrokes":420}}`, status: http.StatusBadRequest, code: "validation_failed", details: []string{"duration:required"}},
+		{name: "practice 49 words", body: `{"player_name":"practice","category":"beginner_words","mode":"practice","typing":{"words":49,"characters":250,"keystrokes":250,"elapsed_ms":40000}}`, status: http.StatusBadRequest, code: "validation_failed", details: []string{"typing.words:out_of_range"}},
+		{name: "practice keystrokes below characters", body: `{"player_name":"practice","category":"beginner_words","mode":"practice","typing":{"words":50,"characters":250,"keystrokes":200,"elapsed_ms":40000}}`, status: http.StatusBadRequest, code: "validation_failed", details: []string{"typing.keystrokes:invalid"}},
+		{name: "passage without progress", body: `{"player_name":"passage","mode":"passage"}`, status: http.StatusBadRequest, code: "validation_failed", details: []string{"progress:required"}},
+		// 文ごとの打鍵数は読みの文字数（1文目は14文字）より少なくできない
+		{name: "passage characters below the reading", body: `{"player_name":"passage","mode":"passage","progress":{"passage_id":"` + samplePassages[0].PassageID + `","sentences":[{"characters":1,"keystrokes":1,"elapsed_ms":1000}]}}`, status: http.StatusBadRequest, code: "validation_failed", details: []string{"progress.sentences.0:invalid"}},
+		{name: "passage faster than max wpm", body: `{"player_name":"passage","mode":"passage","progress":{"passage_id":"` + samplePassages[0].PassageID + `","sentences":[{"characters":14,"keystrokes":14,"elapsed_ms":100}]}}`, status: http.StatusBadRequest, code: "validation_failed", details: []string{"progress.sentences:invalid"}},
+		{name: "passage", body: `{"player_name":"passage","mode":"passage","progress":{"passage_id":"` + samplePassages[0].PassageID + `","sentences":[{"characters":28,"keystrokes":30,"elapsed_ms":8000}]}}`, status: http.StatusOK},
+		{name: "unknown passage", body: `{"player_name":"passage","mode":"passage","progress":{"passage_id":"p_unknown","sentences":[{"characters":10,"keystrokes":10,"elapsed_ms":3000}]}}`, status: http.StatusBadRequest, code: "validation_failed", details: []string{"progress.passage_id:invalid"}},
+		{name: "malformed json", body: `{"player_name":`, status: http.StatusBadRequest, code: "invalid_request"},
+	}
+
+	for _, prefix := range []string{apiV1Prefix, "/api"} {
+		e := newTestEnv(t)
+		e.put(e.cfg.Tables.Passages, samplePassages[0])
+		for _, tt := range tests {
+			t.Run(prefix+" "+tt.name, func(t *testing.T) {
+				rec := e.do(http.MethodPost, prefix+"/game/score", tt.body)
+				if tt.status == http.StatusOK {
+					if rec.Code != http.StatusOK {
+						t.Fatalf("status = %d, want 200: %s", rec.Code, rec.Body.String())
+					}
+					return
+				}
+				details := assertError(t, rec, tt.status, tt.code)
+				for _, want := range tt.details {
+					if !slices.Contains(details, want) {
+						t.Errorf("details = %v, want %s", details, want)
+					}
+				}
+			})
+		}
+	}
+}
+
+// wordListBody は words_per_round が perRound で、単語が words の単語リスト作成のボディ
+func wordListBody(language string, perRound int, words ...string) string {
+	items := make([]string, len(words))
+	for i, w := range words {
+		items[i] = fmt.Sprintf(`{"display":%q}`, w)
+	}
+	return fmt.Sprintf(`{"name":"list","language":%q,"words_per_round":%d,"words":[%s]}`, language, perRound, strings.Join(items, ","))
+}
+
+// TestCreateWordListValidation は単語リスト作成の境界値と、単語の取り込みと同じ読みの検証を確認する
+func TestCreateWordListValidation(t *testing.T) {
+	// ルールセットの最も多いラウンド数は5
+	tenWords := strings.Split("a b c d e f g h i j", " ")
+
+	tests := []struct {
+		name    string
+		body    string
+		status  int
+		code    string
+		details []string
+	}{
+		{name: "10 words in 5 rounds", body: wordListBody("en", 2, tenWords...), status: http.StatusCreated},
+		{name: "11 words in 5 rounds", body: wordListBody("en", 2, append(tenWords, "k")...), status: http.StatusBadRequest, code: "validation_failed", details: []string{"words:out_of_range"}},
+		{name: "no words", body: wordListBody("en", 2), status: http.StatusBadRequest, code: "validation_failed", details: []string{"words:out_of_range"}},
+		{name: "50 words per round", body: wordListBody("en", 50, "a"), status: http.StatusCreated},
+		{name: "51 words per round", body: wordListBody("en", 51, "a"), status: http.StatusBadRequest, code: "validation_failed", details: []string{"words_per_round:out_of_range"}},
+		{name: "jp words with dictionary and ruby", body: wordListBody("jp", 5, "犬", "食《た》べ物《もの》", "リンゴ"), status: http.StatusCreated},
+		{name: "jp kanji without reading", body: wordListBody("jp", 5, "鬱"), status: http.StatusBadRequest, code: "validation_failed", details: []string{"words.0.reading:invalid"}},
+		{name: "jp reading does not match", body: `{"name":"list","language":"jp","words_per_round":5,"words":[{"display":"犬が","reading":"いぬを"}]}`, status: http.StatusBadRequest, code: "validation_failed", details: []string{"words.0.reading:invalid"}},
+		{name: "ambiguous reading", body: wordListBody("jp", 5, "一日"), status: http.StatusBadRequest, code: "validation_failed", details: []string{"words.0.reading:required"}},
+		{name: "ambiguous reading accepted", body: `{"name":"list","language":"jp","words_per_round":5,"words":[{"display":"一日"}],"accept_review":true}`, status: http.StatusCreated},
+		{name: "empty display", body: wordListBody("en", 5, "a", " "), status: http.StatusBadRequest, code: "validation_failed", details: []string{"words.1.display:required"}},
+		{name: "translation without language", body: `{"name":"list","language":"en","words_per_round":5,"words":[{"display":"dog","translation":"いぬ"}]}`, status: http.StatusBadRequest, code: "validation_failed", details: []string{"translation_language:required"}},
+		{name: "display too long", body: `{"name":"list","language":"jp","words_per_round":5,"words":[{"display":"` + strings.Repeat("漢あ", 26) + `","reading":"` + strings.Repeat("あ", 50) + `"}]}`, status: http.StatusBadRequest, code: "validation_failed", details: []string{"words.0.display:out_of_range"}},
+		{name: "reading too long", body: `{"name":"list","language":"jp","words_per_round":5,"words":[{"display":"ねこ","reading":"` + strings.Repeat("あ", 51) + `"}]}`, status: http.StatusBadRequest, code: "validation_failed", details: []string{"words.0.reading:out_of_range"}},
+		{name: "translation too long", body: `{"name":"list","language":"en","words_per_round":5,"words":[{"display":"dog","translation":"` + strings.Repeat("い", 101) + `"}],"translation_language":"jp"}`, status: http.StatusBadRequest, code: "validation_failed", details: []string{"words.0.translation:out_of_range"}},
+		{name: "41-rune name", body: `{"name":"` + strings.Repeat("あ", 41) + `","language":"en","words_per_round":5,"words":[{"display":"dog"}]}`, status: http.StatusBadRequest, code: "validation_failed", details: []string{"name:out_of_range"}},
+		{name: "missing name", body: `{"language":"en","words_per_round":5,"words":[{"display":"dog"}]}`, status: http.StatusBadRequest, code: "validation_failed", details: []string{"name:required"}},
+	}
+
+	for _, prefix := range []string{apiV1Prefix, "/api"} {
+		e := newTestEnv(t)
+		for _, tt := range tests {
+			t.Run(prefix+" "+tt.name, func(t *testing.T) {
+				rec := e.do(http.MethodPost, prefix+"/game/lists", tt.body)
+				if tt.status == http.StatusCreated {
+					if rec.Code != http.StatusCreated {
+						t.Fatalf("status = %d, want 201: %s", rec.Code, rec.Body.String())
+					}
+					return
+				}
+				details := assertError(t, rec, tt.status, tt.code)
+				for _, want := range tt.details {
+					if !slices.Contains(details, want) {
+						t.Errorf("details = %v, want %s", details, want)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestSubmitScoreRequestLimits(t *testing.T) {
+	e := newTestEnv(t, func(cfg *config.Config) { cfg.Security.MaxRequestBytes = 256 })
+
+	for _, prefix := range []string{apiV1Prefix, "/api"} {
+		t.Run(prefix+" too large", func(t *testing.T) {
+			body := scoreBody(strings.Repeat("a", 300), 100, 1, 60)
+			assertError(t, e.do(http.MethodPost, prefix+"/game/score", body), http.StatusRequestEntityTooLarge, "request_too_large")
+		})
+	}
+
+	t.Run("wrong content type", func(t *testing.T) {
+		rec := e.do(http.MethodPost, apiV1Prefix+"/game/score", scoreBody("plain", 100, 1, 60), "Content-Type", "text/plain")
+		assertError(t, rec, http.StatusBadRequest, "invalid_request")
+	})
+}
+
+func TestQueryValidation(t *testing.T) {
+	e := newTestEnv(t)
+
+	tests := []struct {
+		name    string
+		path    string
+		status  int
+		code    string
+		details []string
+	}{
+		{name: "period and category", path: "/game/leaderboard?category=beginner_words&period=weekly", status: http.StatusBadRequest, code: "validation_failed", details: []string{"period:conflict"}},
+		{name: "unknown period", path: "/game/leaderboard?period=daily", status: http.StatusBadRequest, code: "validation_failed", details: []string{"period:invalid"}},
+		{name: "unknown category", path: "/game/leaderboard?category=unknown", status: http.StatusBadRequest, code: "validation_failed", details: []string{"category:invalid"}},
+		{name: "words round 0", path: "/game/words/beginner_words/0", status: http.StatusBadRequest, code: "validation_failed", details: []string{"round:out_of_range"}},
+		{name: "words round 6", path: "/game/words/beginner_words/6", status: http.StatusBadRequest, code: "validation_failed", details: []string{"round:out_of_range"}},
+		{name: "words language", path: "/game/words/beginner_words/1?language=fr", status: http.StatusBadRequest, code: "validation_failed", details: []string{"language:invalid"}},
+		{name: "translation without language", path: "/game/translation/bw_001", status: http.StatusBadRequest, code: "validation_failed", details: []string{"language:required"}},
+		{name: "words unknown list", path: "/game/words/list_ZZZZZZZZ/1", status: http.StatusBadRequest, code: "validation_failed", details: []string{"category:invalid"}},
+		{name: "leaderboard unknown list", path: "/game/leaderboard?category=list_ZZZZZZZZ&mode=practice", status: http.StatusBadRequest, code: "validation_failed", details: []string{"category:invalid"}},
+		{name: "mode and category", path: "/game/leaderboard?category=beginner_words&mode=practice", status: http.StatusBadRequest, code: "validation_failed", details: []string{"mode:conflict"}},
+	}
+
+	for _, prefix := range []string{apiV1Prefix, "/api"} {
+		for _, tt := range tests {
+			t.Run(prefix+" "+tt.name, func(t *testing.T) {
+				details := assertError(t, e.do(http.MethodGet, prefix+tt.path, ""), tt.status, tt.code)
+				for _, want := range tt.details {
+					if !slices.Contains(details, want) {
+						t.Errorf("details = %v, want %s", details, want)
+					}
+				}
+			})
+		}
+	}
+}
+
+// TestImportWordListValidation は単語帳のファイルの取り込みの上限と検証を確認する
+func TestImportWordListValidation(t *testing.T) {
+	apkg, err := os.ReadFile("deckimport/testdata/japanese.apkg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// アップロードの上限（20KiB）はスコア登録などのボディの上限（16KiB）とは別に決まる
+	e := newTestEnv(t, func(cfg *config.Config) { cfg.Security.MaxUploadBytes = 20 << 10 })
+	words := func(n int, translation string) []byte { return []byte(strings.Repeat("apple\t"+translation+"\n", n)) }
+
+	tests := []struct {
+		name    string
+		env     *testEnv
+		body    string
+		status  int
+		code    string
+		details []string
+	}{
+		{name: "too large", env: e, body: uploadBody("words.txt", words(1500, "りんご"), "name", "anki", "words_per_round", "5"), status: http.StatusRequestEntityTooLarge, code: "request_too_large"},
+		{name: "larger than other requests", env: e, body: uploadBody("words.txt", words(65, strings.Repeat("り", 90)), "name", "anki", "words_per_round", "5"), status: http.StatusCreated},
+		// ノートは単語リストの単語数の上限（50語 × 5ラウンド）まで
+		{name: "too many notes", env: e, body: uploadBody("words.txt", words(251, "りんご"), "name", "anki", "words_per_round", "5"), status: http.StatusBadRequest, code: "validation_failed", details: []string{"file:out_of_range"}},
+		{name: "missing file", env: e, body: uploadBody("", nil, "name", "anki", "words_per_round", "5"), status: http.StatusBadRequest, code: "validation_failed", details: []string{"file:required"}},
+		{name: "unreadable file", env: e, body: uploadBody("deck.apkg", []byte("PK\x03\x04broken"), "name", "anki", "words_per_round", "5"), status: http.StatusBadRequest, code: "validation_failed", details: []string{"file:invalid"}},
+		{name: "language not inferred", env: e, body: uploadBody("words.txt", []byte("123\t456\n"), "name", "anki", "words_per_round", "5"), status: http.StatusBadRequest, code: "validation_failed", details: []string{"language:required"}},
+		{name: "unsupported language", env: e, body: uploadBody("words.txt", []byte("你好\thello\n"), "name", "anki", "words_per_round", "5"), status: http.StatusBadRequest, code: "validation_failed", details: []string{"language:invalid"}},
+		{name: "invalid notes", env: e, body: uploadBody("japanese.apkg", apkg, "name", "anki", "words_per_round", "10"), status: http.StatusBadRequest, code: "validation_failed", details: []string{"file.notes.6:invalid", "file.notes.7:invalid"}},
+		{name: "invalid notes skipped", env: e, body: uploadBody("japanese.apkg", apkg, "name", "anki", "words_per_round", "10", "skip_invalid", "true"), status: http.StatusCreated},
+	}
+
+	for _, prefix := range []string{apiV1Prefix, "/api"} {
+		for _, tt := range tests {
+			t.Run(prefix+" "+tt.name, func(t *testing.T) {
+				rec := tt.env.do(http.MethodPost, prefix+"/game/lists/import", tt.body, "Content-Type", uploadContentType)
+				if tt.status == http.StatusCreated {
+					if rec.Code != http.StatusCreated {
+						t.Fatalf("status = %d, want 201: %s", rec.Code, rec.Body.String())
+					}
+					return
+				}
+				details := assertError(t, rec, tt.status, tt.code)
+				for _, want := range tt.details {
+					if !slices.Contains(details, want) {
+						t.Errorf("details = %v, want %s", details, want)
+					}
+				}
+			})
+		}
+	}
+
+	t.Run("skipped notes", func(t *testing.T) {
+		body := uploadBody("japanese.apkg", apkg, "name", "anki", "words_per_round", "10", "skip_invalid", "true")
+		rec := e.do(http.MethodPost, apiV1Prefix+"/game/lists/import", body, "Content-Type", uploadContentType)
+		var resp struct {
+			List struct {
+				Language            string `json:"language"`
+				TranslationLanguage string `json:"translation_language"`
+				Words               []struct {
+					Display string `json:"display"`
+				} `json:"words"`
+			} `json:"list"`
+			Skipped []struct {
+				Ref string `json:"ref"`
+			} `json:"skipped"`
+		}
+		decodeJSON(t, rec, &resp)
+		if resp.List.Language != "jp" || resp.List.TranslationLanguage != "en" || len(resp.List.Words) != 29 {
+			t.Errorf("list = %s/%s with %d words, want jp/en with 29", resp.List.Language, resp.List.TranslationLanguage, len(resp.List.Words))
+		}
+		var got []string
+		for _, s := range resp.Skipped {
+			got = append(got, s.Ref)
+		}
+		if want := []string{"note 4", "note 5", "note 7", "note 8"}; !slices.Equal(got, want) {
+			t.Errorf("skipped = %v, want %v", got, want)
+		}
+	})
+}
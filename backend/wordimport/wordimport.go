@@ -0,0 +1,214 @@
+// Package wordimport は単語リスト（TSV）を words テーブルの項目に変換する。
+// 日本語の単語は表示形・入力する読み・ふりがなを持ち、読みが表示形の区間に一致するかを検証する
+package wordimport
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"typing-game-backend/furigana"
+	"typing-game-backend/game"
+	"typing-game-backend/kanadict"
+)
+
+// Word は words テーブルの項目（サーバーの WordItem と同じ属性）
+type Word struct {
+	Category  string             `dynamodbav:"category" json:"category"`
+	WordID    string             `dynamodbav:"word_id" json:"word_id"`
+	Word      string             `dynamodbav:"word" json:"word"`
+	Display   string             `dynamodbav:"display,omitempty" json:"display"`
+	Furigana  []furigana.Segment `dynamodbav:"furigana,omitempty" json:"furigana,omitempty"`
+	Round     int                `dynamodbav:"round" json:"round"`
+	Type      string             `dynamodbav:"type" json:"type"`
+	Language  string             `dynamodbav:"language" json:"language"`
+	LookupKey string             `dynamodbav:"lookup_key" json:"-"`
+	// Review は辞書で読みを付けた語のうち、読みが複数あり確認が必要な語（保存しない）
+	Review []string `dynamodbav:"-" json:"review,omitempty"`
+}
+
+// Options は取り込む単語のカテゴリーと言語。Dictionary を指定すると、読みもルビもない漢字に辞書で読みを付ける
+type Options struct {
+	Category   string
+	Language   string
+	Dictionary *kanadict.Dictionary
+}
+
+// Parse はタブ区切りの単語リストを読み込む。1行1語で、列は次のとおり（# で始まる行と空行は読み飛ばす）。
+//
+//	round	type	display	reading
+//
+// type は normal・bonus・debuff。日本語の display は漢字かな混じりで、reading（かな）から区間ごとの読みを求める。
+// 読みの割り当て方が複数ある場合は display を青空文庫形式のルビ（一《いち》日《にち》）で書き、reading は省略できる。
+// Options.Dictionary を指定した場合は reading を省略でき、ルビのない漢字に辞書で読みを付ける（確認が必要な語は Word.Review に入る）。
+// かなだけの単語と日本語以外の単語は reading を省略でき、word は display と同じになる。
+// 単語の ID は <category>_<language>_<round>_<連番>（特殊単語は _bonus_・_debuff_ を挟む）で、
+// 取り込み直しても同じ行は同じ項目になる。エラーのある行はすべてまとめて返す
+func Parse(r io.Reader, opts Options) ([]Word, error) {
+	var words []Word
+	var errs []error
+	// counts は ID の連番（ラウンド・種類ごと）
+	counts := make(map[string]int)
+
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimRight(strings.TrimPrefix(scanner.Text(), "\ufeff"), "\r")
+		if strings.TrimSpace(text) == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		w, err := parseLine(text, opts)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("line %d: %w", line, err))
+			continue
+		}
+		key := fmt.Sprintf("%d#%s", w.Round, w.Type)
+		counts[key]++
+		w.WordID = WordID(opts.Category, opts.Language, w.Round, w.Type, counts[key])
+		words = append(words, w)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read word list: %w", err)
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	if len(words) == 0 {
+		return nil, errors.New("word list has no words")
+	}
+	return words, nil
+}
+
+func parseLine(text string, opts Options) (Word, error) {
+	fields := strings.Split(text, "\t")
+	if len(fields) < 3 || len(fields) > 4 {
+		return Word{}, fmt.Errorf("expected 3 or 4 tab-separated columns (round, type, display, reading), got %d", len(fields))
+	}
+	for i := range fields {
+		fields[i] = strings.TrimSpace(fields[i])
+	}
+
+	round, err := strconv.Atoi(fields[0])
+	if err != nil || round < 1 {
+		return Word{}, fmt.Errorf("round %q must be a positive integer", fields[0])
+	}
+	reading := ""
+	if len(fields) == 4 {
+		reading = fields[3]
+	}
+	return NewWord(round, fields[1], fields[2], reading, opts)
+}
+
+// NewWord は1語を検証して項目にする（ID は付けない）。type は normal・bonus・debuff で、
+// 表示形と読みの規則は Parse と同じ
+func NewWord(round int, wordType, display, reading string, opts Options) (Word, error) {
+	if round < 1 {
+		return Word{}, fmt.Errorf("round %d must be a positive integer", round)
+	}
+	if t := game.WordType(wordType); t != game.Normal && t != game.Bonus && t != game.Debuff {
+		return Word{}, fmt.Errorf("type %q must be normal, bonus or debuff", wordType)
+	}
+
+	w := Word{
+		Category:  opts.Category,
+		Round:     round,
+		Type:      wordType,
+		Language:  opts.Language,
+		LookupKey: fmt.Sprintf("%s#%s#%d", opts.Category, opts.Language, round),
+	}
+	if opts.Language != "jp" {
+		if display == "" || (reading != "" && reading != display) {
+			return Word{}, fmt.Errorf("display %q must be set and match reading %q", display, reading)
+		}
+		w.Word, w.Display = display, display
+		return w, nil
+	}
+
+	segments, review, err := Segment(display, reading, opts.Dictionary)
+	if err != nil {
+		return Word{}, err
+	}
+	w.Display = furigana.Text(segments)
+	w.Word = furigana.ToHiragana(furigana.Reading(segments))
+	w.Furigana = segments
+	w.Review = review
+	return w, nil
+}
+
+// Segment は日本語の表示形（ルビ付きでもよい）と読みから区間ごとの読みを求め、一致するか検証する。
+// reading を省略した場合はルビとかなから読みを求め、ルビのない漢字には dict（nil の場合はエラー）で読みを付ける。
+// 辞書で付けた読みのうち確認が必要な語を review に返す
+func Segment(display, reading string, dict *kanadict.Dictionary) (segments []furigana.Segment, review []string, err error) {
+	if display == "" {
+		return nil, nil, errors.New("display is required")
+	}
+	if reading != "" && !furigana.IsKana(reading) {
+		return nil, nil, fmt.Errorf("reading %q must be kana", reading)
+	}
+
+	segments, err = furigana.ParseRuby(display)
+	if err != nil {
+		return nil, nil, fmt.Errorf("display %q: %w", display, err)
+	}
+	text := furigana.Text(segments)
+	// ルビのない漢字がある場合は読みから区間を求める（読みがなければ辞書で読む）
+	if furigana.FirstKanji(furigana.Reading(segments)) != "" {
+		switch {
+		case reading != "":
+			if segments, err = furigana.Align(text, reading); err != nil {
+				return nil, nil, err
+			}
+		case dict != nil:
+			if segments, review, err = readSegments(segments, dict); err != nil {
+				return nil, nil, fmt.Errorf("display %q: %w (add a reading column or ruby)", display, err)
+			}
+		default:
+			return nil, nil, fmt.Errorf("display %q has kanji without a reading (add a reading column or ruby)", display)
+		}
+	}
+	if reading == "" {
+		reading = furigana.Reading(segments)
+	}
+	if err := furigana.Validate(text, reading, segments); err != nil {
+		return nil, nil, err
+	}
+	if !furigana.IsKana(furigana.Reading(segments)) {
+		return nil, nil, fmt.Errorf("reading of %q must be kana", text)
+	}
+	return segments, review, nil
+}
+
+// readSegments はルビのない漢字の区間を辞書で読み、区間に分け直す。ルビの付いた区間はそのままにする
+func readSegments(segments []furigana.Segment, dict *kanadict.Dictionary) ([]furigana.Segment, []string, error) {
+	var result []furigana.Segment
+	var review []string
+	for _, s := range segments {
+		if s.Reading != "" || furigana.FirstKanji(s.Text) == "" {
+			result = append(result, s)
+			continue
+		}
+		read := dict.Read(s.Text)
+		generated, err := read.Segments()
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, t := range read.Ambiguous() {
+			review = append(review, fmt.Sprintf("%s: %s", t.Text, strings.Join(t.Readings, "/")))
+		}
+		result = append(result, generated...)
+	}
+	return result, review, nil
+}
+
+// WordID は scripts/add-difficulty-words.go と同じ形式の単語の ID を返す（n はラウンド・種類ごとの連番）
+func WordID(category, language string, round int, wordType string, n int) string {
+	if wordType == string(game.Normal) {
+		return fmt.Sprintf("%s_%s_%d_%03d", category, language, round, n)
+	}
+	return fmt.Sprintf("%s_%s_%d_%s_%03d", category, language, round, wordType, n)
+}
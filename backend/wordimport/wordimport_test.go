@@ -0,0 +1,83 @@
+package wordimport
+
+import (
+	"strings"
+	"testing"
+
+	"typing-game-backend/kanadict"
+)
+
+func TestParse(t *testing.T) {
+	list := "# round\ttype\tdisplay\treading\n" +
+		"1\tnormal\t食べ物\tたべもの\n" +
+		"1\tnormal\tコーヒー\n" +
+		"1\tbonus\t一《いち》日《にち》\n" +
+		"2\tnormal\tおはよう\tおはよう\n"
+	words, err := Parse(strings.NewReader(list), Options{Category: "beginner_words", Language: "jp"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct{ id, word, display string }{
+		{"beginner_words_jp_1_001", "たべもの", "食べ物"},
+		{"beginner_words_jp_1_002", "こーひー", "コーヒー"},
+		{"beginner_words_jp_1_bonus_001", "いちにち", "一日"},
+		{"beginner_words_jp_2_001", "おはよう", "おはよう"},
+	}
+	if len(words) != len(want) {
+		t.Fatalf("words = %v, want %d words", words, len(want))
+	}
+	for i, w := range want {
+		if words[i].WordID != w.id || words[i].Word != w.word || words[i].Display != w.display {
+			t.Errorf("words[%d] = %s %s %s, want %s %s %s", i, words[i].WordID, words[i].Word, words[i].Display, w.id, w.word, w.display)
+		}
+	}
+	if n := len(words[0].Furigana); n != 3 || words[0].LookupKey != "beginner_words#jp#1" {
+		t.Errorf("words[0] = %+v, want 3 furigana segments and lookup key beginner_words#jp#1", words[0])
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	list := "1\tnormal\t食べ物\tたぺもの\n" + // 送りがなが読みと一致しない
+		"1\tnormal\t猫\n" + // 読みがない
+		"0\tnormal\tねこ\n" +
+		"1\tspecial\tねこ\n" +
+		"1\tnormal\t日の日\tひのひのひ\n" // 読みの割り当て方が複数ある
+	_, err := Parse(strings.NewReader(list), Options{Category: "beginner_words", Language: "jp"})
+	if err == nil {
+		t.Fatal("Parse: want error")
+	}
+	for _, line := range []string{"line 1:", "line 2:", "line 3:", "line 4:", "line 5:"} {
+		if !strings.Contains(err.Error(), line) {
+			t.Errorf("err = %v, want an error for %s", err, line)
+		}
+	}
+
+	words, err := Parse(strings.NewReader("1\tnormal\tcat\n"), Options{Category: "beginner_words", Language: "en"})
+	if err != nil || words[0].Word != "cat" || words[0].Display != "cat" || words[0].Furigana != nil {
+		t.Errorf("en words = %v, %v; want cat without furigana", words, err)
+	}
+}
+
+func TestParseWithDictionary(t *testing.T) {
+	list := "1\tnormal\t食べ物\n" +
+		"1\tnormal\t今日《こんにち》は\n" + // ルビの読みを使う
+		"1\tnormal\t一日\n"
+	words, err := Parse(strings.NewReader(list), Options{Category: "beginner_words", Language: "jp", Dictionary: kanadict.Default()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if words[0].Word != "たべもの" || len(words[0].Review) != 0 {
+		t.Errorf("words[0] = %s %v, want たべもの without review", words[0].Word, words[0].Review)
+	}
+	if words[1].Word != "こんにちは" || len(words[1].Review) != 0 {
+		t.Errorf("words[1] = %s %v, want the ruby reading こんにちは", words[1].Word, words[1].Review)
+	}
+	if words[2].Word != "いちにち" || len(words[2].Review) != 1 || words[2].Review[0] != "一日: いちにち/ついたち" {
+		t.Errorf("words[2] = %s %v, want いちにち flagged for review", words[2].Word, words[2].Review)
+	}
+
+	if _, err := Parse(strings.NewReader("1\tnormal\t鬱\n"), Options{Category: "beginner_words", Language: "jp", Dictionary: kanadict.Default()}); err == nil || !strings.Contains(err.Error(), "鬱") {
+		t.Errorf("Parse with a kanji missing from the dictionary: err = %v, want an error naming it", err)
+	}
+}
@@ -0,0 +1,174 @@
+// Package wordlist はプレイヤー・先生が API で作成するカスタム単語リスト（word_lists テーブルの項目）と、
+// リストを遊ぶための共有コードをまとめたもの。リストの ID はカテゴリーの代わりに単語の取得・スコア・リーダーボードで使う
+package wordlist
+
+import (
+	"crypto/rand"
+	"fmt"
+	"strings"
+	"time"
+
+	"typing-game-backend/wordimport"
+)
+
+// IDPrefix はリストの ID（list_<共有コード>）の接頭辞。カテゴリーと区別するために使う
+const IDPrefix = "list_"
+
+// 共有コードは読み間違えやすい文字（0・1・I・L・O）を除いた英大文字・数字の CodeLength 文字
+const (
+	CodeLength   = 8
+	codeAlphabet = "23456789ABCDEFGHJKMNPQRSTUVWXYZ"
+)
+
+// リストの上限（ラウンド数の上限はルールセットで決まるため、ハンドラーで検証する）。
+// MaxWordLength は単語の表示形（ルビを含む）・読みの文字数の上限（読みの割り当ては文字数とともに時間がかかる）
+const (
+	MaxNameLength        = 40
+	MaxWordsPerRound     = 50
+	MaxWordLength        = 50
+	MaxTranslationLength = 100
+)
+
+// List はカスタム単語リスト。単語は WordsPerRound 語ずつラウンドに分け、
+// ゲームのラウンド数より少ない場合は最初のラウンドから繰り返す
+type List struct {
+	ListID        string            `dynamodbav:"list_id" json:"list_id"`
+	ShareCode     string            `dynamodbav:"share_code" json:"share_code"`
+	Name          string            `dynamodbav:"name" json:"name"`
+	Language      string            `dynamodbav:"language" json:"language"`
+	WordsPerRound int               `dynamodbav:"words_per_round" json:"words_per_round"`
+	Rounds        int               `dynamodbav:"rounds" json:"rounds"`
+	Words         []wordimport.Word `dynamodbav:"words" json:"words"`
+	// Translations は単語の ID ごとの TranslationLanguage の翻訳（翻訳のない単語は含まない）
+	TranslationLanguage string            `dynamodbav:"translation_language,omitempty" json:"translation_language,omitempty"`
+	Translations        map[string]string `dynamodbav:"translations,omitempty" json:"translations,omitempty"`
+	CreatedAt           int64             `dynamodbav:"created_at" json:"created_at"`
+}
+
+// Spec は作成するリストの内容。Words は wordimport.NewWord で検証済みの単語（ID は New で付ける）で、
+// Translations は Words と同じ順の翻訳（空文字は翻訳なし）
+type Spec struct {
+	Name                string
+	Language            string
+	WordsPerRound       int
+	Words               []wordimport.Word
+	TranslationLanguage string
+	Translations        []string
+}
+
+// New は共有コードのリストを作る。単語の ID は <リストの ID>_<language>_<round>_<連番> で、
+// カテゴリーの単語と同じ形式（特殊単語は _bonus_・_debuff_ を挟む）
+func New(code string, spec Spec, createdAt time.Time) *List {
+	l := &List{
+		ListID:              ID(code),
+		ShareCode:           code,
+		Name:                spec.Name,
+		Language:            spec.Language,
+		WordsPerRound:       spec.WordsPerRound,
+		Rounds:              (len(spec.Words) + spec.WordsPerRound - 1) / spec.WordsPerRound,
+		Words:               make([]wordimport.Word, len(spec.Words)),
+		TranslationLanguage: spec.TranslationLanguage,
+		CreatedAt:           createdAt.Unix(),
+	}
+	counts := make(map[string]int)
+	for i, w := range spec.Words {
+		w.Category = l.ListID
+		w.Language = l.Language
+		w.Round = i/l.WordsPerRound + 1
+		w.LookupKey = fmt.Sprintf("%s#%s#%d", l.ListID, l.Language, w.Round)
+		key := fmt.Sprintf("%d#%s", w.Round, w.Type)
+		counts[key]++
+		w.WordID = wordimport.WordID(l.ListID, l.Language, w.Round, w.Type, counts[key])
+		l.Words[i] = w
+
+		if i < len(spec.Translations) && spec.Translations[i] != "" {
+			if l.Translations == nil {
+				l.Translations = make(map[string]string)
+			}
+			l.Translations[w.WordID] = spec.Translations[i]
+		}
+	}
+	return l
+}
+
+// Round はゲームのラウンド（エンドレスモードではウェーブの単語のラウンド）の単語を返す。
+// リストのラウンド数を超えるラウンドは最初のラウンドから繰り返す
+func (l *List) Round(round int) []wordimport.Word {
+	if l.Rounds < 1 || round < 1 {
+		return nil
+	}
+	round = (round-1)%l.Rounds + 1
+	var words []wordimport.Word
+	for _, w := range l.Words {
+		if w.Round == round {
+			words = append(words, w)
+		}
+	}
+	return words
+}
+
+// Translation は単語の翻訳を返す（翻訳の言語が違う場合・翻訳のない単語は false）
+func (l *List) Translation(wordID, language string) (string, bool) {
+	if language != l.TranslationLanguage {
+		return "", false
+	}
+	translation, ok := l.Translations[wordID]
+	return translation, ok
+}
+
+// NewShareCode はランダムな共有コードを返す（重複は保存時に確認する）
+func NewShareCode() (string, error) {
+	// 文字の出やすさに偏りが出ないように、codeAlphabet の文字数の倍数以上のバイトは使わない
+	limit := 256 - 256%len(codeAlphabet)
+	code := make([]byte, 0, CodeLength)
+	buf := make([]byte, CodeLength*2)
+	for len(code) < CodeLength {
+		if _, err := rand.Read(buf); err != nil {
+			return "", fmt.Errorf("failed to generate share code: %w", err)
+		}
+		for _, b := range buf {
+			if int(b) < limit && len(code) < CodeLength {
+				code = append(code, codeAlphabet[int(b)%len(codeAlphabet)])
+			}
+		}
+	}
+	return string(code), nil
+}
+
+// NormalizeShareCode は入力された共有コードを大文字にして前後の空白を除き、形式が正しいかを返す
+func NormalizeShareCode(code string) (string, bool) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if len(code) != CodeLength {
+		return "", false
+	}
+	for _, r := range code {
+		if !strings.ContainsRune(codeAlphabet, r) {
+			return "", false
+		}
+	}
+	return code, true
+}
+
+// ID は共有コードのリストの ID を返す
+func ID(code string) string {
+	return IDPrefix + code
+}
+
+// IsID は category がリストの ID（list_<共有コード>）かを返す
+func IsID(category string) bool {
+	code, ok := strings.CutPrefix(category, IDPrefix)
+	if !ok {
+		return false
+	}
+	normalized, ok := NormalizeShareCode(code)
+	return ok && normalized == code
+}
+
+// ListIDOf はリストの単語の ID からリストの ID を返す（リストの単語でない場合は false）
+func ListIDOf(wordID string) (string, bool) {
+	n := len(IDPrefix) + CodeLength
+	if len(wordID) <= n || wordID[n] != '_' || !IsID(wordID[:n]) {
+		return "", false
+	}
+	return wordID[:n], true
+}
@@ -0,0 +1,107 @@
+package wordlist
+
+import (
+	"testing"
+	"time"
+
+	"typing-game-backend/wordimport"
+)
+
+func TestShareCode(t *testing.T) {
+	seen := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		code, err := NewShareCode()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if normalized, ok := NormalizeShareCode(code); !ok || normalized != code {
+			t.Fatalf("NewShareCode() = %q, which is not a valid share code", code)
+		}
+		if !IsID(ID(code)) {
+			t.Errorf("IsID(%q) = false", ID(code))
+		}
+		seen[code] = true
+	}
+	if len(seen) < 100 {
+		t.Errorf("got %d distinct codes out of 100", len(seen))
+	}
+
+	tests := []struct {
+		input string
+		want  string
+		ok    bool
+	}{
+		{input: "k7q2m9xa", want: "K7Q2M9XA", ok: true},
+		{input: " K7Q2M9XA ", want: "K7Q2M9XA", ok: true},
+		{input: "K7Q2M9X", ok: false},
+		{input: "K7Q2M9X0", ok: false}, // 0 は O と紛らわしいため使わない
+		{input: "きょうのたんご", ok: false},
+	}
+	for _, tt := range tests {
+		got, ok := NormalizeShareCode(tt.input)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("NormalizeShareCode(%q) = %q, %v, want %q, %v", tt.input, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	for category, want := range map[string]bool{
+		"list_K7Q2M9XA":  true,
+		"list_k7q2m9xa":  false,
+		"beginner_words": false,
+		"list_":          false,
+	} {
+		if got := IsID(category); got != want {
+			t.Errorf("IsID(%q) = %v, want %v", category, got, want)
+		}
+	}
+}
+
+func TestNew(t *testing.T) {
+	spec := Spec{Name: "どうぶつ", Language: "en", WordsPerRound: 2, TranslationLanguage: "jp"}
+	for _, w := range []struct{ display, wordType, translation string }{
+		{"dog", "normal", "いぬ"},
+		{"cat", "bonus", ""},
+		{"bird", "normal", "とり"},
+	} {
+		word, err := wordimport.NewWord(1, w.wordType, w.display, "", wordimport.Options{Language: spec.Language})
+		if err != nil {
+			t.Fatal(err)
+		}
+		spec.Words = append(spec.Words, word)
+		spec.Translations = append(spec.Translations, w.translation)
+	}
+
+	l := New("K7Q2M9XA", spec, time.Unix(1700000000, 0))
+	if l.ListID != "list_K7Q2M9XA" || l.Rounds != 2 || l.CreatedAt != 1700000000 {
+		t.Fatalf("list = %s with %d rounds created at %d, want list_K7Q2M9XA with 2 rounds created at 1700000000", l.ListID, l.Rounds, l.CreatedAt)
+	}
+	wantIDs := []string{"list_K7Q2M9XA_en_1_001", "list_K7Q2M9XA_en_1_bonus_001", "list_K7Q2M9XA_en_2_001"}
+	for i, w := range l.Words {
+		if w.WordID != wantIDs[i] || w.Category != l.ListID || w.LookupKey != l.ListID+"#en#"+string(rune('0'+w.Round)) {
+			t.Errorf("word %d = %+v, want ID %s in %s", i, w, wantIDs[i], l.ListID)
+		}
+		if listID, ok := ListIDOf(w.WordID); !ok || listID != l.ListID {
+			t.Errorf("ListIDOf(%q) = %q, %v", w.WordID, listID, ok)
+		}
+	}
+	if _, ok := ListIDOf("bw_001"); ok {
+		t.Errorf("ListIDOf(bw_001) = true, want false")
+	}
+
+	// ゲームのラウンドはリストのラウンド数で繰り返す
+	for round, want := range map[int]int{1: 2, 2: 1, 3: 2, 4: 1, 0: 0} {
+		if got := len(l.Round(round)); got != want {
+			t.Errorf("Round(%d) has %d words, want %d", round, got, want)
+		}
+	}
+
+	if got, ok := l.Translation("list_K7Q2M9XA_en_2_001", "jp"); !ok || got != "とり" {
+		t.Errorf("Translation(bird, jp) = %q, %v, want とり", got, ok)
+	}
+	if _, ok := l.Translation("list_K7Q2M9XA_en_1_bonus_001", "jp"); ok {
+		t.Errorf("Translation(cat, jp) = true, want no translation")
+	}
+	if _, ok := l.Translation("list_K7Q2M9XA_en_1_001", "fr"); ok {
+		t.Errorf("Translation(dog, fr) = true, want false for another language")
+	}
+}
@@ -0,0 +1,367 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+
+	"typing-game-backend/apierror"
+	"typing-game-backend/deckimport"
+	"typing-game-backend/game"
+	"typing-game-backend/kanadict"
+	"typing-game-backend/names"
+	"typing-game-backend/wordimport"
+	"typing-game-backend/wordlist"
+)
+
+// WordListRequest はカスタム単語リストの作成リクエスト
+type WordListRequest struct {
+	Name     string `json:"name" binding:"required"`
+	Language string `json:"language" binding:"required"`
+	// WordsPerRound は1ラウンドの単語数。単語は先頭から WordsPerRound 語ずつラウンドに分ける
+	WordsPerRound int            `json:"words_per_round" binding:"required"`
+	Words         []WordListWord `json:"words" binding:"required"`
+	// TranslationLanguage は単語の翻訳の言語（翻訳のある単語がある場合は必須）
+	TranslationLanguage string `json:"translation_language,omitempty"`
+	// AcceptReview は辞書で付けた読みのうち、読みが複数あり確認が必要な読みをそのまま使うか
+	// （cmd/content の -accept-review と同じ）
+	AcceptReview bool `json:"accept_review,omitempty"`
+}
+
+// WordListWord はカスタム単語リストの1語。表示形・読み・種類は単語の取り込み（wordimport）と同じ規則で検証する
+type WordListWord struct {
+	Display     string `json:"display"`
+	Reading     string `json:"reading,omitempty"`
+	Type        string `json:"type,omitempty"` // normal（省略時）・bonus・debuff
+	Translation string `json:"translation,omitempty"`
+}
+
+// createWordList はカスタム単語リストを作成し、遊ぶための共有コードとともに返す
+func (s *server) createWordList(c *gin.Context) {
+	var req WordListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		respondError(c, bindError(err))
+		return
+	}
+
+	spec, invalid := s.checkWordList(&req)
+	if len(invalid) > 0 {
+		respondError(c, apierror.Validation(invalid...))
+		return
+	}
+
+	logger := loggerFrom(c.Request.Context())
+	l, err := s.store.createWordList(c.Request.Context(), spec)
+	if err != nil {
+		logger.Error("Failed to create word list", "error", err)
+		respondError(c, storageError(err))
+		return
+	}
+
+	logger.Info("Word list created", "list_id", l.ListID, "language", l.Language, "words", len(l.Words), "rounds", l.Rounds)
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Word list created",
+		"list":    l,
+	})
+}
+
+// WordListImportForm は単語帳のファイル（Anki の .apkg・タブ区切りのテキスト）からのカスタム単語リストの作成のフォーム
+// （multipart/form-data）。言語・翻訳の言語を省略した場合はノートのテキストから推定する
+type WordListImportForm struct {
+	File          *multipart.FileHeader `json:"file" form:"file" binding:"required"`
+	Name          string                `json:"name" form:"name" binding:"required"`
+	WordsPerRound int                   `json:"words_per_round" form:"words_per_round" binding:"required"`
+	Language      string                `json:"language" form:"language"`
+	// TranslationLanguage はノートの裏面（翻訳）の言語
+	TranslationLanguage string `json:"translation_language" form:"translation_language"`
+	// FrontField・BackField・ReadingField はノートのフィールドの名前か番号（deckimport.Fields）
+	FrontField   string `json:"front_field" form:"front_field"`
+	BackField    string `json:"back_field" form:"back_field"`
+	ReadingField string `json:"reading_field" form:"reading_field"`
+	AcceptReview bool   `json:"accept_review" form:"accept_review"`
+	// SkipInvalid は単語にできないノートを取り込まずに作成するか（false の場合は検証エラーにする）
+	SkipInvalid bool `json:"skip_invalid" form:"skip_invalid"`
+}
+
+// importWordList は単語帳のファイルからカスタム単語リストを作成する。ノートは易しい順にラウンドに分け、
+// 単語は JSON で作成する場合と同じ規則で検証する。取り込まなかったノートは理由とともに返す
+func (s *server) importWordList(c *gin.Context) {
+	var form WordListImportForm
+	if err := c.ShouldBind(&form); err != nil {
+		respondError(c, bindError(err))
+		return
+	}
+
+	logger := loggerFrom(c.Request.Context())
+	data, err := readUpload(form.File)
+	if err != nil {
+		respondError(c, bindError(err))
+		return
+	}
+	// 単語リストの単語数の上限を超えるノートは読まない（取り込まないノートも数える）
+	maxNotes := wordlist.MaxWordsPerRound * s.rulesets.MaxRounds()
+	notes, err := deckimport.Read(data, maxNotes)
+	if errors.Is(err, deckimport.ErrTooManyNotes) {
+		respondError(c, apierror.Validation(apierror.Field("file", apierror.FieldOutOfRange, 1, maxNotes)))
+		return
+	}
+	if err != nil {
+		logger.Info("Uploaded deck could not be read", "file", form.File.Filename, "error", err)
+		respondError(c, apierror.Validation(apierror.Field("file", apierror.FieldInvalid)))
+		return
+	}
+
+	deck, err := deckimport.Build(notes, deckimport.Options{
+		Language:            form.Language,
+		TranslationLanguage: form.TranslationLanguage,
+		// ラウンドはリストの保存時に付け直すため、1ラウンドの単語数はここでは検証しない
+		WordsPerRound: max(form.WordsPerRound, 1),
+		Fields: deckimport.Fields{
+			Front:   form.FrontField,
+			Back:    form.BackField,
+			Reading: form.ReadingField,
+		},
+		Dictionary:           kanadict.Default(),
+		MaxWordLength:        wordlist.MaxWordLength,
+		MaxTranslationLength: wordlist.MaxTranslationLength,
+	})
+	if err != nil {
+		// 言語を推定できない
+		logger.Info("Uploaded deck could not be imported", "file", form.File.Filename, "error", err)
+		respondError(c, apierror.Validation(apierror.Field("language", apierror.FieldRequired)))
+		return
+	}
+
+	spec := wordlist.Spec{
+		Name:                names.Normalize(form.Name),
+		Language:            deck.Language,
+		WordsPerRound:       form.WordsPerRound,
+		Words:               deck.Words(),
+		TranslationLanguage: deck.TranslationLanguage,
+		Translations:        deck.Translations(),
+	}
+	translated := slices.ContainsFunc(spec.Translations, func(t string) bool { return t != "" })
+	invalid, _ := s.checkWordListSettings(spec, len(spec.Words), "file", translated)
+	skipped := append([]deckimport.Issue{}, deck.Skipped...)
+	if form.SkipInvalid {
+		skipped = append(skipped, deck.Invalid...)
+	} else {
+		for _, issue := range deck.Invalid {
+			invalid = append(invalid, apierror.Field(fmt.Sprintf("file.notes.%d", issue.Note), apierror.FieldInvalid))
+		}
+	}
+	if !form.AcceptReview {
+		for _, e := range deck.Review() {
+			invalid = append(invalid, apierror.Field(fmt.Sprintf("file.notes.%d.reading", e.Note), apierror.FieldRequired))
+		}
+	}
+	if len(invalid) > 0 {
+		respondError(c, apierror.Validation(invalid...))
+		return
+	}
+	sort.Slice(skipped, func(i, j int) bool { return skipped[i].Note < skipped[j].Note })
+
+	l, err := s.store.createWordList(c.Request.Context(), spec)
+	if err != nil {
+		logger.Error("Failed to create word list", "error", err)
+		respondError(c, storageError(err))
+		return
+	}
+
+	logger.Info("Word list imported", "list_id", l.ListID, "language", l.Language, "notes", len(notes), "words", len(l.Words), "skipped", len(skipped))
+	c.JSON(http.StatusCreated, gin.H{
+		"message": "Word list imported",
+		"list":    l,
+		"skipped": skipped,
+	})
+}
+
+// readUpload はアップロードされたファイルを読む（大きさはボディの上限で制限している）
+func readUpload(fh *multipart.FileHeader) ([]byte, error) {
+	f, err := fh.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return io.ReadAll(f)
+}
+
+// checkWordList は作成リクエストを検証し、単語を wordimport で表示形・読み・ふりがなにしたリストの内容を返す
+func (s *server) checkWordList(req *WordListRequest) (wordlist.Spec, []apierror.FieldError) {
+	spec := wordlist.Spec{
+		Name:                names.Normalize(req.Name),
+		Language:            req.Language,
+		WordsPerRound:       req.WordsPerRound,
+		TranslationLanguage: req.TranslationLanguage,
+	}
+	translated := slices.ContainsFunc(req.Words, func(w WordListWord) bool { return strings.TrimSpace(w.Translation) != "" })
+	invalid, ok := s.checkWordListSettings(spec, len(req.Words), "words", translated)
+	// 単語の読みの規則は言語で、ラウンドは1ラウンドの単語数で決まるため、これらが不正な場合は単語を検証しない
+	if !ok {
+		return spec, invalid
+	}
+
+	opts := wordimport.Options{Language: req.Language}
+	if req.Language == "jp" {
+		opts.Dictionary = kanadict.Default()
+	}
+	for i, w := range req.Words {
+		field := fmt.Sprintf("words.%d", i)
+		wordType := w.Type
+		if wordType == "" {
+			wordType = string(game.Normal)
+		}
+		display := strings.TrimSpace(w.Display)
+		reading := strings.TrimSpace(w.Reading)
+		translation := strings.TrimSpace(w.Translation)
+
+		// 長すぎる単語は読みを割り当てる前に拒否する
+		displayTooLong := utf8.RuneCountInString(display) > wordlist.MaxWordLength
+		readingTooLong := utf8.RuneCountInString(reading) > wordlist.MaxWordLength
+		var word wordimport.Word
+		var err error
+		if !displayTooLong && !readingTooLong {
+			word, err = wordimport.NewWord(i/req.WordsPerRound+1, wordType, display, reading, opts)
+		}
+		switch t := game.WordType(wordType); {
+		case display == "":
+			invalid = append(invalid, apierror.Field(field+".display", apierror.FieldRequired))
+		case displayTooLong:
+			invalid = append(invalid, apierror.Field(field+".display", apierror.FieldOutOfRange, 0, wordlist.MaxWordLength))
+		case readingTooLong:
+			invalid = append(invalid, apierror.Field(field+".reading", apierror.FieldOutOfRange, 0, wordlist.MaxWordLength))
+		case t != game.Normal && t != game.Bonus && t != game.Debuff:
+			invalid = append(invalid, apierror.Field(field+".type", apierror.FieldInvalid))
+		case err != nil:
+			// 読みがかなでない・表示形と一致しない・辞書にない漢字がある
+			invalid = append(invalid, apierror.Field(field+".reading", apierror.FieldInvalid))
+		case len(word.Review) > 0 && !req.AcceptReview:
+			invalid = append(invalid, apierror.Field(field+".reading", apierror.FieldRequired))
+		}
+		if utf8.RuneCountInString(translation) > wordlist.MaxTranslationLength {
+			invalid = append(invalid, apierror.Field(field+".translation", apierror.FieldOutOfRange, 0, wordlist.MaxTranslationLength))
+		}
+		spec.Words = append(spec.Words, word)
+		spec.Translations = append(spec.Translations, translation)
+	}
+	return spec, invalid
+}
+
+// checkWordListSettings はリストの名前・言語・1ラウンドの単語数・単語の数（項目名 wordsField）・翻訳の言語を検証し、
+// 単語を検証できる（言語・1ラウンドの単語数・単語の数が正しい）かを返す。
+// 単語の数の上限は1ラウンドの単語数とルールセットの最も多いラウンド数で決まる
+func (s *server) checkWordListSettings(spec wordlist.Spec, words int, wordsField string, translated bool) ([]apierror.FieldError, bool) {
+	var invalid []apierror.FieldError
+	if n := utf8.RuneCountInString(spec.Name); n < 1 || n > wordlist.MaxNameLength {
+		invalid = append(invalid, apierror.Field("name", apierror.FieldOutOfRange, 1, wordlist.MaxNameLength))
+	}
+	languageValid := slices.Contains(s.cfg.Game.WordLanguages, spec.Language)
+	if !languageValid {
+		invalid = append(invalid, apierror.Field("language", apierror.FieldInvalid))
+	}
+	roundsValid := spec.WordsPerRound >= 1 && spec.WordsPerRound <= wordlist.MaxWordsPerRound
+	if !roundsValid {
+		invalid = append(invalid, apierror.Field("words_per_round", apierror.FieldOutOfRange, 1, wordlist.MaxWordsPerRound))
+	}
+	limit := spec.WordsPerRound * s.rulesets.MaxRounds()
+	countValid := words >= 1 && words <= limit
+	if roundsValid && !countValid {
+		invalid = append(invalid, apierror.Field(wordsField, apierror.FieldOutOfRange, 1, limit))
+	}
+	switch {
+	case spec.TranslationLanguage == "" && translated:
+		invalid = append(invalid, apierror.Field("translation_language", apierror.FieldRequired))
+	case spec.TranslationLanguage != "" && !slices.Contains(s.cfg.Game.TranslationLanguages, spec.TranslationLanguage):
+		invalid = append(invalid, apierror.Field("translation_language", apierror.FieldInvalid))
+	}
+	return invalid, languageValid && roundsValid && countValid
+}
+
+// getWordList は共有コードのカスタム単語リストを返す
+func (s *server) getWordList(c *gin.Context) {
+	code, ok := wordlist.NormalizeShareCode(c.Param("share_code"))
+	if !ok {
+		respondError(c, apierror.New(apierror.CodeWordListNotFound))
+		return
+	}
+
+	l, err := s.cachedFetchWordList(c.Request.Context(), wordlist.ID(code))
+	if errors.Is(err, errWordListNotFound) {
+		respondError(c, apierror.Wrap(apierror.CodeWordListNotFound, err))
+		return
+	}
+	if err != nil {
+		loggerFrom(c.Request.Context()).Error("Failed to fetch word list", "share_code", code, "error", err)
+		respondError(c, storageError(err))
+		return
+	}
+
+	s.respondCacheable(c, gin.H{
+		"list": l,
+	})
+}
+
+// wordListCategory は category がカスタム単語リストの ID の場合にリストを返す（カテゴリーの場合は nil）。
+// 作成されていないリストは category の検証エラーとして返す
+func (s *server) wordListCategory(ctx context.Context, category string) (*wordlist.List, []apierror.FieldError, error) {
+	if !wordlist.IsID(category) {
+		return nil, nil, nil
+	}
+	l, err := s.cachedFetchWordList(ctx, category)
+	if errors.Is(err, errWordListNotFound) {
+		return nil, []apierror.FieldError{apierror.Field("category", apierror.FieldInvalid)}, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return l, nil, nil
+}
+
+// listWordItems はカスタム単語リストの単語をカテゴリーの単語と同じ形にする
+func listWordItems(words []wordimport.Word) []WordItem {
+	items := make([]WordItem, len(words))
+	for i, w := range words {
+		items[i] = WordItem{
+			Category: w.Category,
+			WordID:   w.WordID,
+			Word:     w.Word,
+			Display:  w.Display,
+			Furigana: w.Furigana,
+			Round:    w.Round,
+			Type:     w.Type,
+			Language: w.Language,
+		}
+	}
+	return items
+}
+
+// fetchWordTranslation は単語の翻訳を返す。カスタム単語リストの単語はリストの翻訳を、
+// それ以外は translations テーブルの翻訳を返す（どちらも登録されていない場合は errTranslationNotFound）
+func (s *server) fetchWordTranslation(ctx context.Context, wordID, targetLanguage string) (string, error) {
+	listID, ok := wordlist.ListIDOf(wordID)
+	if !ok {
+		return s.cachedFetchTranslation(ctx, wordID, targetLanguage)
+	}
+
+	l, err := s.cachedFetchWordList(ctx, listID)
+	if errors.Is(err, errWordListNotFound) {
+		return "", fmt.Errorf("%w for word_id: %s (%v)", errTranslationNotFound, wordID, err)
+	}
+	if err != nil {
+		return "", err
+	}
+	translation, ok := l.Translation(wordID, targetLanguage)
+	if !ok {
+		return "", fmt.Errorf("%w for word_id: %s, language: %s", errTranslationNotFound, wordID, targetLanguage)
+	}
+	return translation, nil
+}
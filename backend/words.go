@@ -0,0 +1,200 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+
+	"typing-game-backend/furigana"
+	"typing-game-backend/game"
+)
+
+// validCategories はプレイ可能なカテゴリーID
+var validCategories = []string{"beginner_words", "intermediate_words", "beginner_conversation", "intermediate_conversation"}
+
+// wordsLookupIndex は lookup_key をハッシュキーに持つ words テーブルのGSI
+const wordsLookupIndex = "LookupIndex"
+
+// wordLookupKey は LookupIndex のキー（category#language#round）を組み立てる
+func wordLookupKey(category, language string, round int) string {
+	return fmt.Sprintf("%s#%s#%d", category, language, round)
+}
+
+// fallbackWords はwordsテーブル未設定時（ローカル開発用）の単語を返す。
+// 日本語の単語は青空文庫形式のルビ（水《みず》）で表示形と読みを書く
+func fallbackWords(category string, round int, language string) []WordItem {
+	var words []string
+	if language == "jp" {
+		switch category {
+		case "beginner_words":
+			words = []string{"水《みず》", "食《た》べ物《もの》", "飲《の》み物《もの》", "家《いえ》", "学校《がっこう》", "仕事《しごと》", "友達《ともだち》", "家族《かぞく》", "犬《いぬ》", "猫《ねこ》"}
+		case "intermediate_words":
+			words = []string{"環境《かんきょう》", "温暖化《おんだんか》", "公害《こうがい》", "リサイクル", "自然《しぜん》", "動物《どうぶつ》", "植物《しょくぶつ》", "生態系《せいたいけい》", "地球《ちきゅう》", "宇宙《うちゅう》"}
+		case "beginner_conversation":
+			words = []string{"おはよう", "こんにちは", "こんばんは", "おやすみ", "はじめまして", "よろしく", "ありがとう", "すみません", "ごめんなさい", "いいえ"}
+		case "intermediate_conversation":
+			words = []string{"お久《ひさ》しぶりです", "元気《げんき》でしたか", "おかげさまで", "いかがですか", "どうされましたか", "何《なに》かありましたか", "心配《しんぱい》しています", "大丈夫《だいじょうぶ》でしょうか", "手伝《てつだ》いましょうか", "何《なに》かできることは"}
+		default:
+			words = []string{"水《みず》", "食《た》べ物《もの》", "家《いえ》", "学校《がっこう》", "犬《いぬ》", "猫《ねこ》"}
+		}
+	} else {
+		switch category {
+		case "beginner_words":
+			words = []string{"water", "food", "drink", "house", "school", "work", "friend", "family", "dog", "cat"}
+		case "intermediate_words":
+			words = []string{"environment", "global warming", "pollution", "recycle", "nature", "animal", "plant", "ecosystem", "earth", "space"}
+		case "beginner_conversation":
+			words = []string{"good morning", "hello", "good evening", "good night", "nice to meet you", "please treat me well", "thank you", "excuse me", "sorry", "no"}
+		case "intermediate_conversation":
+			words = []string{"long time no see", "how have you been", "thanks to you", "how are things", "what happened", "did something happen", "i am worried", "will it be okay", "shall i help", "is there anything i can do"}
+		default:
+			words = []string{"water", "food", "house", "school", "dog", "cat"}
+		}
+	}
+
+	var items []WordItem
+	for i, w := range words {
+		item := WordItem{
+			Category: category,
+			WordID:   fmt.Sprintf("fallback_%d_%d", round, i),
+			Word:     w,
+			Display:  w,
+			Round:    round,
+			Type:     "normal",
+			Language: language,
+		}
+		if language == "jp" {
+			// 組み込みの単語のルビは正しいことをテストで確認している
+			segments, _ := furigana.ParseRuby(w)
+			item.Word = furigana.ToHiragana(furigana.Reading(segments))
+			item.Display = furigana.Text(segments)
+			item.Furigana = segments
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// applyModifiers はエンドレスモードのウェーブの変化を単語に加える。words（キャッシュの値）は変更せず、新しいスライスを返す
+func applyModifiers(words []WordItem, modifiers []game.WordModifier) []WordItem {
+	for _, m := range modifiers {
+		switch m {
+		case game.ModifierNoBonus:
+			var kept []WordItem
+			for _, w := range words {
+				if w.Type != string(game.Bonus) {
+					kept = append(kept, w)
+				}
+			}
+			words = kept
+		case game.ModifierCompound:
+			words = compoundWords(words)
+		}
+	}
+	return words
+}
+
+// compoundWords は通常の単語を次の通常の単語とつなげる（最後の単語は最初の単語とつなげる）。
+// 日本語以外は空白で区切る。通常の単語が2つ未満の場合と特殊単語はそのままにする
+func compoundWords(words []WordItem) []WordItem {
+	var normal []int
+	for i, w := range words {
+		if w.Type == string(game.Normal) {
+			normal = append(normal, i)
+		}
+	}
+
+	result := make([]WordItem, len(words))
+	copy(result, words)
+	if len(normal) < 2 {
+		return result
+	}
+	for n, i := range normal {
+		next := words[normal[(n+1)%len(normal)]]
+		sep := " "
+		if words[i].Language == "jp" {
+			sep = ""
+		}
+		result[i].WordID = words[i].WordID + "+" + next.WordID
+		result[i].Word = words[i].Word + sep + next.Word
+		result[i].Display = words[i].Display + sep + next.Display
+		if len(words[i].Furigana) > 0 && len(next.Furigana) > 0 {
+			result[i].Furigana = append(slices.Clip(words[i].Furigana), next.Furigana...)
+		}
+	}
+	return result
+}
+
+// practiceWords は練習モードで入力する game.PracticeWords 語を返す。単語が足りない場合は先頭から繰り返す
+func practiceWords(words []WordItem) []WordItem {
+	if len(words) == 0 {
+		return []WordItem{}
+	}
+	result := make([]WordItem, game.PracticeWords)
+	for i := range result {
+		result[i] = words[i%len(words)]
+	}
+	return result
+}
+
+// categoryRegistry は言語ごとのカテゴリー一覧を返す
+func categoryRegistry(language string) []map[string]interface{} {
+	var categories []map[string]interface{}
+
+	if language == "en" {
+		categories = []map[string]interface{}{
+			{
+				"id":          "beginner_words",
+				"name":        "Beginner Words",
+				"description": "Basic words used in daily life",
+				"icon":        "📚",
+			},
+			{
+				"id":          "intermediate_words",
+				"name":        "Intermediate Words",
+				"description": "More complex and specialized words",
+				"icon":        "🎓",
+			},
+			{
+				"id":          "beginner_conversation",
+				"name":        "Beginner Conversation",
+				"description": "Short daily conversation expressions",
+				"icon":        "💬",
+			},
+			{
+				"id":          "intermediate_conversation",
+				"name":        "Intermediate Conversation",
+				"description": "More complex and longer conversation expressions",
+				"icon":        "🗣️",
+			},
+		}
+	} else {
+		categories = []map[string]interface{}{
+			{
+				"id":          "beginner_words",
+				"name":        "初級単語",
+				"description": "日常生活でよく使う基本的な単語",
+				"icon":        "📚",
+			},
+			{
+				"id":          "intermediate_words",
+				"name":        "中級単語",
+				"description": "より複雑で専門的な単語",
+				"icon":        "🎓",
+			},
+			{
+				"id":          "beginner_conversation",
+				"name":        "初級会話",
+				"description": "日常的な短い会話表現",
+				"icon":        "💬",
+			},
+			{
+				"id":          "intermediate_conversation",
+				"name":        "中級会話",
+				"description": "より複雑で長い会話表現",
+				"icon":        "🗣️",
+			},
+		}
+	}
+
+	return categories
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+
+	"typing-game-backend/furigana"
+	"typing-game-backend/game"
+)
+
+func TestApplyModifiers(t *testing.T) {
+	words := []WordItem{
+		{WordID: "a", Word: "みず", Type: "normal", Language: "jp"},
+		{WordID: "b", Word: "ねこ", Type: "bonus", Language: "jp"},
+		{WordID: "c", Word: "いぬ", Type: "normal", Language: "jp"},
+		{WordID: "d", Word: "とり", Type: "normal", Language: "jp"},
+	}
+	original := slices.Clone(words)
+
+	got := applyModifiers(words, []game.WordModifier{game.ModifierCompound, game.ModifierNoBonus})
+	want := []string{"a+c:みずいぬ", "c+d:いぬとり", "d+a:とりみず"}
+	var gotWords []string
+	for _, w := range got {
+		gotWords = append(gotWords, w.WordID+":"+w.Word)
+	}
+	if !slices.Equal(gotWords, want) {
+		t.Errorf("words = %v, want %v", gotWords, want)
+	}
+	if !reflect.DeepEqual(words, original) {
+		t.Errorf("applyModifiers changed the cached words: %v", words)
+	}
+
+	jp := []WordItem{
+		{WordID: "a", Word: "みず", Display: "水", Furigana: []furigana.Segment{{Text: "水", Reading: "みず"}}, Type: "normal", Language: "jp"},
+		{WordID: "b", Word: "ねこ", Display: "猫", Furigana: []furigana.Segment{{Text: "猫", Reading: "ねこ"}}, Type: "normal", Language: "jp"},
+	}
+	if got := applyModifiers(jp, []game.WordModifier{game.ModifierCompound}); got[0].Display != "水猫" || furigana.Reading(got[0].Furigana) != "みずねこ" || len(jp[0].Furigana) != 1 {
+		t.Errorf("jp compound = %v, want the display and furigana joined", got[0])
+	}
+
+	en := []WordItem{{WordID: "a", Word: "cat", Type: "normal"}, {WordID: "b", Word: "dog", Type: "normal"}}
+	if got := applyModifiers(en, []game.WordModifier{game.ModifierCompound}); got[0].Word != "cat dog" || got[1].Word != "dog cat" {
+		t.Errorf("en compound = %v, want words separated by a space", got)
+	}
+}
+
+func TestPracticeWords(t *testing.T) {
+	words := []WordItem{{WordID: "a"}, {WordID: "b"}, {WordID: "c"}}
+	got := practiceWords(words)
+	if len(got) != game.PracticeWords || got[0].WordID != "a" || got[3].WordID != "a" || got[game.PracticeWords-1].WordID != "b" {
+		t.Errorf("practiceWords = %d words starting %v, want %d words cycling a, b, c", len(got), got[:4], game.PracticeWords)
+	}
+	if got := practiceWords(nil); got == nil || len(got) != 0 {
+		t.Errorf("practiceWords(nil) = %v, want an empty slice", got)
+	}
+}
+
+func TestFallbackWordsFurigana(t *testing.T) {
+	for _, category := range append(validCategories, "unknown") {
+		for _, w := range fallbackWords(category, 1, "jp") {
+			if err := furigana.Validate(w.Display, w.Word, w.Furigana); err != nil {
+				t.Errorf("%s %s: %v", category, w.Display, err)
+			}
+			if w.Word != furigana.ToHiragana(w.Word) {
+				t.Errorf("%s %s: word = %q, want hiragana", category, w.Display, w.Word)
+			}
+		}
+	}
+	if w := fallbackWords("beginner_words", 1, "en")[0]; w.Display != w.Word || w.Furigana != nil {
+		t.Errorf("en fallback word = %+v, want the word as its display and no furigana", w)
+	}
+}
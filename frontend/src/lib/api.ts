@@ -90,33 +90,33 @@ class ApiClient {
   }
 
   async healthCheck(): Promise<{ status: string; message: string }> {
-    return this.request('/api/health');
+    return this.request('/api/v1/health');
   }
 
   async submitScore(scoreData: ScoreData): Promise<ApiResponse<ScoreData>> {
-    return this.request('/api/game/score', {
+    return this.request('/api/v1/game/score', {
       method: 'POST',
       body: JSON.stringify(scoreData),
     });
   }
 
   async getLeaderboard(): Promise<ApiResponse<LeaderboardEntry[]>> {
-    return this.request('/api/game/leaderboard');
+    return this.request('/api/v1/game/leaderboard');
   }
 
   async getWords(category: string, round: number, language?: 'jp' | 'en'): Promise<ApiResponse<WordItem[]>> {
     const url = language 
-      ? `/api/game/words/${category}/${round}?language=${language}`
-      : `/api/game/words/${category}/${round}`;
+      ? `/api/v1/game/words/${category}/${round}?language=${language}`
+      : `/api/v1/game/words/${category}/${round}`;
     return this.request(url);
   }
 
   async getCategories(language: 'jp' | 'en' = 'jp'): Promise<ApiResponse<Category[]>> {
-    return this.request(`/api/game/categories?language=${language}`);
+    return this.request(`/api/v1/game/categories?language=${language}`);
   }
 
   async getTranslation(wordId: string, targetLanguage: 'jp' | 'en'): Promise<ApiResponse<{translation: string}>> {
-    return this.request(`/api/game/translation/${wordId}?language=${targetLanguage}`);
+    return this.request(`/api/v1/game/translation/${wordId}?language=${targetLanguage}`);
   }
 }
 
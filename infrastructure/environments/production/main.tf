@@ -57,8 +57,20 @@ module "lambda" {
   scores_table_arn = module.dynamodb.scores_table_arn
   leaderboard_table_name = module.dynamodb.leaderboard_table_name
   leaderboard_table_arn = module.dynamodb.leaderboard_table_arn
+  leaderboard_views_table_name = module.dynamodb.leaderboard_views_table_name
+  leaderboard_views_table_arn = module.dynamodb.leaderboard_views_table_arn
+  player_stats_table_name = module.dynamodb.player_stats_table_name
+  player_stats_table_arn = module.dynamodb.player_stats_table_arn
+  player_names_table_name = module.dynamodb.player_names_table_name
+  player_names_table_arn = module.dynamodb.player_names_table_arn
   words_table_name = module.dynamodb.words_table_name
   words_table_arn = module.dynamodb.words_table_arn
+  translations_table_name = module.dynamodb.translations_table_name
+  translations_table_arn = module.dynamodb.translations_table_arn
+  passages_table_name = module.dynamodb.passages_table_name
+  passages_table_arn = module.dynamodb.passages_table_arn
+  word_lists_table_name = module.dynamodb.word_lists_table_name
+  word_lists_table_arn = module.dynamodb.word_lists_table_arn
 }
 
 # API Gateway Module
@@ -23,11 +23,31 @@ output "leaderboard_table_name" {
   value       = module.dynamodb.leaderboard_table_name
 }
 
+output "leaderboard_views_table_name" {
+  description = "Leaderboard views DynamoDB table name"
+  value       = module.dynamodb.leaderboard_views_table_name
+}
+
 output "words_table_name" {
   description = "Words DynamoDB table name"
   value       = module.dynamodb.words_table_name
 }
 
+output "translations_table_name" {
+  description = "Translations DynamoDB table name"
+  value       = module.dynamodb.translations_table_name
+}
+
+output "passages_table_name" {
+  description = "Passages DynamoDB table name"
+  value       = module.dynamodb.passages_table_name
+}
+
+output "word_lists_table_name" {
+  description = "Custom word lists DynamoDB table name"
+  value       = module.dynamodb.word_lists_table_name
+}
+
 output "lambda_function_name" {
   description = "Lambda function name"
   value       = module.lambda.lambda_function_name
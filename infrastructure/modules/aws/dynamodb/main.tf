@@ -33,6 +33,12 @@ resource "aws_dynamodb_table" "scores" {
     type = "S"
   }
 
+  # 保持期間（SCORE_RETENTION_DAYS）を過ぎたスコアを削除する
+  ttl {
+    attribute_name = "expires_at"
+    enabled        = true
+  }
+
   tags = {
     Name        = "${var.project_name}-scores-${var.environment}"
     Environment = var.environment
@@ -58,6 +64,86 @@ resource "aws_dynamodb_table" "leaderboard" {
   }
 }
 
+# DynamoDB Table for Leaderboard Views (per category / per period)
+# view: "category#<category>", "weekly#2025-W01", "monthly#2025-01"
+resource "aws_dynamodb_table" "leaderboard_views" {
+  name           = "${var.project_name}-leaderboard-views-${var.environment}"
+  billing_mode   = "PAY_PER_REQUEST"
+  hash_key       = "view"
+  range_key      = "player_name"
+
+  attribute {
+    name = "view"
+    type = "S"
+  }
+
+  attribute {
+    name = "player_name"
+    type = "S"
+  }
+
+  attribute {
+    name = "score"
+    type = "N"
+  }
+
+  # ビューごとにスコア順で上位を取得する
+  local_secondary_index {
+    name            = "ViewScoreIndex"
+    range_key       = "score"
+    projection_type = "ALL"
+  }
+
+  # プレイヤーのデータのエクスポート・削除用（キーのみ）
+  global_secondary_index {
+    name            = "PlayerIndex"
+    hash_key        = "player_name"
+    projection_type = "KEYS_ONLY"
+  }
+
+  tags = {
+    Name        = "${var.project_name}-leaderboard-views-${var.environment}"
+    Environment = var.environment
+    Project     = var.project_name
+  }
+}
+
+# DynamoDB Table for Player Stats (per-player totals kept after scores expire)
+resource "aws_dynamodb_table" "player_stats" {
+  name           = "${var.project_name}-player-stats-${var.environment}"
+  billing_mode   = "PAY_PER_REQUEST"
+  hash_key       = "player_name"
+
+  attribute {
+    name = "player_name"
+    type = "S"
+  }
+
+  tags = {
+    Name        = "${var.project_name}-player-stats-${var.environment}"
+    Environment = var.environment
+    Project     = var.project_name
+  }
+}
+
+# DynamoDB Table for Player Names (confusable-name skeleton -> owning player)
+resource "aws_dynamodb_table" "player_names" {
+  name           = "${var.project_name}-player-names-${var.environment}"
+  billing_mode   = "PAY_PER_REQUEST"
+  hash_key       = "name_key"
+
+  attribute {
+    name = "name_key"
+    type = "S"
+  }
+
+  tags = {
+    Name        = "${var.project_name}-player-names-${var.environment}"
+    Environment = var.environment
+    Project     = var.project_name
+  }
+}
+
 # DynamoDB Table for Words
 resource "aws_dynamodb_table" "words" {
   name           = "${var.project_name}-words-${var.environment}"
@@ -76,14 +162,14 @@ resource "aws_dynamodb_table" "words" {
   }
 
   attribute {
-    name = "round"
-    type = "N"
+    name = "lookup_key"
+    type = "S"
   }
 
-  # Global Secondary Index for round-based queries
+  # Global Secondary Index for category#language#round queries
   global_secondary_index {
-    name     = "RoundIndex"
-    hash_key = "round"
+    name     = "LookupIndex"
+    hash_key = "lookup_key"
     range_key = "word_id"
     projection_type = "ALL"
   }
@@ -93,4 +179,76 @@ resource "aws_dynamodb_table" "words" {
     Environment = var.environment
     Project     = var.project_name
   }
-}
\ No newline at end of file
+}
+
+# DynamoDB Table for Translations (word translations per target language)
+resource "aws_dynamodb_table" "translations" {
+  name           = "${var.project_name}-translations-${var.environment}"
+  billing_mode   = "PAY_PER_REQUEST"
+  hash_key       = "word_id"
+  range_key      = "language"
+
+  attribute {
+    name = "word_id"
+    type = "S"
+  }
+
+  attribute {
+    name = "language"
+    type = "S"
+  }
+
+  tags = {
+    Name        = "${var.project_name}-translations-${var.environment}"
+    Environment = var.environment
+    Project     = var.project_name
+  }
+}
+
+# DynamoDB Table for Passages (multi-sentence texts for the passage mode)
+resource "aws_dynamodb_table" "passages" {
+  name           = "${var.project_name}-passages-${var.environment}"
+  billing_mode   = "PAY_PER_REQUEST"
+  hash_key       = "passage_id"
+
+  attribute {
+    name = "passage_id"
+    type = "S"
+  }
+
+  attribute {
+    name = "language"
+    type = "S"
+  }
+
+  # 言語ごとに文章を一覧する
+  global_secondary_index {
+    name            = "LanguageIndex"
+    hash_key        = "language"
+    range_key       = "passage_id"
+    projection_type = "ALL"
+  }
+
+  tags = {
+    Name        = "${var.project_name}-passages-${var.environment}"
+    Environment = var.environment
+    Project     = var.project_name
+  }
+}
+
+resource "aws_dynamodb_table" "word_lists" {
+  name           = "${var.project_name}-word-lists-${var.environment}"
+  billing_mode   = "PAY_PER_REQUEST"
+  hash_key       = "list_id"
+
+  attribute {
+    name = "list_id"
+    type = "S"
+  }
+
+  tags = {
+    Name        = "${var.project_name}-word-lists-${var.environment}"
+    Environment = var.environment
+    Project     = var.project_name
+  }
+}
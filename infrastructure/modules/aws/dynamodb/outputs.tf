@@ -18,6 +18,36 @@ output "leaderboard_table_arn" {
   value       = aws_dynamodb_table.leaderboard.arn
 }
 
+output "leaderboard_views_table_name" {
+  description = "Name of the leaderboard views DynamoDB table"
+  value       = aws_dynamodb_table.leaderboard_views.name
+}
+
+output "leaderboard_views_table_arn" {
+  description = "ARN of the leaderboard views DynamoDB table"
+  value       = aws_dynamodb_table.leaderboard_views.arn
+}
+
+output "player_stats_table_name" {
+  description = "Name of the player stats DynamoDB table"
+  value       = aws_dynamodb_table.player_stats.name
+}
+
+output "player_stats_table_arn" {
+  description = "ARN of the player stats DynamoDB table"
+  value       = aws_dynamodb_table.player_stats.arn
+}
+
+output "player_names_table_name" {
+  description = "Name of the player names DynamoDB table"
+  value       = aws_dynamodb_table.player_names.name
+}
+
+output "player_names_table_arn" {
+  description = "ARN of the player names DynamoDB table"
+  value       = aws_dynamodb_table.player_names.arn
+}
+
 output "words_table_name" {
   description = "Name of the words DynamoDB table"
   value       = aws_dynamodb_table.words.name
@@ -26,4 +56,34 @@ output "words_table_name" {
 output "words_table_arn" {
   description = "ARN of the words DynamoDB table"
   value       = aws_dynamodb_table.words.arn
-}
\ No newline at end of file
+}
+
+output "translations_table_name" {
+  description = "Name of the translations DynamoDB table"
+  value       = aws_dynamodb_table.translations.name
+}
+
+output "translations_table_arn" {
+  description = "ARN of the translations DynamoDB table"
+  value       = aws_dynamodb_table.translations.arn
+}
+
+output "passages_table_name" {
+  description = "Name of the passages DynamoDB table"
+  value       = aws_dynamodb_table.passages.name
+}
+
+output "passages_table_arn" {
+  description = "ARN of the passages DynamoDB table"
+  value       = aws_dynamodb_table.passages.arn
+}
+
+output "word_lists_table_name" {
+  description = "Name of the custom word lists DynamoDB table"
+  value       = aws_dynamodb_table.word_lists.name
+}
+
+output "word_lists_table_arn" {
+  description = "ARN of the custom word lists DynamoDB table"
+  value       = aws_dynamodb_table.word_lists.arn
+}
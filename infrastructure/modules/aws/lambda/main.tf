@@ -47,15 +47,28 @@ resource "aws_iam_role_policy" "lambda_policy" {
           "dynamodb:Query",
           "dynamodb:Scan",
           "dynamodb:UpdateItem",
-          "dynamodb:DeleteItem"
+          "dynamodb:DeleteItem",
+          "dynamodb:DescribeTable"
         ]
         Resource = [
           var.scores_table_arn,
           "${var.scores_table_arn}/*",
           var.leaderboard_table_arn,
           "${var.leaderboard_table_arn}/*",
+          var.leaderboard_views_table_arn,
+          "${var.leaderboard_views_table_arn}/*",
+          var.player_stats_table_arn,
+          "${var.player_stats_table_arn}/*",
+          var.player_names_table_arn,
+          "${var.player_names_table_arn}/*",
           var.words_table_arn,
-          "${var.words_table_arn}/*"
+          "${var.words_table_arn}/*",
+          var.translations_table_arn,
+          "${var.translations_table_arn}/*",
+          var.passages_table_arn,
+          "${var.passages_table_arn}/*",
+          var.word_lists_table_arn,
+          "${var.word_lists_table_arn}/*"
         ]
       },
       {
@@ -100,7 +113,14 @@ resource "aws_lambda_function" "api" {
     variables = {
       SCORES_TABLE_NAME      = var.scores_table_name
       LEADERBOARD_TABLE_NAME = var.leaderboard_table_name
+      LEADERBOARD_VIEWS_TABLE_NAME = var.leaderboard_views_table_name
+      PLAYER_STATS_TABLE_NAME = var.player_stats_table_name
+      PLAYER_NAMES_TABLE_NAME = var.player_names_table_name
+      SCORE_RETENTION_DAYS = tostring(var.score_retention_days)
       WORDS_TABLE_NAME       = var.words_table_name
+      TRANSLATIONS_TABLE_NAME = var.translations_table_name
+      PASSAGES_TABLE_NAME    = var.passages_table_name
+      WORD_LISTS_TABLE_NAME  = var.word_lists_table_name
       ENVIRONMENT           = var.environment
     }
   }
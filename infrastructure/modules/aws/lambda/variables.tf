@@ -37,6 +37,42 @@ variable "leaderboard_table_arn" {
   type        = string
 }
 
+variable "leaderboard_views_table_name" {
+  description = "Name of the leaderboard views DynamoDB table"
+  type        = string
+}
+
+variable "leaderboard_views_table_arn" {
+  description = "ARN of the leaderboard views DynamoDB table"
+  type        = string
+}
+
+variable "player_stats_table_name" {
+  description = "Name of the player stats DynamoDB table"
+  type        = string
+}
+
+variable "player_stats_table_arn" {
+  description = "ARN of the player stats DynamoDB table"
+  type        = string
+}
+
+variable "player_names_table_name" {
+  description = "Name of the player names DynamoDB table"
+  type        = string
+}
+
+variable "player_names_table_arn" {
+  description = "ARN of the player names DynamoDB table"
+  type        = string
+}
+
+variable "score_retention_days" {
+  description = "Days to keep per-game score rows before they expire via TTL"
+  type        = number
+  default     = 365
+}
+
 variable "words_table_name" {
   description = "Name of the words DynamoDB table"
   type        = string
@@ -45,4 +81,34 @@ variable "words_table_name" {
 variable "words_table_arn" {
   description = "ARN of the words DynamoDB table"
   type        = string
-}
\ No newline at end of file
+}
+
+variable "translations_table_name" {
+  description = "Name of the translations DynamoDB table"
+  type        = string
+}
+
+variable "translations_table_arn" {
+  description = "ARN of the translations DynamoDB table"
+  type        = string
+}
+
+variable "passages_table_name" {
+  description = "Name of the passages DynamoDB table"
+  type        = string
+}
+
+variable "passages_table_arn" {
+  description = "ARN of the passages DynamoDB table"
+  type        = string
+}
+
+variable "word_lists_table_name" {
+  description = "Name of the custom word lists DynamoDB table"
+  type        = string
+}
+
+variable "word_lists_table_arn" {
+  description = "ARN of the custom word lists DynamoDB table"
+  type        = string
+}
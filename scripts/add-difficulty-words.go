@@ -13,12 +13,13 @@ import (
 )
 
 type WordItem struct {
-	Category string `dynamodbav:"category"`
-	WordID   string `dynamodbav:"word_id"`
-	Word     string `dynamodbav:"word"`
-	Round    int    `dynamodbav:"round"`
-	Type     string `dynamodbav:"type"` // "normal", "bonus", "debuff"
-	Language string `dynamodbav:"language"`
+	Category  string `dynamodbav:"category"`
+	WordID    string `dynamodbav:"word_id"`
+	Word      string `dynamodbav:"word"`
+	Round     int    `dynamodbav:"round"`
+	Type      string `dynamodbav:"type"` // "normal", "bonus", "debuff"
+	Language  string `dynamodbav:"language"`
+	LookupKey string `dynamodbav:"lookup_key"` // category#language#round
 }
 
 // ラウンド別の単語リスト（段階的難易度アップ）
@@ -180,12 +181,13 @@ func main() {
 				// 通常単語を追加
 				for i, word := range words {
 					wordItem := WordItem{
-						Category: category,
-						WordID:   fmt.Sprintf("%s_%s_%d_%03d", category, language, round, i+1),
-						Word:     word,
-						Round:    round,
-						Type:     "normal",
-						Language: language,
+						Category:  category,
+						WordID:    fmt.Sprintf("%s_%s_%d_%03d", category, language, round, i+1),
+						Word:      word,
+						Round:     round,
+						Type:      "normal",
+						Language:  language,
+						LookupKey: fmt.Sprintf("%s#%s#%d", category, language, round),
 					}
 
 					err := addWordToDynamoDB(dynamoClient, tableName, wordItem)
@@ -200,12 +202,13 @@ func main() {
 					if bonusWords, exists := specialLang["bonus"][round]; exists {
 						for i, word := range bonusWords {
 							wordItem := WordItem{
-								Category: category,
-								WordID:   fmt.Sprintf("%s_%s_%d_bonus_%03d", category, language, round, i+1),
-								Word:     word,
-								Round:    round,
-								Type:     "bonus",
-								Language: language,
+								Category:  category,
+								WordID:    fmt.Sprintf("%s_%s_%d_bonus_%03d", category, language, round, i+1),
+								Word:      word,
+								Round:     round,
+								Type:      "bonus",
+								Language:  language,
+								LookupKey: fmt.Sprintf("%s#%s#%d", category, language, round),
 							}
 
 							err := addWordToDynamoDB(dynamoClient, tableName, wordItem)
@@ -219,12 +222,13 @@ func main() {
 					if debuffWords, exists := specialLang["debuff"][round]; exists {
 						for i, word := range debuffWords {
 							wordItem := WordItem{
-								Category: category,
-								WordID:   fmt.Sprintf("%s_%s_%d_debuff_%03d", category, language, round, i+1),
-								Word:     word,
-								Round:    round,
-								Type:     "debuff",
-								Language: language,
+								Category:  category,
+								WordID:    fmt.Sprintf("%s_%s_%d_debuff_%03d", category, language, round, i+1),
+								Word:      word,
+								Round:     round,
+								Type:      "debuff",
+								Language:  language,
+								LookupKey: fmt.Sprintf("%s#%s#%d", category, language, round),
 							}
 
 							err := addWordToDynamoDB(dynamoClient, tableName, wordItem)
@@ -14,12 +14,13 @@ import (
 )
 
 type WordItem struct {
-	Category string `dynamodbav:"category"`
-	WordID   string `dynamodbav:"word_id"`
-	Word     string `dynamodbav:"word"`
-	Round    int    `dynamodbav:"round"`
-	Type     string `dynamodbav:"type"`
-	Language string `dynamodbav:"language"`
+	Category  string `dynamodbav:"category"`
+	WordID    string `dynamodbav:"word_id"`
+	Word      string `dynamodbav:"word"`
+	Round     int    `dynamodbav:"round"`
+	Type      string `dynamodbav:"type"`
+	Language  string `dynamodbav:"language"`
+	LookupKey string `dynamodbav:"lookup_key"` // category#language#round
 }
 
 // 拡充された単語データ
@@ -208,12 +209,13 @@ func
 					wordID := fmt.Sprintf("%s_%s_%d_%03d", category, language, round, i+1)
 					
 					wordItem := WordItem{
-						Category: category,
-						WordID:   wordID,
-						Word:     word,
-						Round:    round,
-						Type:     "normal",
-						Language: language,
+						Category:  category,
+						WordID:    wordID,
+						Word:      word,
+						Round:     round,
+						Type:      "normal",
+						Language:  language,
+						LookupKey: fmt.Sprintf("%s#%s#%d", category, language, round),
 					}
 
 					item, err := attributevalue.MarshalMap(wordItem)
@@ -262,12 +264,13 @@ func
 				wordID := fmt.Sprintf("special_%s_%s_%03d", language, wordType, i+1)
 				
 				wordItem := WordItem{
-					Category: "special",
-					WordID:   wordID,
-					Word:     word,
-					Round:    0,
-					Type:     wordType,
-					Language: language,
+					Category:  "special",
+					WordID:    wordID,
+					Word:      word,
+					Round:     0,
+					Type:      wordType,
+					Language:  language,
+					LookupKey: fmt.Sprintf("%s#%s#%d", "special", language, 0),
 				}
 
 				item, err := attributevalue.MarshalMap(wordItem)
@@ -13,12 +13,13 @@ import (
 )
 
 type WordItem struct {
-	Category string `dynamodbav:"category"`
-	WordID   string `dynamodbav:"word_id"`
-	Word     string `dynamodbav:"word"`
-	Round    int    `dynamodbav:"round"`
-	Type     string `dynamodbav:"type"`
-	Language string `dynamodbav:"language"`
+	Category  string `dynamodbav:"category"`
+	WordID    string `dynamodbav:"word_id"`
+	Word      string `dynamodbav:"word"`
+	Round     int    `dynamodbav:"round"`
+	Type      string `dynamodbav:"type"`
+	Language  string `dynamodbav:"language"`
+	LookupKey string `dynamodbav:"lookup_key"` // category#language#round
 }
 
 // 単語データ - カテゴリー別（日本語）
@@ -674,12 +675,13 @@ func main() {
 		for round, words := range rounds {
 			for i, word := range words {
 				item := WordItem{
-					Category: category,
-					WordID:   fmt.Sprintf("%s_jp_%d_%03d", category, round, i+1),
-					Word:     word,
-					Round:    round,
-					Type:     "normal",
-					Language: "jp",
+					Category:  category,
+					WordID:    fmt.Sprintf("%s_jp_%d_%03d", category, round, i+1),
+					Word:      word,
+					Round:     round,
+					Type:      "normal",
+					Language:  "jp",
+					LookupKey: fmt.Sprintf("%s#%s#%d", category, "jp", round),
 				}
 
 				av, err := attributevalue.MarshalMap(item)
@@ -707,12 +709,13 @@ func main() {
 		for round, words := range rounds {
 			for i, word := range words {
 				item := WordItem{
-					Category: category,
-					WordID:   fmt.Sprintf("%s_en_%d_%03d", category, round, i+1),
-					Word:     word,
-					Round:    round,
-					Type:     "normal",
-					Language: "en",
+					Category:  category,
+					WordID:    fmt.Sprintf("%s_en_%d_%03d", category, round, i+1),
+					Word:      word,
+					Round:     round,
+					Type:      "normal",
+					Language:  "en",
+					LookupKey: fmt.Sprintf("%s#%s#%d", category, "en", round),
 				}
 
 				av, err := attributevalue.MarshalMap(item)
@@ -739,12 +742,13 @@ func main() {
 	for wordType, words := range SPECIAL_WORDS_JP {
 		for i, word := range words {
 			item := WordItem{
-				Category: "special",
-				WordID:   fmt.Sprintf("special_jp_%s_%03d", wordType, i+1),
-				Word:     word,
-				Round:    0, // 特殊単語は全ラウンドで使用
-				Type:     wordType,
-				Language: "jp",
+				Category:  "special",
+				WordID:    fmt.Sprintf("special_jp_%s_%03d", wordType, i+1),
+				Word:      word,
+				Round:     0, // 特殊単語は全ラウンドで使用
+				Type:      wordType,
+				Language:  "jp",
+				LookupKey: fmt.Sprintf("%s#%s#%d", "special", "jp", 0),
 			}
 
 			av, err := attributevalue.MarshalMap(item)
@@ -770,12 +774,13 @@ func main() {
 	for wordType, words := range SPECIAL_WORDS_EN {
 		for i, word := range words {
 			item := WordItem{
-				Category: "special",
-				WordID:   fmt.Sprintf("special_en_%s_%03d", wordType, i+1),
-				Word:     word,
-				Round:    0, // 特殊単語は全ラウンドで使用
-				Type:     wordType,
-				Language: "en",
+				Category:  "special",
+				WordID:    fmt.Sprintf("special_en_%s_%03d", wordType, i+1),
+				Word:      word,
+				Round:     0, // 特殊単語は全ラウンドで使用
+				Type:      wordType,
+				Language:  "en",
+				LookupKey: fmt.Sprintf("%s#%s#%d", "special", "en", 0),
 			}
 
 			av, err := attributevalue.MarshalMap(item)
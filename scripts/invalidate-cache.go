@@ -0,0 +1,49 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// 単語・翻訳を書き込んだ後に、バックエンドのコンテンツキャッシュを破棄する。
+// 書き込み系スクリプトの実行後に続けて実行する。
+//
+// Usage:
+//   ADMIN_API_KEY=... go run invalidate-cache.go -api https://your-api-id.execute-api.ap-northeast-1.amazonaws.com/production
+
+func main() {
+	apiURL := flag.String("api", "http://localhost:8080", "API base URL")
+	flag.Parse()
+
+	adminKey := os.Getenv("ADMIN_API_KEY")
+	if adminKey == "" {
+		log.Fatal("ADMIN_API_KEY environment variable not set")
+	}
+
+	endpoint := strings.TrimRight(*apiURL, "/") + "/api/admin/cache/invalidate"
+	req, err := http.NewRequest(http.MethodPost, endpoint, nil)
+	if err != nil {
+		log.Fatalf("failed to build request: %v", err)
+	}
+	req.Header.Set("X-Admin-Key", adminKey)
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		log.Fatalf("cache invalidation request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		log.Fatalf("cache invalidation failed: %s %s", resp.Status, body)
+	}
+
+	fmt.Printf("Cache invalidated: %s\n", body)
+}
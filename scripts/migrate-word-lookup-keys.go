@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// words テーブルの既存アイテムに lookup_key（category#language#round）をバックフィルする。
+// LookupIndex はこの属性を持つアイテムのみをインデックスするため、
+// terraform apply でGSIを作成した後に一度実行する。
+//
+// Usage:
+//   go run migrate-word-lookup-keys.go -table typing-game-words-production -mode dry-run
+//   go run migrate-word-lookup-keys.go -table typing-game-words-production -mode commit
+
+type WordItem struct {
+	Category  string `dynamodbav:"category"`
+	WordID    string `dynamodbav:"word_id"`
+	Word      string `dynamodbav:"word"`
+	Round     int    `dynamodbav:"round"`
+	Type      string `dynamodbav:"type"`
+	Language  string `dynamodbav:"language"`
+	LookupKey string `dynamodbav:"lookup_key,omitempty"`
+}
+
+func main() {
+	var (
+		tableName = flag.String("table", "typing-game-words-production", "DynamoDB words table")
+		region    = flag.String("region", "ap-northeast-1", "AWS region")
+		mode      = flag.String("mode", "dry-run", "dry-run or commit")
+	)
+	flag.Parse()
+
+	if *mode != "dry-run" && *mode != "commit" {
+		log.Fatalf("invalid mode %q (expected dry-run or commit)", *mode)
+	}
+
+	ctx := context.Background()
+
+	cfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(*region))
+	if err != nil {
+		log.Fatalf("failed to load AWS config: %v", err)
+	}
+
+	client := dynamodb.NewFromConfig(cfg)
+
+	fmt.Printf("Backfilling lookup_key in table: %s (mode: %s)\n", *tableName, *mode)
+
+	var scanned, pending, updated, failed int
+	paginator := dynamodb.NewScanPaginator(client, &dynamodb.ScanInput{
+		TableName: aws.String(*tableName),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			log.Fatalf("scan words failed: %v", err)
+		}
+
+		var words []WordItem
+		if err := attributevalue.UnmarshalListOfMaps(page.Items, &words); err != nil {
+			log.Fatalf("unmarshal words failed: %v", err)
+		}
+
+		for _, w := range words {
+			scanned++
+
+			key := fmt.Sprintf("%s#%s#%d", w.Category, w.Language, w.Round)
+			if w.LookupKey == key {
+				continue
+			}
+			pending++
+
+			if *mode == "dry-run" {
+				fmt.Printf("  %s/%s: %q -> %q\n", w.Category, w.WordID, w.LookupKey, key)
+				continue
+			}
+
+			_, err := client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+				TableName: aws.String(*tableName),
+				Key: map[string]types.AttributeValue{
+					"category": &types.AttributeValueMemberS{Value: w.Category},
+					"word_id":  &types.AttributeValueMemberS{Value: w.WordID},
+				},
+				UpdateExpression: aws.String("SET lookup_key = :lookup_key"),
+				ExpressionAttributeValues: map[string]types.AttributeValue{
+					":lookup_key": &types.AttributeValueMemberS{Value: key},
+				},
+			})
+			if err != nil {
+				log.Printf("Failed to update %s/%s: %v", w.Category, w.WordID, err)
+				failed++
+				continue
+			}
+			updated++
+		}
+	}
+
+	fmt.Printf("\nScanned: %d, needing update: %d, updated: %d, failed: %d\n", scanned, pending, updated, failed)
+	if *mode == "dry-run" && pending > 0 {
+		fmt.Println("Run again with -mode commit to apply.")
+	}
+}
@@ -14,12 +14,13 @@ import (
 )
 
 type WordItem struct {
-	Category string `dynamodbav:"category"`
-	WordID   string `dynamodbav:"word_id"`
-	Word     string `dynamodbav:"word"`
-	Round    int    `dynamodbav:"round"`
-	Type     string `dynamodbav:"type"`
-	Language string `dynamodbav:"language"`
+	Category  string `dynamodbav:"category"`
+	WordID    string `dynamodbav:"word_id"`
+	Word      string `dynamodbav:"word"`
+	Round     int    `dynamodbav:"round"`
+	Type      string `dynamodbav:"type"`
+	Language  string `dynamodbav:"language"`
+	LookupKey string `dynamodbav:"lookup_key"` // category#language#round
 }
 
 func main() {
@@ -58,12 +59,13 @@ func main() {
 
 	for _, w := range words {
 		wordItem := WordItem{
-			Category: w.category,
-			WordID:   w.wordID,
-			Word:     w.word,
-			Round:    w.round,
-			Type:     w.wordType,
-			Language: w.language,
+			Category:  w.category,
+			WordID:    w.wordID,
+			Word:      w.word,
+			Round:     w.round,
+			Type:      w.wordType,
+			Language:  w.language,
+			LookupKey: fmt.Sprintf("%s#%s#%d", w.category, w.language, w.round),
 		}
 
 		item, err := attributevalue.MarshalMap(wordItem)